@@ -0,0 +1,228 @@
+package aoscx
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aruba/aoscxgo"
+)
+
+// acl is an access control list as stored under system/acls, keyed by name
+// and type (ipv4, ipv6 or mac). aoscxgo does not cover ACLs.
+type acl struct {
+	Name string `json:"name"`
+	Type string `json:"list_type"`
+
+	// Entries are stored in the cfg_aces child collection
+	Entries []aclEntry `json:"-"`
+}
+
+// aclEntry is an entry (ACE) of the cfg_aces collection of an ACL. Zero
+// values mean the ACE matches any value of the field.
+type aclEntry struct {
+	Sequence   int    `json:"sequence_number,omitempty"`
+	Action     string `json:"action"`
+	Protocol   int    `json:"protocol,omitempty"`
+	Ethertype  int    `json:"ethertype,omitempty"`
+	SrcIp      string `json:"src_ip,omitempty"`
+	DstIp      string `json:"dst_ip,omitempty"`
+	SrcMac     string `json:"src_mac,omitempty"`
+	DstMac     string `json:"dst_mac,omitempty"`
+	SrcPortMin int    `json:"src_l4_port_min,omitempty"`
+	SrcPortMax int    `json:"src_l4_port_max,omitempty"`
+	DstPortMin int    `json:"dst_l4_port_min,omitempty"`
+	DstPortMax int    `json:"dst_l4_port_max,omitempty"`
+	Count      bool   `json:"count,omitempty"`
+	Log        bool   `json:"log,omitempty"`
+}
+
+// aclKey returns the REST key of the ACL name of type acl_type.
+func aclKey(name string, acl_type string) string {
+	return name + "," + acl_type
+}
+
+func aclPath(name string, acl_type string) string {
+	return "system/acls/" + restPath(aclKey(name, acl_type))
+}
+
+func (a *acl) path() string {
+	return aclPath(a.Name, a.Type)
+}
+
+func (a *acl) entryPath(sequence int) string {
+	return a.path() + "/cfg_aces/" + strconv.Itoa(sequence)
+}
+
+// bumpVersion makes the switch apply the changed entries of the ACL, changes
+// are only programmed into hardware when cfg_version changes.
+func (a *acl) bumpVersion(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodPatch, a.path(), map[string]interface{}{
+		"cfg_version": time.Now().UnixNano(),
+	}, nil)
+}
+
+// Create creates the ACL and its entries. It returns whether the ACL itself
+// was created.
+func (a *acl) Create(ctx context.Context, sw *aoscxgo.Client) (bool, error) {
+	err := restRequest(ctx, sw, http.MethodPost, "system/acls", a, nil)
+	if err != nil {
+		return false, err
+	}
+
+	for _, entry := range a.Entries {
+		err = restRequest(ctx, sw, http.MethodPost, a.path()+"/cfg_aces", entry, nil)
+		if err != nil {
+			return true, err
+		}
+	}
+
+	return true, a.bumpVersion(ctx, sw)
+}
+
+// Get retrieves the ACL a.Name of type a.Type and its entries ordered by
+// sequence number.
+func (a *acl) Get(ctx context.Context, sw *aoscxgo.Client) error {
+	tmp_acl := acl{}
+
+	err := restGet(ctx, sw, a.path(), &tmp_acl)
+	if err != nil {
+		return err
+	}
+
+	entries := map[string]aclEntry{}
+
+	err = restGet(ctx, sw, a.path()+"/cfg_aces?depth=2", &entries)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+
+	a.Entries = []aclEntry{}
+	for key, entry := range entries {
+		if entry.Sequence == 0 {
+			entry.Sequence, _ = strconv.Atoi(key)
+		}
+		a.Entries = append(a.Entries, entry)
+	}
+	sort.Slice(a.Entries, func(i, j int) bool {
+		return a.Entries[i].Sequence < a.Entries[j].Sequence
+	})
+
+	return nil
+}
+
+// Update reconciles the entries of the ACL with a.Entries by sequence
+// number, entries that did not change are left alone.
+func (a *acl) Update(ctx context.Context, sw *aoscxgo.Client) error {
+	current := acl{Name: a.Name, Type: a.Type}
+	err := current.Get(ctx, sw)
+	if err != nil {
+		return err
+	}
+
+	existing := map[int]aclEntry{}
+	for _, entry := range current.Entries {
+		existing[entry.Sequence] = entry
+	}
+
+	changed := false
+
+	for _, entry := range a.Entries {
+		current_entry, ok := existing[entry.Sequence]
+		delete(existing, entry.Sequence)
+
+		if !ok {
+			err = restRequest(ctx, sw, http.MethodPost, a.path()+"/cfg_aces", entry, nil)
+		} else if current_entry != entry {
+			// The sequence number is the key of the entry and not writable
+			body := entry
+			body.Sequence = 0
+			err = restRequest(ctx, sw, http.MethodPut, a.entryPath(entry.Sequence), body, nil)
+		} else {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		changed = true
+	}
+
+	for sequence := range existing {
+		err = restRequest(ctx, sw, http.MethodDelete, a.entryPath(sequence), nil, nil)
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	return a.bumpVersion(ctx, sw)
+}
+
+// Delete deletes the ACL and its entries, it fails while the ACL is applied.
+func (a *acl) Delete(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodDelete, a.path(), nil, nil)
+}
+
+// aclApplication is an ACL applied in one direction on an interface or VLAN.
+// The ACL is referenced by an attribute of the target, e.g. aclv4_in_cfg,
+// which holds at most one ACL per type and direction.
+type aclApplication struct {
+	// Target is the path of the interface or VLAN
+	Target string
+	// Attribute is the name of the attribute referencing the ACL
+	Attribute string
+
+	AclName string
+	AclType string
+}
+
+// Apply references the ACL from the target, replacing the ACL applied before.
+func (a *aclApplication) Apply(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodPatch, a.Target, map[string]interface{}{
+		a.Attribute:              restURI(aclPath(a.AclName, a.AclType)),
+		a.Attribute + "_version": time.Now().UnixNano(),
+	}, nil)
+}
+
+// Get retrieves the ACL applied on the target, AclName is empty when there
+// is none.
+func (a *aclApplication) Get(ctx context.Context, sw *aoscxgo.Client) error {
+	tmp_target := map[string]interface{}{}
+
+	err := restGet(ctx, sw, a.Target+"?attributes="+a.Attribute, &tmp_target)
+	if err != nil {
+		return err
+	}
+
+	a.AclName = ""
+	a.AclType = ""
+
+	uri, _ := tmp_target[a.Attribute].(string)
+	if uri == "" {
+		return nil
+	}
+
+	// ACLs are referenced by their name,type key
+	key := uriKey(uri)
+	if index := strings.LastIndex(key, ","); index > 0 {
+		a.AclName = key[:index]
+		a.AclType = key[index+1:]
+	}
+
+	return nil
+}
+
+// Remove clears the ACL reference of the target.
+func (a *aclApplication) Remove(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodPatch, a.Target, map[string]interface{}{
+		a.Attribute:              nil,
+		a.Attribute + "_version": time.Now().UnixNano(),
+	}, nil)
+}
@@ -0,0 +1,100 @@
+package aoscx
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/aruba/aoscxgo"
+)
+
+// activeGateway is the VSX active gateway of a VLAN interface: both switches
+// of a VSX pair answer for the virtual IP addresses with the virtual MAC.
+// aoscxgo does not know these attributes and may clear them when it replaces
+// the interface, so they are written after the interface itself.
+type activeGateway struct {
+	Ipv4 []string
+	Ipv6 []string
+
+	// Ipv6LinkLocal is stored with the Ipv6 addresses on the switch
+	Ipv6LinkLocal string
+	Mac           string
+
+	// Ipv6Mac is the MAC of the IPv6 addresses read from the switch when it
+	// differs from the MAC of the IPv4 addresses, Update writes Mac to both
+	Ipv6Mac string
+}
+
+// activeGatewayResponse is the REST representation of an active gateway.
+type activeGatewayResponse struct {
+	Ipv4  []string `json:"vsx_virtual_ip4"`
+	MacV4 string   `json:"vsx_virtual_gw_mac_v4"`
+	Ipv6  []string `json:"vsx_virtual_ip6"`
+	MacV6 string   `json:"vsx_virtual_gw_mac_v6"`
+}
+
+func activeGatewayPath(interface_name string) string {
+	return "system/interfaces/" + restPath(interface_name)
+}
+
+// configured reports whether the active gateway has a virtual IP address.
+func (a *activeGateway) configured() bool {
+	return len(a.Ipv4) > 0 || len(a.Ipv6) > 0 || a.Ipv6LinkLocal != ""
+}
+
+// Get retrieves the active gateway of the interface, it has no virtual IP
+// addresses when none is configured.
+func (a *activeGateway) Get(ctx context.Context, sw *aoscxgo.Client, interface_name string) error {
+	tmp_gateway := activeGatewayResponse{}
+
+	err := restGet(ctx, sw, activeGatewayPath(interface_name)+"?attributes=vsx_virtual_ip4,vsx_virtual_gw_mac_v4,vsx_virtual_ip6,vsx_virtual_gw_mac_v6", &tmp_gateway)
+	if err != nil {
+		return err
+	}
+
+	a.Ipv4 = append([]string{}, tmp_gateway.Ipv4...)
+	a.Ipv6 = []string{}
+	a.Ipv6LinkLocal = ""
+	for _, address := range tmp_gateway.Ipv6 {
+		if ip := net.ParseIP(address); ip != nil && ip.IsLinkLocalUnicast() {
+			a.Ipv6LinkLocal = address
+		} else {
+			a.Ipv6 = append(a.Ipv6, address)
+		}
+	}
+
+	a.Mac = tmp_gateway.MacV4
+	a.Ipv6Mac = ""
+	if len(a.Ipv4) == 0 {
+		a.Mac = tmp_gateway.MacV6
+	} else if len(tmp_gateway.Ipv6) > 0 && tmp_gateway.MacV6 != tmp_gateway.MacV4 {
+		a.Ipv6Mac = tmp_gateway.MacV6
+	}
+
+	return nil
+}
+
+// Update writes the active gateway of the interface, an active gateway
+// without virtual IP addresses removes it.
+func (a *activeGateway) Update(ctx context.Context, sw *aoscxgo.Client, interface_name string) error {
+	body := map[string]interface{}{
+		"vsx_virtual_ip4":       []string{},
+		"vsx_virtual_gw_mac_v4": nil,
+		"vsx_virtual_ip6":       []string{},
+		"vsx_virtual_gw_mac_v6": nil,
+	}
+	if len(a.Ipv4) > 0 {
+		body["vsx_virtual_ip4"] = a.Ipv4
+		body["vsx_virtual_gw_mac_v4"] = a.Mac
+	}
+	if len(a.Ipv6) > 0 || a.Ipv6LinkLocal != "" {
+		ipv6 := []string{}
+		if a.Ipv6LinkLocal != "" {
+			ipv6 = append(ipv6, a.Ipv6LinkLocal)
+		}
+		body["vsx_virtual_ip6"] = append(ipv6, a.Ipv6...)
+		body["vsx_virtual_gw_mac_v6"] = a.Mac
+	}
+
+	return restRequest(ctx, sw, http.MethodPatch, activeGatewayPath(interface_name), body, nil)
+}
@@ -0,0 +1,380 @@
+package aoscx
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/aruba/aoscxgo"
+)
+
+// aoscxgo does not cover routing protocols, BGP is configured through the
+// REST API. A VRF has at most one BGP router, keyed by its ASN.
+
+func bgpRouterPath(vrf_name string, asn int) string {
+	return "system/vrfs/" + restPath(vrf_name) + "/bgp_routers/" + strconv.Itoa(asn)
+}
+
+func bgpNeighborPath(vrf_name string, asn int, neighbor string) string {
+	return bgpRouterPath(vrf_name, asn) + "/bgp_neighbors/" + restPath(neighbor)
+}
+
+// bgpRouter is the BGP router of a VRF.
+type bgpRouter struct {
+	Vrf string
+	Asn int
+
+	RouterId string
+}
+
+// bgpRouterResponse is the REST representation of a BGP router.
+type bgpRouterResponse struct {
+	RouterId string `json:"router_id"`
+}
+
+func (r *bgpRouter) path() string {
+	return bgpRouterPath(r.Vrf, r.Asn)
+}
+
+func (r *bgpRouter) body() map[string]interface{} {
+	body := map[string]interface{}{
+		"router_id": nil,
+	}
+	if r.RouterId != "" {
+		body["router_id"] = r.RouterId
+	}
+
+	return body
+}
+
+// Create creates the BGP router.
+func (r *bgpRouter) Create(ctx context.Context, sw *aoscxgo.Client) error {
+	body := r.body()
+	body["asn"] = r.Asn
+
+	return restRequest(ctx, sw, http.MethodPost, "system/vrfs/"+restPath(r.Vrf)+"/bgp_routers", body, nil)
+}
+
+// Get retrieves the BGP router r.Asn of r.Vrf.
+func (r *bgpRouter) Get(ctx context.Context, sw *aoscxgo.Client) error {
+	tmp_router := bgpRouterResponse{}
+
+	err := restGet(ctx, sw, r.path(), &tmp_router)
+	if err != nil {
+		return err
+	}
+
+	r.RouterId = tmp_router.RouterId
+
+	return nil
+}
+
+// Update writes the settings of the BGP router.
+func (r *bgpRouter) Update(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodPatch, r.path(), r.body(), nil)
+}
+
+// Delete deletes the BGP router with its neighbors.
+func (r *bgpRouter) Delete(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodDelete, r.path(), nil, nil)
+}
+
+// bgpNeighbor is a BGP neighbor or, when IsPeerGroup is set, a peer group
+// whose settings are inherited by the neighbors in PeerGroup. Password is
+// write only, the switch does not return it.
+type bgpNeighbor struct {
+	Vrf  string
+	Asn  int
+	Name string
+
+	IsPeerGroup  bool
+	RemoteAs     int
+	UpdateSource string
+	Password     string
+	Keepalive    int
+	Holdtime     int
+	Bfd          bool
+	PeerGroup    string
+}
+
+// bgpNeighborResponse is the REST representation of a BGP neighbor.
+// Activate and RouteMaps are keyed by address family and managed with the
+// address family.
+type bgpNeighborResponse struct {
+	Name           string                       `json:"ip_or_ifname_or_group_name"`
+	IsPeerGroup    bool                         `json:"is_peer_group"`
+	RemoteAs       int                          `json:"remote_as"`
+	LocalInterface string                       `json:"local_interface"`
+	Timers         map[string]int               `json:"timers"`
+	BfdEnable      bool                         `json:"bfd_enable"`
+	PeerGroup      string                       `json:"bgp_peer_group"`
+	Activate       map[string]bool              `json:"activate"`
+	RouteMaps      map[string]map[string]string `json:"route_maps"`
+}
+
+func (n *bgpNeighbor) path() string {
+	return bgpNeighborPath(n.Vrf, n.Asn, n.Name)
+}
+
+func (n *bgpNeighbor) body() map[string]interface{} {
+	body := map[string]interface{}{
+		"remote_as":       nil,
+		"local_interface": nil,
+		"password":        nil,
+		"timers": map[string]int{
+			"keepalive": n.Keepalive,
+			"holdtime":  n.Holdtime,
+		},
+		"bfd_enable":     n.Bfd,
+		"bgp_peer_group": nil,
+	}
+	if n.RemoteAs > 0 {
+		body["remote_as"] = n.RemoteAs
+	}
+	if n.UpdateSource != "" {
+		body["local_interface"] = restURI("system/interfaces/" + restPath(n.UpdateSource))
+	}
+	if n.Password != "" {
+		body["password"] = n.Password
+	}
+	if n.PeerGroup != "" {
+		body["bgp_peer_group"] = restURI(bgpNeighborPath(n.Vrf, n.Asn, n.PeerGroup))
+	}
+
+	return body
+}
+
+// Create creates the neighbor or peer group.
+func (n *bgpNeighbor) Create(ctx context.Context, sw *aoscxgo.Client) error {
+	body := n.body()
+	body["ip_or_ifname_or_group_name"] = n.Name
+	body["is_peer_group"] = n.IsPeerGroup
+
+	return restRequest(ctx, sw, http.MethodPost, bgpRouterPath(n.Vrf, n.Asn)+"/bgp_neighbors", body, nil)
+}
+
+// Get retrieves the neighbor n.Name, except its password.
+func (n *bgpNeighbor) Get(ctx context.Context, sw *aoscxgo.Client) error {
+	tmp_neighbor := bgpNeighborResponse{}
+
+	err := restGet(ctx, sw, n.path(), &tmp_neighbor)
+	if err != nil {
+		return err
+	}
+
+	n.IsPeerGroup = tmp_neighbor.IsPeerGroup
+	n.RemoteAs = tmp_neighbor.RemoteAs
+	n.UpdateSource = ""
+	if tmp_neighbor.LocalInterface != "" {
+		n.UpdateSource = uriKey(tmp_neighbor.LocalInterface)
+	}
+	n.Keepalive = tmp_neighbor.Timers["keepalive"]
+	n.Holdtime = tmp_neighbor.Timers["holdtime"]
+	n.Bfd = tmp_neighbor.BfdEnable
+	n.PeerGroup = ""
+	if tmp_neighbor.PeerGroup != "" {
+		n.PeerGroup = uriKey(tmp_neighbor.PeerGroup)
+	}
+
+	return nil
+}
+
+// Update writes the settings of the neighbor.
+func (n *bgpNeighbor) Update(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodPatch, n.path(), n.body(), nil)
+}
+
+// Delete deletes the neighbor.
+func (n *bgpNeighbor) Delete(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodDelete, n.path(), nil, nil)
+}
+
+// bgpAddressFamily is the configuration of an address family, e.g.
+// ipv4-unicast, of a BGP router. Networks and redistribution are stored on
+// the router and the neighbors activated in the family carry their route
+// maps, all keyed by address family.
+type bgpAddressFamily struct {
+	Vrf    string
+	Asn    int
+	Family string
+
+	Networks     []string
+	Redistribute []string
+	Neighbors    map[string]bgpAddressFamilyNeighbor
+}
+
+// bgpAddressFamilyNeighbor holds the route maps of a neighbor activated in
+// the address family, empty when none is bound.
+type bgpAddressFamilyNeighbor struct {
+	RouteMapIn  string
+	RouteMapOut string
+}
+
+// bgpRouterFamilies is the part of the REST representation of a BGP router
+// holding the address family settings.
+type bgpRouterFamilies struct {
+	Networks     map[string][]string `json:"networks"`
+	Redistribute map[string][]string `json:"redistribute"`
+}
+
+// get retrieves the address family settings of the router and its
+// neighbors.
+func (f *bgpAddressFamily) get(ctx context.Context, sw *aoscxgo.Client) (bgpRouterFamilies, map[string]bgpNeighborResponse, error) {
+	router_families := bgpRouterFamilies{}
+
+	err := restGet(ctx, sw, bgpRouterPath(f.Vrf, f.Asn)+"?attributes=networks,redistribute", &router_families)
+	if err != nil {
+		return router_families, nil, err
+	}
+
+	neighbors := map[string]bgpNeighborResponse{}
+
+	err = restGet(ctx, sw, bgpRouterPath(f.Vrf, f.Asn)+"/bgp_neighbors?depth=2", &neighbors)
+	if err != nil && !isNotFound(err) {
+		return router_families, nil, err
+	}
+
+	by_name := map[string]bgpNeighborResponse{}
+	for key, neighbor := range neighbors {
+		if neighbor.Name == "" {
+			neighbor.Name = uriKey(key)
+		}
+		by_name[neighbor.Name] = neighbor
+	}
+
+	return router_families, by_name, nil
+}
+
+// Get retrieves the address family f.Family of the BGP router.
+func (f *bgpAddressFamily) Get(ctx context.Context, sw *aoscxgo.Client) error {
+	router_families, neighbors, err := f.get(ctx, sw)
+	if err != nil {
+		return err
+	}
+
+	f.Networks = append([]string{}, router_families.Networks[f.Family]...)
+	sort.Strings(f.Networks)
+	f.Redistribute = append([]string{}, router_families.Redistribute[f.Family]...)
+	sort.Strings(f.Redistribute)
+
+	f.Neighbors = map[string]bgpAddressFamilyNeighbor{}
+	for name, neighbor := range neighbors {
+		if !neighbor.Activate[f.Family] {
+			continue
+		}
+		tmp_neighbor := bgpAddressFamilyNeighbor{}
+		if uri := neighbor.RouteMaps[f.Family]["in"]; uri != "" {
+			tmp_neighbor.RouteMapIn = uriKey(uri)
+		}
+		if uri := neighbor.RouteMaps[f.Family]["out"]; uri != "" {
+			tmp_neighbor.RouteMapOut = uriKey(uri)
+		}
+		f.Neighbors[name] = tmp_neighbor
+	}
+
+	return nil
+}
+
+// Update writes the address family, the settings of other address families
+// are kept. Neighbors missing from f.Neighbors are deactivated in the
+// family.
+func (f *bgpAddressFamily) Update(ctx context.Context, sw *aoscxgo.Client) error {
+	router_families, neighbors, err := f.get(ctx, sw)
+	if err != nil {
+		return err
+	}
+
+	// Address families are configured on existing neighbors only, check
+	// them before changing anything
+	for name := range f.Neighbors {
+		if _, ok := neighbors[name]; !ok {
+			return &apiError{
+				Method:     http.MethodPatch,
+				URI:        restURI(bgpNeighborPath(f.Vrf, f.Asn, name)),
+				StatusCode: http.StatusNotFound,
+				Status:     "404 Not Found",
+			}
+		}
+	}
+
+	if router_families.Networks == nil {
+		router_families.Networks = map[string][]string{}
+	}
+	if router_families.Redistribute == nil {
+		router_families.Redistribute = map[string][]string{}
+	}
+	delete(router_families.Networks, f.Family)
+	if len(f.Networks) > 0 {
+		router_families.Networks[f.Family] = f.Networks
+	}
+	delete(router_families.Redistribute, f.Family)
+	if len(f.Redistribute) > 0 {
+		router_families.Redistribute[f.Family] = f.Redistribute
+	}
+
+	err = restRequest(ctx, sw, http.MethodPatch, bgpRouterPath(f.Vrf, f.Asn), router_families, nil)
+	if err != nil {
+		return err
+	}
+
+	for name, tmp_neighbor := range f.Neighbors {
+		neighbor := neighbors[name]
+
+		route_maps := map[string]string{}
+		if tmp_neighbor.RouteMapIn != "" {
+			route_maps["in"] = routeMapURI(tmp_neighbor.RouteMapIn)
+		}
+		if tmp_neighbor.RouteMapOut != "" {
+			route_maps["out"] = routeMapURI(tmp_neighbor.RouteMapOut)
+		}
+
+		err = f.updateNeighbor(ctx, sw, neighbor, true, route_maps)
+		if err != nil {
+			return err
+		}
+	}
+
+	for name, neighbor := range neighbors {
+		if _, ok := f.Neighbors[name]; ok || (!neighbor.Activate[f.Family] && len(neighbor.RouteMaps[f.Family]) == 0) {
+			continue
+		}
+
+		err = f.updateNeighbor(ctx, sw, neighbor, false, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateNeighbor activates or deactivates neighbor in the address family and
+// replaces its route maps of the family.
+func (f *bgpAddressFamily) updateNeighbor(ctx context.Context, sw *aoscxgo.Client, neighbor bgpNeighborResponse, activate bool, route_maps map[string]string) error {
+	if neighbor.Activate == nil {
+		neighbor.Activate = map[string]bool{}
+	}
+	if neighbor.RouteMaps == nil {
+		neighbor.RouteMaps = map[string]map[string]string{}
+	}
+
+	neighbor.Activate[f.Family] = activate
+	if len(route_maps) > 0 {
+		neighbor.RouteMaps[f.Family] = route_maps
+	} else {
+		delete(neighbor.RouteMaps, f.Family)
+	}
+
+	return restRequest(ctx, sw, http.MethodPatch, bgpNeighborPath(f.Vrf, f.Asn, neighbor.Name), map[string]interface{}{
+		"activate":   neighbor.Activate,
+		"route_maps": neighbor.RouteMaps,
+	}, nil)
+}
+
+// Delete removes the networks and redistribution of the address family and
+// deactivates its neighbors.
+func (f *bgpAddressFamily) Delete(ctx context.Context, sw *aoscxgo.Client) error {
+	empty := bgpAddressFamily{Vrf: f.Vrf, Asn: f.Asn, Family: f.Family}
+	return empty.Update(ctx, sw)
+}
@@ -0,0 +1,197 @@
+package aoscx
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/aruba/aoscxgo"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// providerMeta is the value returned by providerConfigure and handed to every
+// resource and data source. It holds a pool of switch sessions keyed by
+// hostname which are logged in on first use.
+type providerMeta struct {
+	// hostname is the switch used by objects without a switch attribute
+	hostname string
+	// switches maps the aliases of the provider switches argument to hostnames
+	switches  map[string]string
+	username  string
+	password  string
+	transport http.RoundTripper
+
+	mu    sync.Mutex
+	conns map[string]*switchConn
+}
+
+// switchConn is a logged in switch of the pool.
+type switchConn struct {
+	client  *aoscxgo.Client
+	session *session
+}
+
+// resolve returns the hostname of switch_name, which is either an alias of
+// the provider switches argument or a hostname. An empty switch_name selects
+// the provider hostname.
+func (meta *providerMeta) resolve(switch_name string) string {
+	if switch_name == "" {
+		return meta.hostname
+	}
+	if hostname, ok := meta.switches[switch_name]; ok {
+		return hostname
+	}
+	return switch_name
+}
+
+// connect returns the session of hostname, logging in if this is the first
+// time the switch is used.
+func (meta *providerMeta) connect(hostname string) (*switchConn, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	meta.mu.Lock()
+	defer meta.mu.Unlock()
+
+	if conn, ok := meta.conns[hostname]; ok {
+		return conn, diags
+	}
+
+	sess := newSession(hostname, meta.username, meta.password, meta.transport)
+
+	sw, err := aoscxgo.Connect(
+		&aoscxgo.Client{
+			Hostname:  hostname,
+			Username:  meta.username,
+			Password:  meta.password,
+			Transport: sess.clientTransport(),
+		},
+	)
+
+	if err != nil {
+		if tls_diag := tlsErrorDiagnostic(hostname, err); tls_diag != nil {
+			return nil, append(diags, *tls_diag)
+		}
+		return nil, errorDiagnostics("Unable to log in to "+hostname, err, nil)
+	}
+
+	if (sw == nil) || (sw.Cookie == nil) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to create AOS-CX client",
+			Detail:   "Login to " + hostname + " did not return a session cookie",
+		})
+		return nil, diags
+	}
+
+	sess.register()
+
+	conn := &switchConn{
+		client:  sw,
+		session: sess,
+	}
+	meta.conns[hostname] = conn
+
+	return conn, diags
+}
+
+// connectDefault logs in to the provider hostname so configuration errors
+// are reported when the provider is configured.
+func (meta *providerMeta) connectDefault() diag.Diagnostics {
+	if meta.hostname == "" {
+		return nil
+	}
+
+	_, diags := meta.connect(meta.hostname)
+
+	return diags
+}
+
+// switchClient returns the aoscxgo client of the switch selected by the
+// switch attribute of d, bound to ctx so the deadline of the calling CRUD
+// function and cancellation by Terraform are applied to every request made
+// with it.
+func switchClient(ctx context.Context, d *schema.ResourceData, m interface{}) (*aoscxgo.Client, diag.Diagnostics) {
+	return namedSwitchClient(ctx, m, d.Get("switch").(string), cty.GetAttrPath("switch"))
+}
+
+// namedSwitchClient returns the aoscxgo client of switch_name, an alias of
+// the provider switches argument or a hostname, for resources managing more
+// than one switch. path is the attribute switch_name was taken from.
+func namedSwitchClient(ctx context.Context, m interface{}, switch_name string, path cty.Path) (*aoscxgo.Client, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	meta := m.(*providerMeta)
+
+	hostname := meta.resolve(switch_name)
+	if hostname == "" {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "No switch selected",
+			Detail:        "Set switch on the resource or hostname in the provider block",
+			AttributePath: path,
+		})
+		return nil, diags
+	}
+
+	conn, diags := meta.connect(hostname)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	tr := &http.Transport{}
+	tr.RegisterProtocol("https", &contextTransport{
+		ctx:  ctx,
+		next: conn.session,
+	})
+
+	sw := *conn.client
+	sw.Transport = tr
+
+	return &sw, diags
+}
+
+// contextTransport sends requests with ctx as their context. aoscxgo builds
+// its requests without a context, this is how CRUD contexts reach them.
+type contextTransport struct {
+	ctx  context.Context
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.next.RoundTrip(req.WithContext(t.ctx))
+}
+
+// switchSchema is the switch attribute shared by all resources and data
+// sources.
+func switchSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Description: "Switch the object is managed on, either a key of the provider switches map or a hostname. Defaults to the provider hostname",
+	}
+}
+
+// switchID appends the switch attribute of d to id so objects with the same
+// name on different switches get distinct IDs, e.g. 1/1/1@leaf1. id is
+// returned as is for objects on the provider hostname.
+func switchID(d *schema.ResourceData, id string) string {
+	if switch_name := d.Get("switch").(string); switch_name != "" {
+		return id + "@" + switch_name
+	}
+	return id
+}
+
+// parseSwitchID splits an ID built by switchID into the object ID and the
+// switch, the switch is empty when id does not contain one.
+func parseSwitchID(id string) (string, string) {
+	if index := strings.LastIndex(id, "@"); index >= 0 {
+		return id[:index], id[index+1:]
+	}
+	return id, ""
+}
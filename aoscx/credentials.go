@@ -0,0 +1,104 @@
+package aoscx
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// credentialsProfile holds the connection values of one profile of a
+// credentials file.
+type credentialsProfile struct {
+	Hostname string `yaml:"hostname"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// readCredentialsProfile loads profile from the credentials file at path.
+// Files ending in .yaml or .yml are parsed as YAML, anything else as INI:
+//
+//	[core]
+//	hostname = 10.6.7.16
+//	username = admin
+//	password = secret
+func readCredentialsProfile(path string, profile string) (*credentialsProfile, error) {
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading credentials file %s: %v", path, err)
+	}
+
+	profiles := map[string]*credentialsProfile{}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &profiles)
+	default:
+		profiles, err = parseCredentialsINI(content)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing credentials file %s: %v", path, err)
+	}
+
+	creds, ok := profiles[profile]
+	if !ok || creds == nil {
+		return nil, fmt.Errorf("Profile %q not found in credentials file %s", profile, path)
+	}
+
+	return creds, nil
+}
+
+// parseCredentialsINI parses an INI credentials file into profiles keyed by
+// section name.
+func parseCredentialsINI(content []byte) (map[string]*credentialsProfile, error) {
+	profiles := map[string]*credentialsProfile{}
+
+	var current *credentialsProfile
+	line_num := 0
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line_num++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.TrimSpace(strings.TrimPrefix(line[1:len(line)-1], "profile "))
+			current = &credentialsProfile{}
+			profiles[name] = current
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found || current == nil {
+			return nil, fmt.Errorf("line %d: expected a [profile] section or key = value", line_num)
+		}
+
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+
+		switch strings.TrimSpace(key) {
+		case "hostname":
+			current.Hostname = value
+		case "username":
+			current.Username = value
+		case "password":
+			current.Password = value
+		}
+	}
+
+	return profiles, scanner.Err()
+}
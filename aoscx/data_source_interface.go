@@ -0,0 +1,197 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aruba/aoscxgo"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceInterface() *schema.Resource {
+	return &schema.Resource{
+		Description: "Data source to retrieve an interface from AOS-CX switches.",
+		ReadContext: dataSourceInterfaceRead,
+		Schema: map[string]*schema.Schema{
+			"switch": switchSchema(),
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"admin_state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"role": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Either l2 for switched interfaces or l3 for routed interfaces",
+			},
+			"vlan_mode": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vlan_tag": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"vlan_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"ipv4": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ipv6": &schema.Schema{
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"vrf": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceInterfaceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+	name := d.Get("name").(string)
+
+	tmp_int := aoscxgo.Interface{
+		Name: name,
+	}
+
+	err = tmp_int.Get(sw)
+
+	if requestFailed(err) {
+		diags = append(diags, errorDiagnostics(fmt.Sprintf("Error in Retrieving Interface %s", name), err, nil)...)
+		return diags
+	}
+
+	tmp_summary := interfaceSummary{}
+
+	err = restGet(ctx, sw, "system/interfaces/"+restPath(name)+"?attributes=routing", &tmp_summary)
+
+	if requestFailed(err) {
+		diags = append(diags, errorDiagnostics(fmt.Sprintf("Error in Retrieving Interface %s", name), err, nil)...)
+		return diags
+	}
+	role := tmp_summary.role()
+
+	d.SetId(switchID(d, name))
+	d.Set("description", tmp_int.Description)
+	d.Set("admin_state", tmp_int.AdminState)
+	d.Set("role", role)
+
+	if role == "l3" {
+		tmp_l3_int := aoscxgo.L3Interface{
+			Interface: aoscxgo.Interface{
+				Name: name,
+			}}
+
+		err = tmp_l3_int.Get(sw)
+
+		if requestFailed(err) {
+			diags = append(diags, errorDiagnostics(fmt.Sprintf("Error in Retrieving L3 Interface %s", name), err, nil)...)
+			return diags
+		}
+
+		d.Set("ipv4", tmp_l3_int.Ipv4)
+		d.Set("ipv6", tmp_l3_int.Ipv6)
+		d.Set("vrf", tmp_l3_int.Vrf)
+	} else {
+		tmp_l2_int := aoscxgo.L2Interface{
+			Interface: aoscxgo.Interface{
+				Name: name,
+			}}
+
+		err = tmp_l2_int.Get(sw)
+
+		if requestFailed(err) {
+			diags = append(diags, errorDiagnostics(fmt.Sprintf("Error in Retrieving L2 Interface %s", name), err, nil)...)
+			return diags
+		}
+
+		if tmp_l2_int.VlanMode == "access" || tmp_l2_int.VlanMode == "" {
+			d.Set("vlan_mode", "access")
+		} else {
+			d.Set("vlan_mode", "trunk")
+			d.Set("vlan_ids", tmp_l2_int.VlanIds)
+		}
+		d.Set("vlan_tag", tmp_l2_int.VlanTag)
+	}
+
+	return diags
+}
+
+// interfaceSummary holds the attributes of an interface listed by
+// getInterfaceSummaries.
+type interfaceSummary struct {
+	Name        string            `json:"name"`
+	Routing     bool              `json:"routing"`
+	Description string            `json:"description"`
+	AdminState  string            `json:"admin"`
+	UserConfig  map[string]string `json:"user_config"`
+}
+
+// role returns l3 for routed interfaces and l2 for the others.
+func (s *interfaceSummary) role() string {
+	if s.Routing {
+		return "l3"
+	}
+	return "l2"
+}
+
+// adminState returns the admin state of the interface, physical ports keep
+// it in user_config.
+func (s *interfaceSummary) adminState() string {
+	if admin := s.UserConfig["admin"]; admin != "" {
+		return admin
+	}
+	if s.AdminState != "" {
+		return s.AdminState
+	}
+	return "down"
+}
+
+// getInterfaceSummaries returns the name, role, description and admin state
+// of every interface on the switch in a single request, keyed by interface
+// name.
+func getInterfaceSummaries(ctx context.Context, sw *aoscxgo.Client) (map[string]interfaceSummary, error) {
+	interfaces := map[string]interfaceSummary{}
+
+	err := restGet(ctx, sw, "system/interfaces?depth=2&attributes=name,routing,description,admin,user_config", &interfaces)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := map[string]interfaceSummary{}
+	for _, tmp_int := range interfaces {
+		summaries[tmp_int.Name] = tmp_int
+	}
+
+	return summaries, nil
+}
@@ -0,0 +1,130 @@
+package aoscx
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceInterfaces() *schema.Resource {
+	return &schema.Resource{
+		Description: "Data source to retrieve interfaces from AOS-CX switches.",
+		ReadContext: dataSourceInterfacesRead,
+		Schema: map[string]*schema.Schema{
+			"switch": switchSchema(),
+			"name_regex": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "Only return interfaces whose name matches this regular expression",
+			},
+			"admin_state": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"up", "down"}, true),
+				Description:  "Only return interfaces with this admin state",
+			},
+			"role": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"l2", "l3"}, true),
+				Description:  "Only return switched (l2) or routed (l3) interfaces",
+			},
+			"names": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"interfaces": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"admin_state": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"role": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceInterfacesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	summaries, err := getInterfaceSummaries(ctx, sw)
+
+	if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving Interfaces", err, nil)...)
+		return diags
+	}
+
+	var name_regex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		name_regex = regexp.MustCompile(v.(string))
+	}
+	admin_state := d.Get("admin_state").(string)
+	role := strings.ToLower(d.Get("role").(string))
+
+	var int_names []string
+	for name, tmp_int := range summaries {
+		if name_regex != nil && !name_regex.MatchString(name) {
+			continue
+		}
+		if role != "" && role != tmp_int.role() {
+			continue
+		}
+		if admin_state != "" && !strings.EqualFold(admin_state, tmp_int.adminState()) {
+			continue
+		}
+		int_names = append(int_names, name)
+	}
+	sort.Strings(int_names)
+
+	names := []string{}
+	interfaces := []map[string]interface{}{}
+
+	for _, name := range int_names {
+		tmp_int := summaries[name]
+
+		names = append(names, name)
+		interfaces = append(interfaces, map[string]interface{}{
+			"name":        tmp_int.Name,
+			"description": tmp_int.Description,
+			"admin_state": tmp_int.adminState(),
+			"role":        tmp_int.role(),
+		})
+	}
+
+	d.SetId(switchID(d, "interfaces"))
+	d.Set("names", names)
+	d.Set("interfaces", interfaces)
+
+	return diags
+}
@@ -0,0 +1,154 @@
+package aoscx
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testDataSourceMockSwitch(t *testing.T) *mockSwitch {
+	m := newMockSwitch(t)
+
+	m.set("system/vlans/10", map[string]interface{}{"id": 10, "name": "servers", "description": "server farm", "admin": "up"})
+	m.set("system/vlans/20", map[string]interface{}{"id": 20, "name": "storage", "admin": "up"})
+	m.set("system/vlans/30", map[string]interface{}{"id": 30, "name": "servers-backup", "admin": "down"})
+	m.set("system/vrfs/blue", map[string]interface{}{"name": "blue", "rd": "65000:10"})
+	m.patch("system/interfaces/1%2F1%2F5", map[string]interface{}{"routing": true, "description": "uplink", "user_config": map[string]interface{}{"admin": "up"}})
+
+	return m
+}
+
+func TestDataSourceVlan(t *testing.T) {
+	m := testDataSourceMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testProviderConfig(m) + `
+data "aoscx_vlan" "test" {
+  vlan_id = 10
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aoscx_vlan.test", "id", "10"),
+					resource.TestCheckResourceAttr("data.aoscx_vlan.test", "name", "servers"),
+					resource.TestCheckResourceAttr("data.aoscx_vlan.test", "description", "server farm"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceVlans(t *testing.T) {
+	m := testDataSourceMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testProviderConfig(m) + `
+data "aoscx_vlans" "all" {}
+
+data "aoscx_vlans" "servers" {
+  name_regex  = "^servers"
+  admin_state = "up"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aoscx_vlans.all", "vlan_ids.#", "4"),
+					resource.TestCheckResourceAttr("data.aoscx_vlans.servers", "vlan_ids.#", "1"),
+					resource.TestCheckResourceAttr("data.aoscx_vlans.servers", "vlans.0.name", "servers"),
+					resource.TestCheckResourceAttr("data.aoscx_vlans.servers", "vlans.0.description", "server farm"),
+					resource.TestCheckResourceAttr("data.aoscx_vlans.servers", "id", "vlans"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceInterface(t *testing.T) {
+	m := testDataSourceMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testProviderConfig(m) + `
+data "aoscx_interface" "test" {
+  name = "1/1/5"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aoscx_interface.test", "id", "1/1/5"),
+					resource.TestCheckResourceAttr("data.aoscx_interface.test", "role", "l3"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceInterfaces(t *testing.T) {
+	m := testDataSourceMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testProviderConfig(m) + `
+data "aoscx_interfaces" "l2" {
+  name_regex = "^1/1/"
+  role       = "l2"
+}
+
+data "aoscx_interfaces" "l3" {
+  role = "l3"
+}
+
+data "aoscx_interfaces" "up" {
+  admin_state = "up"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aoscx_interfaces.l2", "names.#", "7"),
+					resource.TestCheckResourceAttr("data.aoscx_interfaces.l3", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.aoscx_interfaces.l3", "names.0", "1/1/5"),
+					resource.TestCheckResourceAttr("data.aoscx_interfaces.l3", "id", "interfaces"),
+					resource.TestCheckResourceAttr("data.aoscx_interfaces.up", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.aoscx_interfaces.up", "interfaces.0.description", "uplink"),
+					resource.TestCheckResourceAttr("data.aoscx_interfaces.up", "interfaces.0.admin_state", "up"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceVrf(t *testing.T) {
+	m := testDataSourceMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testProviderConfig(m) + `
+data "aoscx_vrf" "test" {
+  name = "blue"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aoscx_vrf.test", "id", "blue"),
+					resource.TestCheckResourceAttr("data.aoscx_vrf.test", "rd", "65000:10"),
+				),
+			},
+			{
+				Config: testProviderConfig(m) + `
+data "aoscx_vrf" "test" {
+  name = "missing"
+}
+`,
+				ExpectError: regexp.MustCompile(`missing`),
+			},
+		},
+	})
+}
@@ -0,0 +1,69 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/aruba/aoscxgo"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceVlan() *schema.Resource {
+	return &schema.Resource{
+		Description: "Data source to retrieve a VLAN from AOS-CX switches.",
+		ReadContext: dataSourceVlanRead,
+		Schema: map[string]*schema.Schema{
+			"switch": switchSchema(),
+			"vlan_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(1, 4094),
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"admin_state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceVlanRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+	vlan_id := d.Get("vlan_id").(int)
+
+	tmp_vlan := aoscxgo.Vlan{
+		VlanId: vlan_id,
+	}
+
+	err = tmp_vlan.Get(sw)
+
+	if requestFailed(err) {
+		diags = append(diags, errorDiagnostics(fmt.Sprintf("Error in Retrieving VLAN %v", vlan_id), err, nil)...)
+		return diags
+	}
+
+	d.SetId(switchID(d, strconv.Itoa(vlan_id)))
+	d.Set("name", tmp_vlan.Name)
+	d.Set("description", tmp_vlan.Description)
+	d.Set("admin_state", tmp_vlan.AdminState)
+
+	return diags
+}
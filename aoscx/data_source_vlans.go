@@ -0,0 +1,132 @@
+package aoscx
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceVlans() *schema.Resource {
+	return &schema.Resource{
+		Description: "Data source to retrieve all VLANs from AOS-CX switches.",
+		ReadContext: dataSourceVlansRead,
+		Schema: map[string]*schema.Schema{
+			"switch": switchSchema(),
+			"name_regex": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "Only return VLANs whose name matches this regular expression",
+			},
+			"admin_state": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"up", "down"}, true),
+				Description:  "Only return VLANs with this admin state",
+			},
+			"vlan_ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"vlans": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"vlan_id": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"admin_state": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// vlanSummary holds the attributes of a VLAN listed by dataSourceVlansRead.
+type vlanSummary struct {
+	VlanId      int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	AdminState  string `json:"admin"`
+}
+
+func dataSourceVlansRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	summaries := map[string]vlanSummary{}
+
+	err := restGet(ctx, sw, "system/vlans?depth=2&attributes=id,name,description,admin", &summaries)
+
+	if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving VLANs", err, nil)...)
+		return diags
+	}
+
+	var name_regex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		name_regex = regexp.MustCompile(v.(string))
+	}
+	admin_state := d.Get("admin_state").(string)
+
+	tmp_vlans := map[int]vlanSummary{}
+	var vlan_ids []int
+	for _, tmp_vlan := range summaries {
+		if name_regex != nil && !name_regex.MatchString(tmp_vlan.Name) {
+			continue
+		}
+		if admin_state != "" && !strings.EqualFold(admin_state, tmp_vlan.AdminState) {
+			continue
+		}
+		tmp_vlans[tmp_vlan.VlanId] = tmp_vlan
+		vlan_ids = append(vlan_ids, tmp_vlan.VlanId)
+	}
+	sort.Ints(vlan_ids)
+
+	matched_ids := []int{}
+	vlans := []map[string]interface{}{}
+
+	for _, vlan_id := range vlan_ids {
+		tmp_vlan := tmp_vlans[vlan_id]
+
+		matched_ids = append(matched_ids, vlan_id)
+		vlans = append(vlans, map[string]interface{}{
+			"vlan_id":     tmp_vlan.VlanId,
+			"name":        tmp_vlan.Name,
+			"description": tmp_vlan.Description,
+			"admin_state": tmp_vlan.AdminState,
+		})
+	}
+
+	d.SetId(switchID(d, "vlans"))
+	d.Set("vlan_ids", matched_ids)
+	d.Set("vlans", vlans)
+
+	return diags
+}
@@ -0,0 +1,55 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceVrf() *schema.Resource {
+	return &schema.Resource{
+		Description: "Data source to retrieve a VRF from AOS-CX switches.",
+		ReadContext: dataSourceVrfRead,
+		Schema: map[string]*schema.Schema{
+			"switch": switchSchema(),
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"rd": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Route distinguisher of the VRF",
+			},
+		},
+	}
+}
+
+func dataSourceVrfRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+	name := d.Get("name").(string)
+
+	tmp_vrf := struct {
+		Name string `json:"name"`
+		Rd   string `json:"rd"`
+	}{}
+
+	err := restGet(ctx, sw, "system/vrfs/"+restPath(name), &tmp_vrf)
+
+	if requestFailed(err) {
+		diags = append(diags, errorDiagnostics(fmt.Sprintf("Error in Retrieving VRF %s", name), err, nil)...)
+		return diags
+	}
+
+	d.SetId(switchID(d, name))
+	d.Set("rd", tmp_vrf.Rd)
+
+	return diags
+}
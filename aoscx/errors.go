@@ -0,0 +1,144 @@
+package aoscx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/aruba/aoscxgo"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// maxErrorBodyLength limits how much of a REST response body is included in
+// diagnostics.
+const maxErrorBodyLength = 512
+
+// apiError describes a REST call that returned an error status.
+type apiError struct {
+	Method     string
+	URI        string
+	StatusCode int
+	Status     string
+	Body       string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s %s returned %s", e.Method, e.URI, e.Status)
+}
+
+// statusCode returns the HTTP status code carried by err, or 0 when err is
+// not an HTTP error.
+func statusCode(err error) int {
+	var api_err *apiError
+	if errors.As(err, &api_err) {
+		return api_err.StatusCode
+	}
+
+	var request_err *aoscxgo.RequestError
+	if errors.As(err, &request_err) && request_err != nil {
+		code, _, _ := strings.Cut(request_err.StatusCode, " ")
+		status, _ := strconv.Atoi(code)
+		return status
+	}
+
+	return 0
+}
+
+// requestFailed reports whether err is an actual failure. aoscxgo reports
+// some successful calls as a RequestError carrying a 2xx status such as
+// 204 No Content, those are not failures.
+func requestFailed(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	status := statusCode(err)
+
+	return status < 200 || status > 299
+}
+
+// isNotFound reports whether err is a 404 Not Found returned by the switch.
+func isNotFound(err error) bool {
+	return statusCode(err) == 404
+}
+
+// errorDiagnostics classifies err into an error diagnostic with summary as
+// its summary. The detail holds the HTTP status, request method and URI and
+// an excerpt of the response body when they are known. path points to the
+// attribute the error relates to and may be nil. No diagnostics are returned
+// when requestFailed(err) is false.
+func errorDiagnostics(summary string, err error, path cty.Path) diag.Diagnostics {
+	if !requestFailed(err) {
+		return nil
+	}
+
+	var details []string
+
+	var api_err *apiError
+	var request_err *aoscxgo.RequestError
+	var url_err *url.Error
+	var net_err net.Error
+	var syntax_err *json.SyntaxError
+	var type_err *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &api_err):
+		details = append(details,
+			fmt.Sprintf("HTTP status: %s", api_err.Status),
+			fmt.Sprintf("Request: %s %s", api_err.Method, api_err.URI),
+		)
+		if body := strings.TrimSpace(api_err.Body); body != "" {
+			if len(body) > maxErrorBodyLength {
+				body = body[:maxErrorBodyLength] + "..."
+			}
+			details = append(details, fmt.Sprintf("Response: %s", body))
+		}
+
+	case errors.As(err, &request_err) && request_err != nil:
+		details = append(details, fmt.Sprintf("HTTP status: %s", request_err.StatusCode))
+		if request_err.Err != nil {
+			details = append(details, fmt.Sprintf("Response: %v", request_err.Err))
+		}
+
+	case errors.Is(err, context.DeadlineExceeded):
+		details = append(details, "The request timed out before the switch responded", err.Error())
+
+	case errors.Is(err, context.Canceled):
+		details = append(details, "The request was cancelled", err.Error())
+
+	case errors.As(err, &url_err):
+		if tls_diag := tlsErrorDiagnostic(url_err.URL, url_err.Err); tls_diag != nil {
+			details = append(details, tls_diag.Detail)
+		} else if errors.As(err, &net_err) && net_err.Timeout() {
+			details = append(details, "The request timed out before the switch responded")
+		} else {
+			details = append(details, "The switch could not be reached")
+		}
+		details = append(details,
+			fmt.Sprintf("Request: %s %s", url_err.Op, url_err.URL),
+			fmt.Sprintf("Error: %v", url_err.Err),
+		)
+
+	case errors.As(err, &syntax_err), errors.As(err, &type_err):
+		details = append(details, "The switch returned a response that could not be decoded", fmt.Sprintf("Error: %v", err))
+
+	default:
+		details = append(details, fmt.Sprintf("Error: %v", err))
+	}
+
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       summary,
+			Detail:        strings.Join(details, "\n"),
+			AttributePath: path,
+		},
+	}
+}
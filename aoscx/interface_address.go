@@ -0,0 +1,69 @@
+package aoscx
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	"github.com/aruba/aoscxgo"
+)
+
+// ip6Address is an entry of the ip6_addresses collection of an interface.
+type ip6Address struct {
+	Address string `json:"address"`
+	Type    string `json:"type,omitempty"`
+}
+
+// getIp6Addresses returns the sorted IPv6 addresses of the interface at
+// path, a missing collection has no addresses.
+func getIp6Addresses(ctx context.Context, sw *aoscxgo.Client, path string) ([]string, error) {
+	addresses := map[string]ip6Address{}
+	err := restGet(ctx, sw, path+"/ip6_addresses?depth=2", &addresses)
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+
+	result := []string{}
+	for _, address := range addresses {
+		result = append(result, address.Address)
+	}
+	sort.Strings(result)
+
+	return result, nil
+}
+
+// updateIp6Addresses adds the addresses of wanted missing from current to the
+// interface at path and removes the ones no longer wanted.
+func updateIp6Addresses(ctx context.Context, sw *aoscxgo.Client, path string, current []string, wanted []string) error {
+	wanted_set := map[string]bool{}
+	for _, address := range wanted {
+		wanted_set[address] = true
+	}
+
+	existing := map[string]bool{}
+	for _, address := range current {
+		existing[address] = true
+		if wanted_set[address] {
+			continue
+		}
+		err := restRequest(ctx, sw, http.MethodDelete, path+"/ip6_addresses/"+restPath(address), nil, nil)
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+	}
+
+	for _, address := range wanted {
+		if existing[address] {
+			continue
+		}
+		err := restRequest(ctx, sw, http.MethodPost, path+"/ip6_addresses", ip6Address{
+			Address: address,
+			Type:    "global-unicast",
+		}, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
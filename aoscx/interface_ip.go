@@ -0,0 +1,225 @@
+package aoscx
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	"github.com/aruba/aoscxgo"
+)
+
+// ipSettings are the IP options of a routed interface or VLAN interface,
+// aoscxgo does not know them and may clear them when it replaces the
+// interface, so they are written after the interface itself.
+type ipSettings struct {
+	// DhcpRelayServers maps the VRF the DHCP servers are reached through to
+	// their addresses
+	DhcpRelayServers map[string][]string
+
+	IpMtu             int
+	ProxyArp          bool
+	LocalProxyArp     bool
+	DirectedBroadcast bool
+	IcmpRedirects     bool
+
+	// NdRa is nil when router advertisements are suppressed
+	NdRa *ndRaSettings
+}
+
+// ndRaSettings are the IPv6 router advertisements sent on an interface.
+type ndRaSettings struct {
+	Interval      int
+	Lifetime      int
+	ManagedConfig bool
+	OtherConfig   bool
+}
+
+// ndRaConfig is the REST representation of ndRaSettings.
+type ndRaConfig struct {
+	SuppressRa    bool `json:"suppress_ra"`
+	MaxInterval   int  `json:"max_interval"`
+	Lifetime      int  `json:"lifetime"`
+	ManagedConfig bool `json:"managed_config_flag"`
+	OtherConfig   bool `json:"other_config_flag"`
+}
+
+// ipSettingsResponse is the REST representation of the IP options of an
+// interface.
+type ipSettingsResponse struct {
+	IpMtu             int         `json:"ip_mtu"`
+	ProxyArp          bool        `json:"proxy_arp_enabled"`
+	LocalProxyArp     bool        `json:"local_proxy_arp_enabled"`
+	DirectedBroadcast bool        `json:"directed_broadcast_enabled"`
+	IcmpRedirects     bool        `json:"icmp_redirect_enabled"`
+	NdRaConfig        *ndRaConfig `json:"nd_ra_config"`
+}
+
+// dhcpRelay is an entry of system/dhcp_relays, keyed by the interface and the
+// VRF of the DHCP servers.
+type dhcpRelay struct {
+	Port    string   `json:"port"`
+	Vrf     string   `json:"vrf"`
+	Servers []string `json:"ipv4_ucast_server"`
+}
+
+func dhcpRelayPath(interface_name string, vrf_name string) string {
+	return "system/dhcp_relays/" + restPath(interface_name+","+vrf_name)
+}
+
+// getDhcpRelays returns the DHCP servers relayed to from the interface by
+// VRF.
+func getDhcpRelays(ctx context.Context, sw *aoscxgo.Client, interface_name string) (map[string][]string, error) {
+	relays := map[string]dhcpRelay{}
+	err := restGet(ctx, sw, "system/dhcp_relays?depth=2", &relays)
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+
+	servers := map[string][]string{}
+	for _, relay := range relays {
+		if uriKey(relay.Port) == interface_name && len(relay.Servers) > 0 {
+			servers[uriKey(relay.Vrf)] = relay.Servers
+		}
+	}
+
+	return servers, nil
+}
+
+// Get retrieves the IP options of the interface.
+func (s *ipSettings) Get(ctx context.Context, sw *aoscxgo.Client, interface_name string) error {
+	tmp_settings := ipSettingsResponse{
+		IpMtu:         1500,
+		IcmpRedirects: true,
+		NdRaConfig: &ndRaConfig{
+			SuppressRa:  true,
+			MaxInterval: 600,
+			Lifetime:    1800,
+		},
+	}
+
+	err := restGet(ctx, sw, "system/interfaces/"+restPath(interface_name)+"?attributes=ip_mtu,proxy_arp_enabled,local_proxy_arp_enabled,directed_broadcast_enabled,icmp_redirect_enabled,nd_ra_config", &tmp_settings)
+	if err != nil {
+		return err
+	}
+
+	s.IpMtu = tmp_settings.IpMtu
+	s.ProxyArp = tmp_settings.ProxyArp
+	s.LocalProxyArp = tmp_settings.LocalProxyArp
+	s.DirectedBroadcast = tmp_settings.DirectedBroadcast
+	s.IcmpRedirects = tmp_settings.IcmpRedirects
+	s.NdRa = nil
+	if ra := tmp_settings.NdRaConfig; ra != nil && !ra.SuppressRa {
+		s.NdRa = &ndRaSettings{
+			Interval:      ra.MaxInterval,
+			Lifetime:      ra.Lifetime,
+			ManagedConfig: ra.ManagedConfig,
+			OtherConfig:   ra.OtherConfig,
+		}
+	}
+
+	s.DhcpRelayServers, err = getDhcpRelays(ctx, sw, interface_name)
+
+	return err
+}
+
+// Update writes the IP options of the interface and reconciles its DHCP
+// relay entries.
+func (s *ipSettings) Update(ctx context.Context, sw *aoscxgo.Client, interface_name string) error {
+	body := map[string]interface{}{
+		"ip_mtu":                     s.IpMtu,
+		"proxy_arp_enabled":          s.ProxyArp,
+		"local_proxy_arp_enabled":    s.LocalProxyArp,
+		"directed_broadcast_enabled": s.DirectedBroadcast,
+		"icmp_redirect_enabled":      s.IcmpRedirects,
+		"nd_ra_config":               nil,
+	}
+	if s.NdRa != nil {
+		body["nd_ra_config"] = ndRaConfig{
+			MaxInterval:   s.NdRa.Interval,
+			Lifetime:      s.NdRa.Lifetime,
+			ManagedConfig: s.NdRa.ManagedConfig,
+			OtherConfig:   s.NdRa.OtherConfig,
+		}
+	}
+
+	err := restRequest(ctx, sw, http.MethodPatch, "system/interfaces/"+restPath(interface_name), body, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.updateDhcpRelays(ctx, sw, interface_name)
+}
+
+// updateDhcpRelays creates, updates and removes the DHCP relay entries of the
+// interface to match s.DhcpRelayServers.
+func (s *ipSettings) updateDhcpRelays(ctx context.Context, sw *aoscxgo.Client, interface_name string) error {
+	current, err := getDhcpRelays(ctx, sw, interface_name)
+	if err != nil {
+		return err
+	}
+
+	for vrf_name := range current {
+		if _, ok := s.DhcpRelayServers[vrf_name]; ok {
+			continue
+		}
+		err = restRequest(ctx, sw, http.MethodDelete, dhcpRelayPath(interface_name, vrf_name), nil, nil)
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+	}
+
+	vrf_names := []string{}
+	for vrf_name := range s.DhcpRelayServers {
+		vrf_names = append(vrf_names, vrf_name)
+	}
+	sort.Strings(vrf_names)
+
+	for _, vrf_name := range vrf_names {
+		servers := s.DhcpRelayServers[vrf_name]
+		if existing, ok := current[vrf_name]; ok {
+			if equalStrings(existing, servers) {
+				continue
+			}
+			err = restRequest(ctx, sw, http.MethodPatch, dhcpRelayPath(interface_name, vrf_name), map[string]interface{}{
+				"ipv4_ucast_server": servers,
+			}, nil)
+		} else {
+			err = restRequest(ctx, sw, http.MethodPost, "system/dhcp_relays", dhcpRelay{
+				Port:    restURI("system/interfaces/" + restPath(interface_name)),
+				Vrf:     restURI("system/vrfs/" + restPath(vrf_name)),
+				Servers: servers,
+			}, nil)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delete removes the DHCP relay entries of the interface and restores the
+// defaults of its IP options.
+func (s *ipSettings) Delete(ctx context.Context, sw *aoscxgo.Client, interface_name string) error {
+	defaults := ipSettings{
+		DhcpRelayServers: map[string][]string{},
+		IpMtu:            1500,
+		IcmpRedirects:    true,
+	}
+
+	return defaults.Update(ctx, sw, interface_name)
+}
+
+// equalStrings reports whether a and b hold the same strings in the same
+// order.
+func equalStrings(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for index := range a {
+		if a[index] != b[index] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,235 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aruba/aoscxgo"
+)
+
+// portSpeeds maps the speeds of physical ports to Mb/s, the unit of the
+// speeds the switch reports and accepts.
+var portSpeeds = map[string]int{
+	"10m":  10,
+	"100m": 100,
+	"1g":   1000,
+	"2.5g": 2500,
+	"5g":   5000,
+	"10g":  10000,
+	"25g":  25000,
+	"40g":  40000,
+	"50g":  50000,
+	"100g": 100000,
+}
+
+// portSettingKeys maps the attributes of the port settings to their keys in
+// the user_config attribute of an interface, other keys like admin belong to
+// aoscxgo.
+var portSettingKeys = map[string]string{
+	"mtu":                       "mtu",
+	"speed":                     "speeds",
+	"duplex":                    "duplex",
+	"autonegotiation":           "autoneg",
+	"fec":                       "fec",
+	"flow_control":              "pause",
+	"energy_efficient_ethernet": "eee",
+}
+
+// portSettings are the physical settings of a port, aoscxgo does not know
+// them. They are stored in the user_config attribute of the interface, which
+// aoscxgo replaces, so they are written after the interface itself. Only the
+// settings in Configured are written, keys of settings at their default value
+// are removed.
+type portSettings struct {
+	Mtu                     int
+	Speed                   string
+	Duplex                  string
+	Autonegotiation         bool
+	Fec                     string
+	FlowControl             string
+	EnergyEfficientEthernet bool
+
+	// Configured holds the attributes of the settings managed by Terraform
+	Configured map[string]bool
+}
+
+// portCapabilities is the hw_intf_info attribute of a physical port. Speeds
+// and FecModes are comma separated lists.
+type portCapabilities struct {
+	Speeds       string `json:"speeds"`
+	MaxMtu       string `json:"max_mtu"`
+	FecModes     string `json:"fec_modes"`
+	PauseCapable string `json:"pause_capable"`
+	EeeCapable   string `json:"eee_capable"`
+}
+
+// portInterface is the REST representation of the port attributes of an
+// interface.
+type portInterface struct {
+	UserConfig map[string]string `json:"user_config"`
+	HwIntfInfo *portCapabilities `json:"hw_intf_info"`
+}
+
+func getPortInterface(ctx context.Context, sw *aoscxgo.Client, interface_name string) (portInterface, error) {
+	tmp_interface := portInterface{}
+
+	err := restGet(ctx, sw, "system/interfaces/"+restPath(interface_name)+"?attributes=user_config,hw_intf_info", &tmp_interface)
+	if tmp_interface.UserConfig == nil {
+		tmp_interface.UserConfig = map[string]string{}
+	}
+
+	return tmp_interface, err
+}
+
+// Get retrieves the port settings of the interface.
+func (p *portSettings) Get(ctx context.Context, sw *aoscxgo.Client, interface_name string) error {
+	tmp_interface, err := getPortInterface(ctx, sw, interface_name)
+	if err != nil {
+		return err
+	}
+	user_config := tmp_interface.UserConfig
+
+	*p = portSettings{
+		Mtu:                     1500,
+		Speed:                   "auto",
+		Duplex:                  "full",
+		Autonegotiation:         user_config["autoneg"] != "off",
+		Fec:                     "auto",
+		FlowControl:             "off",
+		EnergyEfficientEthernet: user_config["eee"] == "true",
+	}
+	if mtu, err := strconv.Atoi(user_config["mtu"]); err == nil {
+		p.Mtu = mtu
+	}
+	for speed, mbps := range portSpeeds {
+		if user_config["speeds"] == strconv.Itoa(mbps) {
+			p.Speed = speed
+		}
+	}
+	if duplex := user_config["duplex"]; duplex != "" {
+		p.Duplex = duplex
+	}
+	if fec := user_config["fec"]; fec != "" {
+		p.Fec = fec
+	}
+	if pause := user_config["pause"]; pause != "" {
+		p.FlowControl = pause
+	}
+
+	return nil
+}
+
+// getPortCapabilities returns the capabilities of the port, nil when the
+// switch does not report them.
+func getPortCapabilities(ctx context.Context, sw *aoscxgo.Client, interface_name string) (*portCapabilities, error) {
+	tmp_interface, err := getPortInterface(ctx, sw, interface_name)
+
+	return tmp_interface.HwIntfInfo, err
+}
+
+// Update writes the configured port settings. Keys of previous, the
+// user_config of the interface before aoscxgo replaced it, are kept unless
+// aoscxgo or a configured setting owns them.
+func (p *portSettings) Update(ctx context.Context, sw *aoscxgo.Client, interface_name string, previous map[string]string) error {
+	tmp_interface, err := getPortInterface(ctx, sw, interface_name)
+	if err != nil {
+		return err
+	}
+
+	user_config := map[string]string{}
+	for key, value := range previous {
+		if key != "admin" {
+			user_config[key] = value
+		}
+	}
+	for key, value := range tmp_interface.UserConfig {
+		user_config[key] = value
+	}
+
+	for attribute, key := range portSettingKeys {
+		if !p.Configured[attribute] {
+			continue
+		}
+		delete(user_config, key)
+		if value := p.userConfigValue(attribute); value != "" {
+			user_config[key] = value
+		}
+	}
+
+	return restRequest(ctx, sw, http.MethodPatch, "system/interfaces/"+restPath(interface_name), map[string]interface{}{
+		"user_config": user_config,
+	}, nil)
+}
+
+// userConfigValue returns the user_config value of the setting of
+// attribute, empty when the setting is at its default.
+func (p *portSettings) userConfigValue(attribute string) string {
+	switch {
+	case attribute == "mtu" && p.Mtu != 1500:
+		return strconv.Itoa(p.Mtu)
+	case attribute == "speed" && p.Speed != "auto":
+		return strconv.Itoa(portSpeeds[p.Speed])
+	case attribute == "duplex" && p.Duplex != "full":
+		return p.Duplex
+	case attribute == "autonegotiation" && !p.Autonegotiation:
+		return "off"
+	case attribute == "fec" && p.Fec != "auto":
+		return p.Fec
+	case attribute == "flow_control" && p.FlowControl != "off":
+		return p.FlowControl
+	case attribute == "energy_efficient_ethernet" && p.EnergyEfficientEthernet:
+		return "true"
+	}
+	return ""
+}
+
+// check returns the attribute of the first configured setting the port does
+// not support, with an error describing what it supports.
+func (p *portSettings) check(caps portCapabilities) (string, error) {
+	if max_mtu, err := strconv.Atoi(caps.MaxMtu); err == nil && p.Configured["mtu"] && p.Mtu > max_mtu {
+		return "mtu", fmt.Errorf("mtu %d exceeds the maximum MTU %d of the port", p.Mtu, max_mtu)
+	}
+
+	if p.Configured["speed"] && p.Speed != "auto" && caps.Speeds != "" {
+		supported := []string{}
+		found := false
+		for _, mbps := range strings.Split(caps.Speeds, ",") {
+			for speed, speed_mbps := range portSpeeds {
+				if strconv.Itoa(speed_mbps) == strings.TrimSpace(mbps) {
+					supported = append(supported, speed)
+					found = found || speed == p.Speed
+				}
+			}
+		}
+		if !found {
+			return "speed", fmt.Errorf("speed %s is not supported by the port, supported speeds are %s", p.Speed, strings.Join(supported, ", "))
+		}
+	}
+
+	if p.Configured["fec"] && p.Fec != "auto" && p.Fec != "off" {
+		supported := []string{"auto", "off"}
+		found := false
+		for _, mode := range strings.Split(caps.FecModes, ",") {
+			if mode = strings.TrimSpace(mode); mode != "" {
+				supported = append(supported, mode)
+				found = found || mode == p.Fec
+			}
+		}
+		if !found {
+			return "fec", fmt.Errorf("fec %s is not supported by the port, supported modes are %s", p.Fec, strings.Join(supported, ", "))
+		}
+	}
+
+	if p.Configured["flow_control"] && p.FlowControl != "off" && caps.PauseCapable != "true" {
+		return "flow_control", fmt.Errorf("the port does not support flow control")
+	}
+
+	if p.Configured["energy_efficient_ethernet"] && p.EnergyEfficientEthernet && caps.EeeCapable != "true" {
+		return "energy_efficient_ethernet", fmt.Errorf("the port does not support energy efficient ethernet")
+	}
+
+	return "", nil
+}
@@ -0,0 +1,260 @@
+package aoscx
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/aruba/aoscxgo"
+)
+
+// lag is a link aggregation interface, system/interfaces/lagN. aoscxgo only
+// handles physical ports, so LAGs are configured through the REST API with
+// the same L2 and L3 settings as the interface resources.
+type lag struct {
+	Name        string
+	Description string
+	AdminState  string
+	Members     []string
+
+	// LacpMode is active, passive or static
+	LacpMode string
+	LacpRate string
+	Fallback bool
+	Hash     string
+
+	// MultiChassis spans the LAG over both switches of a VSX pair
+	MultiChassis bool
+
+	Routing bool
+
+	// L2 settings, used when Routing is false
+	VlanMode        string
+	NativeVlanTag   bool
+	VlanTag         int
+	VlanIds         []int
+	TrunkAllowedAll bool
+
+	// L3 settings, used when Routing is true. The first Ipv4 address is the
+	// primary address.
+	Ipv4 []string
+	Ipv6 []string
+	Vrf  string
+}
+
+// lagResponse is the REST representation of a LAG.
+type lagResponse struct {
+	Description         string            `json:"description"`
+	AdminState          string            `json:"admin"`
+	Interfaces          []string          `json:"interfaces"`
+	Lacp                string            `json:"lacp"`
+	OtherConfig         map[string]string `json:"other_config"`
+	Routing             bool              `json:"routing"`
+	VlanMode            string            `json:"vlan_mode"`
+	VlanTag             string            `json:"vlan_tag"`
+	VlanTrunks          []string          `json:"vlan_trunks"`
+	Ip4Address          string            `json:"ip4_address"`
+	Ip4AddressSecondary []string          `json:"ip4_address_secondary"`
+	Vrf                 string            `json:"vrf"`
+}
+
+func (l *lag) path() string {
+	return "system/interfaces/" + restPath(l.Name)
+}
+
+// body returns the writable configuration of the LAG. Settings of the mode
+// that is not in use are cleared so switching between L2 and L3 doesn't
+// leave stale configuration behind.
+func (l *lag) body() map[string]interface{} {
+	members := []string{}
+	for _, member := range l.Members {
+		members = append(members, restURI("system/interfaces/"+restPath(member)))
+	}
+
+	lacp := l.LacpMode
+	if lacp == "static" {
+		lacp = "off"
+	}
+
+	body := map[string]interface{}{
+		"description": l.Description,
+		"admin":       l.AdminState,
+		"interfaces":  members,
+		"lacp":        lacp,
+		"other_config": map[string]string{
+			"lacp-time":        l.LacpRate,
+			"lacp-fallback-ab": strconv.FormatBool(l.Fallback),
+			"bond_mode":        l.Hash,
+			"mclag_enabled":    strconv.FormatBool(l.MultiChassis),
+		},
+		"routing":               l.Routing,
+		"vlan_mode":             nil,
+		"vlan_tag":              nil,
+		"vlan_trunks":           []string{},
+		"ip4_address":           nil,
+		"ip4_address_secondary": []string{},
+		"vrf":                   nil,
+	}
+
+	if l.Routing {
+		if len(l.Ipv4) > 0 {
+			body["ip4_address"] = l.Ipv4[0]
+			body["ip4_address_secondary"] = l.Ipv4[1:]
+		}
+		body["vrf"] = restURI("system/vrfs/" + restPath(l.Vrf))
+		return body
+	}
+
+	body["vlan_tag"] = restURI("system/vlans/" + strconv.Itoa(l.VlanTag))
+	if l.VlanMode == "trunk" {
+		if l.NativeVlanTag {
+			body["vlan_mode"] = "native-tagged"
+		} else {
+			body["vlan_mode"] = "native-untagged"
+		}
+		// An empty vlan_trunks allows all VLANs
+		if !l.TrunkAllowedAll {
+			trunks := []string{}
+			for _, vlan_id := range l.VlanIds {
+				trunks = append(trunks, restURI("system/vlans/"+strconv.Itoa(vlan_id)))
+			}
+			body["vlan_trunks"] = trunks
+		}
+	} else {
+		body["vlan_mode"] = "access"
+	}
+
+	return body
+}
+
+// Create creates the LAG with its members and addresses. It returns whether
+// the LAG interface itself was created.
+func (l *lag) Create(ctx context.Context, sw *aoscxgo.Client) (bool, error) {
+	body := l.body()
+	body["name"] = l.Name
+	body["type"] = "lag"
+
+	err := restRequest(ctx, sw, http.MethodPost, "system/interfaces", body, nil)
+	if err != nil {
+		return false, err
+	}
+
+	return true, l.updateIpv6(ctx, sw, nil)
+}
+
+// Get retrieves the LAG named l.Name.
+func (l *lag) Get(ctx context.Context, sw *aoscxgo.Client) error {
+	tmp_lag := lagResponse{}
+
+	err := restGet(ctx, sw, l.path(), &tmp_lag)
+	if err != nil {
+		return err
+	}
+
+	l.Description = tmp_lag.Description
+	l.AdminState = tmp_lag.AdminState
+	if l.AdminState == "" {
+		l.AdminState = "down"
+	}
+
+	l.Members = []string{}
+	for _, member := range tmp_lag.Interfaces {
+		l.Members = append(l.Members, uriKey(member))
+	}
+	sort.Strings(l.Members)
+
+	switch tmp_lag.Lacp {
+	case "off", "":
+		l.LacpMode = "static"
+	default:
+		l.LacpMode = tmp_lag.Lacp
+	}
+	l.LacpRate = tmp_lag.OtherConfig["lacp-time"]
+	if l.LacpRate == "" {
+		l.LacpRate = "slow"
+	}
+	l.Fallback = tmp_lag.OtherConfig["lacp-fallback-ab"] == "true"
+	l.Hash = tmp_lag.OtherConfig["bond_mode"]
+	if l.Hash == "" {
+		l.Hash = "l3-src-dst"
+	}
+	l.MultiChassis = tmp_lag.OtherConfig["mclag_enabled"] == "true"
+
+	l.Routing = tmp_lag.Routing
+
+	// Settings of the unused mode are reported with their defaults
+	l.VlanMode = "access"
+	l.NativeVlanTag = false
+	l.VlanTag = 1
+	l.VlanIds = []int{}
+	l.TrunkAllowedAll = false
+	l.Ipv4 = []string{}
+	l.Ipv6 = []string{}
+	l.Vrf = "default"
+
+	if l.Routing {
+		if tmp_lag.Ip4Address != "" {
+			l.Ipv4 = append([]string{tmp_lag.Ip4Address}, tmp_lag.Ip4AddressSecondary...)
+		}
+		if tmp_lag.Vrf != "" {
+			l.Vrf = uriKey(tmp_lag.Vrf)
+		}
+
+		l.Ipv6, err = getIp6Addresses(ctx, sw, l.path())
+
+		return err
+	}
+
+	if tmp_lag.VlanTag != "" {
+		if vlan_id, err := strconv.Atoi(uriKey(tmp_lag.VlanTag)); err == nil {
+			l.VlanTag = vlan_id
+		}
+	}
+
+	if tmp_lag.VlanMode == "native-tagged" || tmp_lag.VlanMode == "native-untagged" || tmp_lag.VlanMode == "trunk" {
+		l.VlanMode = "trunk"
+		l.NativeVlanTag = tmp_lag.VlanMode == "native-tagged"
+		l.TrunkAllowedAll = len(tmp_lag.VlanTrunks) == 0
+		for _, trunk := range tmp_lag.VlanTrunks {
+			if vlan_id, err := strconv.Atoi(uriKey(trunk)); err == nil {
+				l.VlanIds = append(l.VlanIds, vlan_id)
+			}
+		}
+		sort.Ints(l.VlanIds)
+	}
+
+	return nil
+}
+
+// Update writes the LAG configuration and reconciles its IPv6 addresses.
+func (l *lag) Update(ctx context.Context, sw *aoscxgo.Client) error {
+	current := lag{Name: l.Name}
+	err := current.Get(ctx, sw)
+	if err != nil {
+		return err
+	}
+
+	err = restRequest(ctx, sw, http.MethodPatch, l.path(), l.body(), nil)
+	if err != nil {
+		return err
+	}
+
+	return l.updateIpv6(ctx, sw, current.Ipv6)
+}
+
+// updateIpv6 reconciles the IPv6 addresses of the LAG with current, a LAG
+// without routing has none.
+func (l *lag) updateIpv6(ctx context.Context, sw *aoscxgo.Client, current []string) error {
+	wanted := []string{}
+	if l.Routing {
+		wanted = l.Ipv6
+	}
+
+	return updateIp6Addresses(ctx, sw, l.path(), current, wanted)
+}
+
+// Delete deletes the LAG, its members return to standalone ports.
+func (l *lag) Delete(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodDelete, l.path(), nil, nil)
+}
@@ -0,0 +1,128 @@
+package aoscx
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/aruba/aoscxgo"
+)
+
+// loopbackInterface is a loopback interface, system/interfaces/loopbackN.
+// aoscxgo can only materialize physical ports, so loopbacks are configured
+// through the REST API.
+type loopbackInterface struct {
+	Id          int
+	Description string
+	AdminState  string
+
+	// The first Ipv4 address is the primary address
+	Ipv4 []string
+	Ipv6 []string
+	Vrf  string
+}
+
+// loopbackResponse is the REST representation of a loopback interface.
+type loopbackResponse struct {
+	Description         string   `json:"description"`
+	AdminState          string   `json:"admin"`
+	Ip4Address          string   `json:"ip4_address"`
+	Ip4AddressSecondary []string `json:"ip4_address_secondary"`
+	Vrf                 string   `json:"vrf"`
+}
+
+// loopbackName returns the interface name of loopback id, e.g. loopback0.
+func loopbackName(id int) string {
+	return "loopback" + strconv.Itoa(id)
+}
+
+func (l *loopbackInterface) path() string {
+	return "system/interfaces/" + restPath(loopbackName(l.Id))
+}
+
+// body returns the writable configuration of the loopback.
+func (l *loopbackInterface) body() map[string]interface{} {
+	body := map[string]interface{}{
+		"description":           nil,
+		"admin":                 l.AdminState,
+		"ip4_address":           nil,
+		"ip4_address_secondary": []string{},
+		"vrf":                   restURI("system/vrfs/" + restPath(l.Vrf)),
+	}
+
+	if l.Description != "" {
+		body["description"] = l.Description
+	}
+	if len(l.Ipv4) > 0 {
+		body["ip4_address"] = l.Ipv4[0]
+		body["ip4_address_secondary"] = l.Ipv4[1:]
+	}
+
+	return body
+}
+
+// Create creates the loopback with its addresses. It returns whether the
+// loopback interface itself was created.
+func (l *loopbackInterface) Create(ctx context.Context, sw *aoscxgo.Client) (bool, error) {
+	body := l.body()
+	body["name"] = loopbackName(l.Id)
+	body["type"] = "loopback"
+
+	err := restRequest(ctx, sw, http.MethodPost, "system/interfaces", body, nil)
+	if err != nil {
+		return false, err
+	}
+
+	return true, updateIp6Addresses(ctx, sw, l.path(), nil, l.Ipv6)
+}
+
+// Get retrieves loopback l.Id.
+func (l *loopbackInterface) Get(ctx context.Context, sw *aoscxgo.Client) error {
+	tmp_loopback := loopbackResponse{}
+
+	err := restGet(ctx, sw, l.path(), &tmp_loopback)
+	if err != nil {
+		return err
+	}
+
+	l.Description = tmp_loopback.Description
+	l.AdminState = tmp_loopback.AdminState
+	if l.AdminState == "" {
+		l.AdminState = "down"
+	}
+
+	l.Ipv4 = []string{}
+	if tmp_loopback.Ip4Address != "" {
+		l.Ipv4 = append([]string{tmp_loopback.Ip4Address}, tmp_loopback.Ip4AddressSecondary...)
+	}
+
+	l.Vrf = "default"
+	if tmp_loopback.Vrf != "" {
+		l.Vrf = uriKey(tmp_loopback.Vrf)
+	}
+
+	l.Ipv6, err = getIp6Addresses(ctx, sw, l.path())
+
+	return err
+}
+
+// Update writes the loopback configuration and reconciles its IPv6
+// addresses.
+func (l *loopbackInterface) Update(ctx context.Context, sw *aoscxgo.Client) error {
+	current, err := getIp6Addresses(ctx, sw, l.path())
+	if err != nil {
+		return err
+	}
+
+	err = restRequest(ctx, sw, http.MethodPatch, l.path(), l.body(), nil)
+	if err != nil {
+		return err
+	}
+
+	return updateIp6Addresses(ctx, sw, l.path(), current, l.Ipv6)
+}
+
+// Delete deletes the loopback.
+func (l *loopbackInterface) Delete(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodDelete, l.path(), nil, nil)
+}
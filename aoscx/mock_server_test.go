@@ -0,0 +1,507 @@
+package aoscx
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// mockCollections maps the REST collections served by mockSwitch to the body
+// fields forming the key of their members. A POST to a collection stores the
+// body under the key built from these fields, joined by commas like AOS-CX
+// does for composite keys.
+var mockCollections = map[string][]string{
+	"vlans":       {"id"},
+	"interfaces":  {"name"},
+	"vrfs":        {"name"},
+	"fullconfigs": {"name"},
+
+	"vrf_address_families":   {"address_family"},
+	"static_routes":          {"prefix"},
+	"static_nexthops":        {"id"},
+	"ip6_addresses":          {"address"},
+	"acls":                   {"name", "list_type"},
+	"cfg_aces":               {"sequence_number"},
+	"ospf_routers":           {"instance_tag"},
+	"ospfv3_routers":         {"instance_tag"},
+	"areas":                  {"area_id"},
+	"ospf_interfaces":        {"name"},
+	"bgp_routers":            {"asn"},
+	"bgp_neighbors":          {"ip_or_ifname_or_group_name"},
+	"prefix_lists":           {"name"},
+	"prefix_list_entries":    {"preference"},
+	"route_maps":             {"name"},
+	"route_map_entries":      {"preference"},
+	"community_lists":        {"name"},
+	"community_list_entries": {"preference"},
+	"mstp_instances":         {"mstp_instance_id"},
+	"radius_servers":         {"address", "port", "port_type"},
+	"aaa_server_groups":      {"group_name"},
+	"port_access_roles":      {"name"},
+	"dhcp_relays":            {"port", "vrf"},
+
+	"port_access_auth_configurations": {"authentication_method"},
+}
+
+// mockFault makes mockSwitch answer matching requests with status instead of
+// handling them.
+type mockFault struct {
+	method string
+	path   string
+	status int
+	count  int
+}
+
+// mockSwitch is an in-memory fake of the AOS-CX REST API. Objects are stored
+// as decoded JSON keyed by their path below /rest/<version>/ with escaped
+// segments, e.g. system/interfaces/1%2F1%2F1.
+type mockSwitch struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mu       sync.Mutex
+	objects  map[string]map[string]interface{}
+	seeded   map[string]map[string]interface{}
+	sessions map[string]bool
+	faults   []*mockFault
+	logins   int
+	requests []string
+}
+
+// newMockSwitch starts a fake switch with VLAN 1, the default VRF and
+// physical interfaces 1/1/1 to 1/1/8. Ports 1/1/1 to 1/1/4 are 1G copper
+// ports, 1/1/5 to 1/1/8 are 25G ports. It is stopped when the test ends.
+func newMockSwitch(t *testing.T) *mockSwitch {
+	m := &mockSwitch{
+		t:        t,
+		objects:  map[string]map[string]interface{}{},
+		seeded:   map[string]map[string]interface{}{},
+		sessions: map[string]bool{},
+	}
+
+	m.seed("system", map[string]interface{}{"hostname": "mock-switch"})
+	m.seed("system/vlans/1", map[string]interface{}{"id": 1, "name": "DEFAULT_VLAN_1", "admin": "up"})
+	m.seed("system/vrfs/default", map[string]interface{}{"name": "default"})
+	m.seed("system/vrfs/mgmt", map[string]interface{}{"name": "mgmt"})
+	for port := 1; port <= 8; port++ {
+		name := fmt.Sprintf("1/1/%d", port)
+		hw_intf_info := map[string]interface{}{
+			"speeds":        "10,100,1000",
+			"max_mtu":       "9198",
+			"fec_modes":     "",
+			"pause_capable": "true",
+			"eee_capable":   "true",
+		}
+		if port > 4 {
+			hw_intf_info = map[string]interface{}{
+				"speeds":        "1000,10000,25000",
+				"max_mtu":       "9198",
+				"fec_modes":     "cl74,cl91",
+				"pause_capable": "true",
+				"eee_capable":   "false",
+			}
+		}
+		m.seed("system/interfaces/"+url.PathEscape(name), map[string]interface{}{
+			"name":         name,
+			"type":         "system",
+			"routing":      false,
+			"hw_intf_info": hw_intf_info,
+		})
+	}
+
+	m.server = httptest.NewTLSServer(http.HandlerFunc(m.handle))
+	t.Cleanup(m.server.Close)
+
+	return m
+}
+
+// hostname returns the host:port the fake switch listens on.
+func (m *mockSwitch) hostname() string {
+	return strings.TrimPrefix(m.server.URL, "https://")
+}
+
+// seed stores obj at path and restores it whenever path is deleted, the way
+// physical interfaces return to their defaults on AOS-CX.
+func (m *mockSwitch) seed(path string, obj map[string]interface{}) {
+	m.seeded[path] = copyObject(obj)
+	m.objects[path] = copyObject(obj)
+}
+
+// get returns a copy of the object at path, or nil when there is none.
+func (m *mockSwitch) get(path string) map[string]interface{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if obj, ok := m.objects[path]; ok {
+		return copyObject(obj)
+	}
+	return nil
+}
+
+// exists reports whether an object is stored at path.
+func (m *mockSwitch) exists(path string) bool {
+	return m.get(path) != nil
+}
+
+// set stores obj at path out of band, replacing any existing object.
+func (m *mockSwitch) set(path string, obj map[string]interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.objects[path] = copyObject(obj)
+}
+
+// patch changes fields of the object at path out of band, used to simulate
+// drift.
+func (m *mockSwitch) patch(path string, fields map[string]interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	obj, ok := m.objects[path]
+	if !ok {
+		m.t.Fatalf("mock switch: cannot patch missing object %s", path)
+	}
+	for key, value := range fields {
+		obj[key] = value
+	}
+}
+
+// remove deletes the object at path and its children out of band.
+func (m *mockSwitch) remove(path string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.deleteLocked(path)
+}
+
+// injectFault answers the next count requests whose method matches method
+// (any when empty) and whose path starts with path with status. A count of
+// -1 keeps the fault until the test ends.
+func (m *mockSwitch) injectFault(method string, path string, status int, count int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.faults = append(m.faults, &mockFault{
+		method: method,
+		path:   path,
+		status: status,
+		count:  count,
+	})
+}
+
+// expireSessions invalidates every session cookie, like a session timeout
+// on the switch.
+func (m *mockSwitch) expireSessions() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sessions = map[string]bool{}
+}
+
+// loginCount returns how many logins the switch accepted.
+func (m *mockSwitch) loginCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.logins
+}
+
+// activeSessions returns how many sessions are logged in.
+func (m *mockSwitch) activeSessions() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.sessions)
+}
+
+// requestCount returns how many requests matched method and path.
+func (m *mockSwitch) requestCount(method string, path string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	count := 0
+	for _, request := range m.requests {
+		if request == method+" "+path {
+			count++
+		}
+	}
+	return count
+}
+
+func (m *mockSwitch) handle(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	segments := strings.Split(strings.TrimPrefix(r.URL.EscapedPath(), "/"), "/")
+	if len(segments) < 3 || segments[0] != "rest" {
+		http.NotFound(w, r)
+		return
+	}
+	for index, segment := range segments {
+		if unescaped, err := url.PathUnescape(segment); err == nil {
+			segments[index] = url.PathEscape(unescaped)
+		}
+	}
+	version := segments[1]
+	path := strings.Join(segments[2:], "/")
+
+	m.requests = append(m.requests, r.Method+" "+path)
+
+	for _, fault := range m.faults {
+		if fault.count == 0 || (fault.method != "" && fault.method != r.Method) || !strings.HasPrefix(path, fault.path) {
+			continue
+		}
+		if fault.count > 0 {
+			fault.count--
+		}
+		if fault.status == http.StatusUnauthorized {
+			m.sessions = map[string]bool{}
+		}
+		http.Error(w, http.StatusText(fault.status), fault.status)
+		return
+	}
+
+	switch path {
+	case "login":
+		m.login(w, r)
+		return
+	case "logout":
+		if cookie, err := r.Cookie("id"); err == nil {
+			delete(m.sessions, cookie.Value)
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if cookie, err := r.Cookie("id"); err != nil || !m.sessions[cookie.Value] {
+		http.Error(w, "Login failed: session not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		m.handleGet(w, r, version, path)
+	case http.MethodPost:
+		m.handlePost(w, r, path)
+	case http.MethodPut, http.MethodPatch:
+		m.handleWrite(w, r, path)
+	case http.MethodDelete:
+		if _, ok := m.objects[path]; !ok {
+			http.Error(w, "Object not found", http.StatusNotFound)
+			return
+		}
+		m.deleteLocked(path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (m *mockSwitch) login(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	if r.Form.Get("username") == "" || r.Form.Get("password") == "" {
+		http.Error(w, "Login failed", http.StatusUnauthorized)
+		return
+	}
+
+	token := make([]byte, 16)
+	rand.Read(token)
+	id := hex.EncodeToString(token)
+
+	m.sessions[id] = true
+	m.logins++
+
+	http.SetCookie(w, &http.Cookie{Name: "id", Value: id, Path: "/", Secure: true, HttpOnly: true})
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockSwitch) handleGet(w http.ResponseWriter, r *http.Request, version string, path string) {
+	var attributes []string
+	if value := r.URL.Query().Get("attributes"); value != "" {
+		attributes = strings.Split(value, ",")
+	}
+
+	if obj, ok := m.objects[path]; ok {
+		writeJSON(w, http.StatusOK, filterAttributes(obj, attributes))
+		return
+	}
+
+	collection := path[strings.LastIndex(path, "/")+1:]
+	if _, ok := mockCollections[collection]; !ok {
+		http.Error(w, "Object not found", http.StatusNotFound)
+		return
+	}
+
+	depth, _ := strconv.Atoi(r.URL.Query().Get("depth"))
+
+	members := map[string]interface{}{}
+	for member_path, obj := range m.objects {
+		if !strings.HasPrefix(member_path, path+"/") || strings.Contains(member_path[len(path)+1:], "/") {
+			continue
+		}
+		key := member_path[len(path)+1:]
+		if depth >= 2 {
+			members[key] = filterAttributes(obj, attributes)
+		} else {
+			members[key] = "/rest/" + version + "/" + member_path
+		}
+	}
+
+	writeJSON(w, http.StatusOK, members)
+}
+
+func (m *mockSwitch) handlePost(w http.ResponseWriter, r *http.Request, path string) {
+	collection := path[strings.LastIndex(path, "/")+1:]
+	key_fields, ok := mockCollections[collection]
+	if !ok {
+		http.Error(w, "Collection not found", http.StatusNotFound)
+		return
+	}
+
+	if index := strings.LastIndex(path, "/"); index >= 0 {
+		if _, ok := m.objects[path[:index]]; !ok {
+			http.Error(w, "Parent object not found", http.StatusNotFound)
+			return
+		}
+	}
+
+	obj, err := readObject(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var key_values []string
+	for _, field := range key_fields {
+		value, ok := obj[field]
+		if !ok {
+			http.Error(w, fmt.Sprintf("Missing key attribute %s", field), http.StatusBadRequest)
+			return
+		}
+		// Key attributes referencing other objects contribute the key of
+		// the referenced object
+		if uri, ok := value.(string); ok && strings.HasPrefix(uri, "/rest/") {
+			value = uriKey(uri)
+		}
+		key_values = append(key_values, fmt.Sprint(value))
+	}
+
+	member_path := path + "/" + url.PathEscape(strings.Join(key_values, ","))
+	if _, exists := m.objects[member_path]; exists {
+		http.Error(w, "Object already exists", http.StatusBadRequest)
+		return
+	}
+
+	m.objects[member_path] = obj
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (m *mockSwitch) handleWrite(w http.ResponseWriter, r *http.Request, path string) {
+	body, err := readObject(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	obj, exists := m.objects[path]
+
+	if r.Method == http.MethodPatch {
+		if !exists {
+			http.Error(w, "Object not found", http.StatusNotFound)
+			return
+		}
+		for key, value := range body {
+			obj[key] = value
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	// PUT replaces the configuration but keeps the key attributes
+	if exists {
+		parent := path[:strings.LastIndex(path+"/", "/")]
+		if index := strings.LastIndex(parent, "/"); index >= 0 {
+			for _, field := range mockCollections[parent[index+1:]] {
+				if _, ok := body[field]; !ok {
+					body[field] = obj[field]
+				}
+			}
+		}
+	}
+	m.objects[path] = body
+	w.WriteHeader(http.StatusOK)
+}
+
+// deleteLocked removes path and its children, seeded objects are reset to
+// their defaults instead. m.mu must be held.
+func (m *mockSwitch) deleteLocked(path string) {
+	for obj_path := range m.objects {
+		if obj_path == path || strings.HasPrefix(obj_path, path+"/") {
+			delete(m.objects, obj_path)
+		}
+	}
+	if seed, ok := m.seeded[path]; ok {
+		m.objects[path] = copyObject(seed)
+	}
+}
+
+func readObject(r *http.Request) (map[string]interface{}, error) {
+	obj := map[string]interface{}{}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) == 0 {
+		return obj, nil
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(string(body)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&obj); err != nil {
+		return nil, fmt.Errorf("Invalid JSON body: %v", err)
+	}
+
+	return obj, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+func filterAttributes(obj map[string]interface{}, attributes []string) map[string]interface{} {
+	if len(attributes) == 0 {
+		return obj
+	}
+
+	filtered := map[string]interface{}{}
+	for _, attribute := range attributes {
+		if value, ok := obj[attribute]; ok {
+			filtered[attribute] = value
+		}
+	}
+	return filtered
+}
+
+// copyObject deep copies a decoded JSON object.
+func copyObject(obj map[string]interface{}) map[string]interface{} {
+	encoded, _ := json.Marshal(obj)
+
+	tmp_obj := map[string]interface{}{}
+	decoder := json.NewDecoder(strings.NewReader(string(encoded)))
+	decoder.UseNumber()
+	decoder.Decode(&tmp_obj)
+
+	return tmp_obj
+}
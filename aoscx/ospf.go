@@ -0,0 +1,294 @@
+package aoscx
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aruba/aoscxgo"
+)
+
+// ospfRouters maps OSPF versions to the VRF collection holding their
+// routers, aoscxgo does not cover routing protocols.
+var ospfRouters = map[int]string{
+	2: "ospf_routers",
+	3: "ospfv3_routers",
+}
+
+func ospfRouterPath(vrf_name string, version int, instance_id int) string {
+	return "system/vrfs/" + restPath(vrf_name) + "/" + ospfRouters[version] + "/" + strconv.Itoa(instance_id)
+}
+
+func ospfAreaPath(vrf_name string, version int, instance_id int, area_id string) string {
+	return ospfRouterPath(vrf_name, version, instance_id) + "/areas/" + restPath(area_id)
+}
+
+// ospfRouter is an OSPFv2 or OSPFv3 instance of a VRF.
+type ospfRouter struct {
+	Vrf        string
+	Version    int
+	InstanceId int
+
+	RouterId                string
+	PassiveInterfaceDefault bool
+	Redistribute            []string
+}
+
+// ospfRouterResponse is the REST representation of an OSPF router.
+type ospfRouterResponse struct {
+	RouterId                string   `json:"router_id"`
+	PassiveInterfaceDefault bool     `json:"passive_interface_default"`
+	Redistribute            []string `json:"redistribute"`
+}
+
+func (r *ospfRouter) path() string {
+	return ospfRouterPath(r.Vrf, r.Version, r.InstanceId)
+}
+
+func (r *ospfRouter) body() map[string]interface{} {
+	body := map[string]interface{}{
+		"router_id":                 nil,
+		"passive_interface_default": r.PassiveInterfaceDefault,
+		"redistribute":              r.Redistribute,
+	}
+	if r.RouterId != "" {
+		body["router_id"] = r.RouterId
+	}
+	if r.Redistribute == nil {
+		body["redistribute"] = []string{}
+	}
+
+	return body
+}
+
+// Create creates the OSPF router.
+func (r *ospfRouter) Create(ctx context.Context, sw *aoscxgo.Client) error {
+	body := r.body()
+	body["instance_tag"] = r.InstanceId
+
+	return restRequest(ctx, sw, http.MethodPost, "system/vrfs/"+restPath(r.Vrf)+"/"+ospfRouters[r.Version], body, nil)
+}
+
+// Get retrieves the OSPF router r.InstanceId of r.Vrf.
+func (r *ospfRouter) Get(ctx context.Context, sw *aoscxgo.Client) error {
+	tmp_router := ospfRouterResponse{}
+
+	err := restGet(ctx, sw, r.path(), &tmp_router)
+	if err != nil {
+		return err
+	}
+
+	r.RouterId = tmp_router.RouterId
+	r.PassiveInterfaceDefault = tmp_router.PassiveInterfaceDefault
+	r.Redistribute = append([]string{}, tmp_router.Redistribute...)
+	sort.Strings(r.Redistribute)
+
+	return nil
+}
+
+// Update writes the settings of the OSPF router.
+func (r *ospfRouter) Update(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodPatch, r.path(), r.body(), nil)
+}
+
+// Delete deletes the OSPF router with its areas and interfaces.
+func (r *ospfRouter) Delete(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodDelete, r.path(), nil, nil)
+}
+
+// ospfArea is an area of an OSPF router. Type is default, stub or nssa,
+// NoSummary makes a stub or NSSA area totally stubby.
+type ospfArea struct {
+	Vrf        string
+	Version    int
+	InstanceId int
+	AreaId     string
+
+	Type        string
+	NoSummary   bool
+	DefaultCost int
+}
+
+// ospfAreaResponse is the REST representation of an OSPF area. area_type
+// combines the type and no-summary option, e.g. stub_no_summary.
+type ospfAreaResponse struct {
+	AreaType        string `json:"area_type"`
+	StubDefaultCost int    `json:"stub_default_cost"`
+}
+
+func (a *ospfArea) path() string {
+	return ospfAreaPath(a.Vrf, a.Version, a.InstanceId, a.AreaId)
+}
+
+func (a *ospfArea) body() map[string]interface{} {
+	area_type := a.Type
+	if a.NoSummary && a.Type != "default" {
+		area_type += "_no_summary"
+	}
+
+	return map[string]interface{}{
+		"area_type":         area_type,
+		"stub_default_cost": a.DefaultCost,
+	}
+}
+
+// Create creates the OSPF area.
+func (a *ospfArea) Create(ctx context.Context, sw *aoscxgo.Client) error {
+	body := a.body()
+	body["area_id"] = a.AreaId
+
+	return restRequest(ctx, sw, http.MethodPost, ospfRouterPath(a.Vrf, a.Version, a.InstanceId)+"/areas", body, nil)
+}
+
+// Get retrieves the OSPF area a.AreaId.
+func (a *ospfArea) Get(ctx context.Context, sw *aoscxgo.Client) error {
+	tmp_area := ospfAreaResponse{}
+
+	err := restGet(ctx, sw, a.path(), &tmp_area)
+	if err != nil {
+		return err
+	}
+
+	a.Type = strings.TrimSuffix(tmp_area.AreaType, "_no_summary")
+	a.NoSummary = a.Type != tmp_area.AreaType
+	if a.Type == "" {
+		a.Type = "default"
+	}
+	a.DefaultCost = tmp_area.StubDefaultCost
+
+	return nil
+}
+
+// Update writes the settings of the OSPF area.
+func (a *ospfArea) Update(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodPatch, a.path(), a.body(), nil)
+}
+
+// Delete deletes the OSPF area.
+func (a *ospfArea) Delete(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodDelete, a.path(), nil, nil)
+}
+
+// ospfInterface enables OSPF on a routed interface in an area. AuthKey is
+// write only, the switch does not return it.
+type ospfInterface struct {
+	Vrf        string
+	Version    int
+	InstanceId int
+	AreaId     string
+	Name       string
+
+	Cost          int
+	Priority      int
+	NetworkType   string
+	HelloInterval int
+	DeadInterval  int
+	AuthType      string
+	AuthKey       string
+	AuthKeyId     int
+}
+
+// ospfInterfaceResponse is the REST representation of an OSPF interface.
+type ospfInterfaceResponse struct {
+	Cost      int                    `json:"ospf_if_out_cost"`
+	Priority  int                    `json:"ospf_priority"`
+	Type      string                 `json:"ospf_if_type"`
+	Intervals map[string]int         `json:"ospf_intervals"`
+	AuthType  string                 `json:"ospf_auth_type"`
+	Md5Keys   map[string]interface{} `json:"ospf_auth_md5_keys"`
+}
+
+// ospfNetworkTypes maps network types to their REST value.
+var ospfNetworkTypes = map[string]string{
+	"broadcast":      "ospf_iftype_broadcast",
+	"point-to-point": "ospf_iftype_pointopoint",
+}
+
+func (i *ospfInterface) path() string {
+	return ospfAreaPath(i.Vrf, i.Version, i.InstanceId, i.AreaId) + "/ospf_interfaces/" + restPath(i.Name)
+}
+
+func (i *ospfInterface) body() map[string]interface{} {
+	body := map[string]interface{}{
+		"ospf_if_out_cost": nil,
+		"ospf_priority":    i.Priority,
+		"ospf_if_type":     ospfNetworkTypes[i.NetworkType],
+		"ospf_intervals": map[string]int{
+			"hello_interval": i.HelloInterval,
+			"dead_interval":  i.DeadInterval,
+		},
+		"ospf_auth_type":     nil,
+		"ospf_auth_text_key": nil,
+		"ospf_auth_md5_keys": map[string]string{},
+	}
+	if i.Cost > 0 {
+		body["ospf_if_out_cost"] = i.Cost
+	}
+
+	switch i.AuthType {
+	case "text":
+		body["ospf_auth_type"] = "text"
+		body["ospf_auth_text_key"] = i.AuthKey
+	case "md5":
+		body["ospf_auth_type"] = "md5"
+		body["ospf_auth_md5_keys"] = map[string]string{strconv.Itoa(i.AuthKeyId): i.AuthKey}
+	}
+
+	return body
+}
+
+// Create enables OSPF on the interface.
+func (i *ospfInterface) Create(ctx context.Context, sw *aoscxgo.Client) error {
+	body := i.body()
+	body["name"] = i.Name
+	body["port"] = restURI("system/interfaces/" + restPath(i.Name))
+
+	return restRequest(ctx, sw, http.MethodPost, ospfAreaPath(i.Vrf, i.Version, i.InstanceId, i.AreaId)+"/ospf_interfaces", body, nil)
+}
+
+// Get retrieves the OSPF settings of the interface, except AuthKey.
+func (i *ospfInterface) Get(ctx context.Context, sw *aoscxgo.Client) error {
+	tmp_interface := ospfInterfaceResponse{}
+
+	err := restGet(ctx, sw, i.path(), &tmp_interface)
+	if err != nil {
+		return err
+	}
+
+	i.Cost = tmp_interface.Cost
+	i.Priority = tmp_interface.Priority
+	i.NetworkType = "broadcast"
+	for network_type, value := range ospfNetworkTypes {
+		if value == tmp_interface.Type {
+			i.NetworkType = network_type
+		}
+	}
+	i.HelloInterval = tmp_interface.Intervals["hello_interval"]
+	i.DeadInterval = tmp_interface.Intervals["dead_interval"]
+
+	i.AuthType = "none"
+	i.AuthKeyId = 0
+	switch tmp_interface.AuthType {
+	case "text":
+		i.AuthType = "text"
+	case "md5":
+		i.AuthType = "md5"
+		for key_id := range tmp_interface.Md5Keys {
+			i.AuthKeyId, _ = strconv.Atoi(key_id)
+		}
+	}
+
+	return nil
+}
+
+// Update writes the OSPF settings of the interface.
+func (i *ospfInterface) Update(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodPatch, i.path(), i.body(), nil)
+}
+
+// Delete disables OSPF on the interface.
+func (i *ospfInterface) Delete(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodDelete, i.path(), nil, nil)
+}
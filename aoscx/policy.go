@@ -0,0 +1,392 @@
+package aoscx
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/aruba/aoscxgo"
+)
+
+// Routing policies are prefix lists, route maps and community lists stored
+// under system with their entries in a child collection keyed by preference,
+// the sequence number in the CLI. aoscxgo does not cover them.
+
+// getPolicyEntries retrieves the entries of the collection at path into
+// entries, which must point to a map keyed by preference.
+func getPolicyEntries(ctx context.Context, sw *aoscxgo.Client, path string, entries interface{}) error {
+	err := restGet(ctx, sw, path+"?depth=2", entries)
+	if isNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// createPolicy posts body to the policy collection and creates the entries
+// of wanted in the entry collection at entries_path. It returns whether the
+// policy itself was created, which is also the case when an entry failed.
+func createPolicy(ctx context.Context, sw *aoscxgo.Client, collection string, body map[string]interface{}, entries_path string, wanted map[int]map[string]interface{}) (bool, error) {
+	err := restRequest(ctx, sw, http.MethodPost, collection, body, nil)
+	if err != nil {
+		return false, err
+	}
+
+	return true, updatePolicyEntries(ctx, sw, entries_path, nil, wanted)
+}
+
+// updatePolicyEntries reconciles the entries of the collection at path with
+// wanted by preference, entries that did not change are left alone. Both
+// maps hold the REST bodies of the entries without their preference.
+func updatePolicyEntries(ctx context.Context, sw *aoscxgo.Client, path string, current map[int]map[string]interface{}, wanted map[int]map[string]interface{}) error {
+	preferences := make([]int, 0, len(wanted))
+	for preference := range wanted {
+		preferences = append(preferences, preference)
+	}
+	sort.Ints(preferences)
+
+	for _, preference := range preferences {
+		body := wanted[preference]
+		current_body, ok := current[preference]
+
+		var err error
+		if !ok {
+			post_body := map[string]interface{}{"preference": preference}
+			for key, value := range body {
+				post_body[key] = value
+			}
+			err = restRequest(ctx, sw, http.MethodPost, path, post_body, nil)
+		} else if !reflect.DeepEqual(current_body, body) {
+			err = restRequest(ctx, sw, http.MethodPut, path+"/"+strconv.Itoa(preference), body, nil)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for preference := range current {
+		if _, ok := wanted[preference]; ok {
+			continue
+		}
+		err := restRequest(ctx, sw, http.MethodDelete, path+"/"+strconv.Itoa(preference), nil, nil)
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func prefixListPath(name string) string {
+	return "system/prefix_lists/" + restPath(name)
+}
+
+// prefixList is an IPv4 or IPv6 prefix list.
+type prefixList struct {
+	Name          string
+	AddressFamily string
+
+	Entries []prefixListEntry
+}
+
+// prefixListEntry is an entry of a prefix list. Ge and Le are 0 when the
+// entry only matches Prefix itself.
+type prefixListEntry struct {
+	Preference int    `json:"preference"`
+	Action     string `json:"action"`
+	Prefix     string `json:"prefix"`
+	Ge         int    `json:"ge"`
+	Le         int    `json:"le"`
+}
+
+func (e *prefixListEntry) body() map[string]interface{} {
+	return map[string]interface{}{
+		"action": e.Action,
+		"prefix": e.Prefix,
+		"ge":     e.Ge,
+		"le":     e.Le,
+	}
+}
+
+func prefixListEntryBodies(entries []prefixListEntry) map[int]map[string]interface{} {
+	bodies := map[int]map[string]interface{}{}
+	for _, entry := range entries {
+		bodies[entry.Preference] = entry.body()
+	}
+	return bodies
+}
+
+// Create creates the prefix list and its entries.
+func (p *prefixList) Create(ctx context.Context, sw *aoscxgo.Client) (bool, error) {
+	return createPolicy(ctx, sw, "system/prefix_lists", map[string]interface{}{
+		"name":           p.Name,
+		"address_family": p.AddressFamily,
+	}, prefixListPath(p.Name)+"/prefix_list_entries", prefixListEntryBodies(p.Entries))
+}
+
+// Get retrieves the prefix list p.Name and its entries ordered by
+// preference.
+func (p *prefixList) Get(ctx context.Context, sw *aoscxgo.Client) error {
+	tmp_list := struct {
+		AddressFamily string `json:"address_family"`
+	}{}
+
+	err := restGet(ctx, sw, prefixListPath(p.Name), &tmp_list)
+	if err != nil {
+		return err
+	}
+
+	entries := map[string]prefixListEntry{}
+
+	err = getPolicyEntries(ctx, sw, prefixListPath(p.Name)+"/prefix_list_entries", &entries)
+	if err != nil {
+		return err
+	}
+
+	p.AddressFamily = tmp_list.AddressFamily
+	p.Entries = []prefixListEntry{}
+	for key, entry := range entries {
+		if entry.Preference == 0 {
+			entry.Preference, _ = strconv.Atoi(key)
+		}
+		p.Entries = append(p.Entries, entry)
+	}
+	sort.Slice(p.Entries, func(i, j int) bool {
+		return p.Entries[i].Preference < p.Entries[j].Preference
+	})
+
+	return nil
+}
+
+// Update reconciles the entries of the prefix list with p.Entries.
+func (p *prefixList) Update(ctx context.Context, sw *aoscxgo.Client) error {
+	current := prefixList{Name: p.Name}
+	err := current.Get(ctx, sw)
+	if err != nil {
+		return err
+	}
+
+	return updatePolicyEntries(ctx, sw, prefixListPath(p.Name)+"/prefix_list_entries", prefixListEntryBodies(current.Entries), prefixListEntryBodies(p.Entries))
+}
+
+// Delete deletes the prefix list and its entries.
+func (p *prefixList) Delete(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodDelete, prefixListPath(p.Name), nil, nil)
+}
+
+func routeMapPath(name string) string {
+	return "system/route_maps/" + restPath(name)
+}
+
+func routeMapURI(name string) string {
+	return restURI(routeMapPath(name))
+}
+
+// routeMap is a route map with its entries.
+type routeMap struct {
+	Name string
+
+	Entries []routeMapEntry
+}
+
+// routeMapEntry is an entry of a route map. Match and Set hold its match and
+// set clauses keyed by their REST name, e.g. ipv4_prefix_list or
+// local_preference, with the values as strings.
+type routeMapEntry struct {
+	Preference  int               `json:"preference"`
+	Action      string            `json:"action"`
+	Description string            `json:"description"`
+	Match       map[string]string `json:"match"`
+	Set         map[string]string `json:"set"`
+}
+
+func (e *routeMapEntry) body() map[string]interface{} {
+	body := map[string]interface{}{
+		"action":      e.Action,
+		"description": nil,
+		"match":       map[string]string{},
+		"set":         map[string]string{},
+	}
+	if e.Description != "" {
+		body["description"] = e.Description
+	}
+	if e.Match != nil {
+		body["match"] = e.Match
+	}
+	if e.Set != nil {
+		body["set"] = e.Set
+	}
+
+	return body
+}
+
+func routeMapEntryBodies(entries []routeMapEntry) map[int]map[string]interface{} {
+	bodies := map[int]map[string]interface{}{}
+	for _, entry := range entries {
+		bodies[entry.Preference] = entry.body()
+	}
+	return bodies
+}
+
+// Create creates the route map and its entries.
+func (r *routeMap) Create(ctx context.Context, sw *aoscxgo.Client) (bool, error) {
+	return createPolicy(ctx, sw, "system/route_maps", map[string]interface{}{
+		"name": r.Name,
+	}, routeMapPath(r.Name)+"/route_map_entries", routeMapEntryBodies(r.Entries))
+}
+
+// Get retrieves the route map r.Name and its entries ordered by preference.
+func (r *routeMap) Get(ctx context.Context, sw *aoscxgo.Client) error {
+	err := restGet(ctx, sw, routeMapPath(r.Name), &map[string]interface{}{})
+	if err != nil {
+		return err
+	}
+
+	entries := map[string]routeMapEntry{}
+
+	err = getPolicyEntries(ctx, sw, routeMapPath(r.Name)+"/route_map_entries", &entries)
+	if err != nil {
+		return err
+	}
+
+	r.Entries = []routeMapEntry{}
+	for key, entry := range entries {
+		if entry.Preference == 0 {
+			entry.Preference, _ = strconv.Atoi(key)
+		}
+		if entry.Match == nil {
+			entry.Match = map[string]string{}
+		}
+		if entry.Set == nil {
+			entry.Set = map[string]string{}
+		}
+		r.Entries = append(r.Entries, entry)
+	}
+	sort.Slice(r.Entries, func(i, j int) bool {
+		return r.Entries[i].Preference < r.Entries[j].Preference
+	})
+
+	return nil
+}
+
+// Update reconciles the entries of the route map with r.Entries.
+func (r *routeMap) Update(ctx context.Context, sw *aoscxgo.Client) error {
+	current := routeMap{Name: r.Name}
+	err := current.Get(ctx, sw)
+	if err != nil {
+		return err
+	}
+
+	return updatePolicyEntries(ctx, sw, routeMapPath(r.Name)+"/route_map_entries", routeMapEntryBodies(current.Entries), routeMapEntryBodies(r.Entries))
+}
+
+// Delete deletes the route map and its entries.
+func (r *routeMap) Delete(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodDelete, routeMapPath(r.Name), nil, nil)
+}
+
+// communityListTypes maps the community list types to their REST value.
+var communityListTypes = map[string]string{
+	"standard": "community-list",
+	"expanded": "community-expanded-list",
+}
+
+func communityListPath(name string) string {
+	return "system/community_lists/" + restPath(name)
+}
+
+// communityList is a standard or expanded BGP community list. Entries of
+// standard lists match communities, those of expanded lists regular
+// expressions.
+type communityList struct {
+	Name string
+	Type string
+
+	Entries []communityListEntry
+}
+
+// communityListEntry is an entry of a community list.
+type communityListEntry struct {
+	Preference  int    `json:"preference"`
+	Action      string `json:"action"`
+	MatchString string `json:"match_string"`
+}
+
+func (e *communityListEntry) body() map[string]interface{} {
+	return map[string]interface{}{
+		"action":       e.Action,
+		"match_string": e.MatchString,
+	}
+}
+
+func communityListEntryBodies(entries []communityListEntry) map[int]map[string]interface{} {
+	bodies := map[int]map[string]interface{}{}
+	for _, entry := range entries {
+		bodies[entry.Preference] = entry.body()
+	}
+	return bodies
+}
+
+// Create creates the community list and its entries.
+func (c *communityList) Create(ctx context.Context, sw *aoscxgo.Client) (bool, error) {
+	return createPolicy(ctx, sw, "system/community_lists", map[string]interface{}{
+		"name": c.Name,
+		"type": communityListTypes[c.Type],
+	}, communityListPath(c.Name)+"/community_list_entries", communityListEntryBodies(c.Entries))
+}
+
+// Get retrieves the community list c.Name and its entries ordered by
+// preference.
+func (c *communityList) Get(ctx context.Context, sw *aoscxgo.Client) error {
+	tmp_list := struct {
+		Type string `json:"type"`
+	}{}
+
+	err := restGet(ctx, sw, communityListPath(c.Name), &tmp_list)
+	if err != nil {
+		return err
+	}
+
+	entries := map[string]communityListEntry{}
+
+	err = getPolicyEntries(ctx, sw, communityListPath(c.Name)+"/community_list_entries", &entries)
+	if err != nil {
+		return err
+	}
+
+	c.Type = tmp_list.Type
+	for list_type, value := range communityListTypes {
+		if value == tmp_list.Type {
+			c.Type = list_type
+		}
+	}
+	c.Entries = []communityListEntry{}
+	for key, entry := range entries {
+		if entry.Preference == 0 {
+			entry.Preference, _ = strconv.Atoi(key)
+		}
+		c.Entries = append(c.Entries, entry)
+	}
+	sort.Slice(c.Entries, func(i, j int) bool {
+		return c.Entries[i].Preference < c.Entries[j].Preference
+	})
+
+	return nil
+}
+
+// Update reconciles the entries of the community list with c.Entries.
+func (c *communityList) Update(ctx context.Context, sw *aoscxgo.Client) error {
+	current := communityList{Name: c.Name}
+	err := current.Get(ctx, sw)
+	if err != nil {
+		return err
+	}
+
+	return updatePolicyEntries(ctx, sw, communityListPath(c.Name)+"/community_list_entries", communityListEntryBodies(current.Entries), communityListEntryBodies(c.Entries))
+}
+
+// Delete deletes the community list and its entries.
+func (c *communityList) Delete(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodDelete, communityListPath(c.Name), nil, nil)
+}
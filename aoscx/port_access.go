@@ -0,0 +1,454 @@
+package aoscx
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/aruba/aoscxgo"
+)
+
+// aoscxgo does not cover AAA and port access. RADIUS servers belong to a VRF
+// and are keyed by address, port and port type, udp for plain RADIUS. Servers
+// reference the AAA server group they are in, servers without a group are in
+// the built-in radius group.
+
+// defaultServerGroup is the built-in AAA server group of RADIUS servers.
+const defaultServerGroup = "radius"
+
+// portAccessMethods maps the port access authentication methods to their
+// REST key.
+var portAccessMethods = map[string]string{
+	"dot1x":    "802.1x",
+	"mac_auth": "mac-auth",
+}
+
+func radiusServerPath(vrf_name string, address string, port int) string {
+	return "system/vrfs/" + restPath(vrf_name) + "/radius_servers/" + restPath(address+","+strconv.Itoa(port)+",udp")
+}
+
+func aaaServerGroupPath(name string) string {
+	return "system/aaa_server_groups/" + restPath(name)
+}
+
+func portAccessRolePath(name string) string {
+	return "system/port_access_roles/" + restPath(name)
+}
+
+// radiusServer is a RADIUS server. Key is the shared secret, which the
+// switch only returns encrypted.
+type radiusServer struct {
+	Vrf     string
+	Address string
+	Port    int
+
+	Key         string
+	Timeout     int
+	Retries     int
+	ServerGroup string
+}
+
+// radiusServerResponse is the REST representation of a RADIUS server.
+type radiusServerResponse struct {
+	Timeout int      `json:"timeout"`
+	Retries int      `json:"retries"`
+	Group   []string `json:"group"`
+}
+
+func (r *radiusServer) path() string {
+	return radiusServerPath(r.Vrf, r.Address, r.Port)
+}
+
+func (r *radiusServer) body() map[string]interface{} {
+	group := r.ServerGroup
+	if group == "" {
+		group = defaultServerGroup
+	}
+
+	return map[string]interface{}{
+		"passkey": r.Key,
+		"timeout": r.Timeout,
+		"retries": r.Retries,
+		"group":   []string{restURI(aaaServerGroupPath(group))},
+	}
+}
+
+// Create creates the RADIUS server.
+func (r *radiusServer) Create(ctx context.Context, sw *aoscxgo.Client) error {
+	body := r.body()
+	body["address"] = r.Address
+	body["port"] = r.Port
+	body["port_type"] = "udp"
+
+	return restRequest(ctx, sw, http.MethodPost, "system/vrfs/"+restPath(r.Vrf)+"/radius_servers", body, nil)
+}
+
+// Get retrieves the RADIUS server, except its key.
+func (r *radiusServer) Get(ctx context.Context, sw *aoscxgo.Client) error {
+	tmp_server := radiusServerResponse{}
+
+	err := restGet(ctx, sw, r.path(), &tmp_server)
+	if err != nil {
+		return err
+	}
+
+	r.Timeout = tmp_server.Timeout
+	r.Retries = tmp_server.Retries
+	r.ServerGroup = ""
+	for _, uri := range tmp_server.Group {
+		if group := uriKey(uri); group != defaultServerGroup {
+			r.ServerGroup = group
+		}
+	}
+
+	return nil
+}
+
+// Update writes the settings of the RADIUS server.
+func (r *radiusServer) Update(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodPatch, r.path(), r.body(), nil)
+}
+
+// Delete deletes the RADIUS server.
+func (r *radiusServer) Delete(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodDelete, r.path(), nil, nil)
+}
+
+// aaaServerGroup is a group of RADIUS servers. Dot1x and MacAuth select the
+// group for 802.1X and MAC authentication instead of the radius group, they
+// are attributes of the system.
+type aaaServerGroup struct {
+	Name string
+
+	Dot1x   bool
+	MacAuth bool
+}
+
+// aaaAuthenticationGroups are the system attributes selecting the server
+// group of the port access authentication methods.
+type aaaAuthenticationGroups struct {
+	Dot1x   string `json:"dot1x_authentication_server_group"`
+	MacAuth string `json:"mac_authentication_server_group"`
+}
+
+// Create creates the server group and selects it for port access
+// authentication. It returns whether the group was created, the group is
+// left on the switch when selecting it fails.
+func (g *aaaServerGroup) Create(ctx context.Context, sw *aoscxgo.Client) (bool, error) {
+	err := restRequest(ctx, sw, http.MethodPost, "system/aaa_server_groups", map[string]interface{}{
+		"group_name": g.Name,
+		"group_type": "radius",
+	}, nil)
+	if err != nil {
+		return false, err
+	}
+
+	return true, g.Update(ctx, sw)
+}
+
+// Get retrieves the server group g.Name and whether it is selected for port
+// access authentication.
+func (g *aaaServerGroup) Get(ctx context.Context, sw *aoscxgo.Client) error {
+	err := restGet(ctx, sw, aaaServerGroupPath(g.Name), &map[string]interface{}{})
+	if err != nil {
+		return err
+	}
+
+	tmp_groups := aaaAuthenticationGroups{}
+
+	err = restGet(ctx, sw, "system?attributes=dot1x_authentication_server_group,mac_authentication_server_group", &tmp_groups)
+	if err != nil {
+		return err
+	}
+
+	uri := restURI(aaaServerGroupPath(g.Name))
+	g.Dot1x = tmp_groups.Dot1x == uri
+	g.MacAuth = tmp_groups.MacAuth == uri
+
+	return nil
+}
+
+// Update selects the server group for the port access authentication
+// methods in g and deselects it for the others.
+func (g *aaaServerGroup) Update(ctx context.Context, sw *aoscxgo.Client) error {
+	tmp_groups := aaaAuthenticationGroups{}
+
+	err := restGet(ctx, sw, "system?attributes=dot1x_authentication_server_group,mac_authentication_server_group", &tmp_groups)
+	if err != nil {
+		return err
+	}
+
+	uri := restURI(aaaServerGroupPath(g.Name))
+	body := map[string]interface{}{}
+	if g.Dot1x && tmp_groups.Dot1x != uri {
+		body["dot1x_authentication_server_group"] = uri
+	} else if !g.Dot1x && tmp_groups.Dot1x == uri {
+		body["dot1x_authentication_server_group"] = nil
+	}
+	if g.MacAuth && tmp_groups.MacAuth != uri {
+		body["mac_authentication_server_group"] = uri
+	} else if !g.MacAuth && tmp_groups.MacAuth == uri {
+		body["mac_authentication_server_group"] = nil
+	}
+	if len(body) == 0 {
+		return nil
+	}
+
+	return restRequest(ctx, sw, http.MethodPatch, "system", body, nil)
+}
+
+// Delete deselects the server group for port access authentication and
+// deletes it.
+func (g *aaaServerGroup) Delete(ctx context.Context, sw *aoscxgo.Client) error {
+	unused := aaaServerGroup{Name: g.Name}
+	err := unused.Update(ctx, sw)
+	if err != nil {
+		return err
+	}
+
+	return restRequest(ctx, sw, http.MethodDelete, aaaServerGroupPath(g.Name), nil, nil)
+}
+
+// portAccessRole is a role assigned to clients authenticated on a port.
+// VlanAccess is the untagged VLAN of the clients, 0 keeps the VLAN of the
+// port. ReauthPeriod 0 uses the reauthentication period of the port.
+type portAccessRole struct {
+	Name string
+
+	Description  string
+	VlanAccess   int
+	VlanTrunks   []int
+	ReauthPeriod int
+}
+
+// portAccessRoleResponse is the REST representation of a port access role.
+type portAccessRoleResponse struct {
+	Description  string   `json:"description"`
+	VlanTag      string   `json:"vlan_tag"`
+	VlanTrunks   []string `json:"vlan_trunks"`
+	ReauthPeriod int      `json:"reauth_period"`
+}
+
+func (r *portAccessRole) body() map[string]interface{} {
+	vlan_trunks := []string{}
+	for _, vlan_id := range r.VlanTrunks {
+		vlan_trunks = append(vlan_trunks, restURI("system/vlans/"+strconv.Itoa(vlan_id)))
+	}
+
+	body := map[string]interface{}{
+		"description":   nil,
+		"vlan_tag":      nil,
+		"vlan_trunks":   vlan_trunks,
+		"reauth_period": nil,
+	}
+	if r.Description != "" {
+		body["description"] = r.Description
+	}
+	if r.VlanAccess != 0 {
+		body["vlan_tag"] = restURI("system/vlans/" + strconv.Itoa(r.VlanAccess))
+	}
+	if r.ReauthPeriod != 0 {
+		body["reauth_period"] = r.ReauthPeriod
+	}
+
+	return body
+}
+
+// Create creates the port access role.
+func (r *portAccessRole) Create(ctx context.Context, sw *aoscxgo.Client) error {
+	body := r.body()
+	body["name"] = r.Name
+
+	return restRequest(ctx, sw, http.MethodPost, "system/port_access_roles", body, nil)
+}
+
+// Get retrieves the port access role r.Name.
+func (r *portAccessRole) Get(ctx context.Context, sw *aoscxgo.Client) error {
+	tmp_role := portAccessRoleResponse{}
+
+	err := restGet(ctx, sw, portAccessRolePath(r.Name), &tmp_role)
+	if err != nil {
+		return err
+	}
+
+	r.Description = tmp_role.Description
+	r.VlanAccess = 0
+	if tmp_role.VlanTag != "" {
+		r.VlanAccess, _ = strconv.Atoi(uriKey(tmp_role.VlanTag))
+	}
+	r.VlanTrunks = []int{}
+	for _, uri := range tmp_role.VlanTrunks {
+		vlan_id, _ := strconv.Atoi(uriKey(uri))
+		r.VlanTrunks = append(r.VlanTrunks, vlan_id)
+	}
+	sort.Ints(r.VlanTrunks)
+	r.ReauthPeriod = tmp_role.ReauthPeriod
+
+	return nil
+}
+
+// Update writes the settings of the port access role.
+func (r *portAccessRole) Update(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodPatch, portAccessRolePath(r.Name), r.body(), nil)
+}
+
+// Delete deletes the port access role.
+func (r *portAccessRole) Delete(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodDelete, portAccessRolePath(r.Name), nil, nil)
+}
+
+// portAccess is the port access configuration of an interface. Methods holds
+// the enabled authentication methods, dot1x and mac_auth. ReauthPeriod 0
+// disables reauthentication, FallbackRole is the role of clients when no
+// RADIUS server is reachable.
+type portAccess struct {
+	Name string
+
+	Methods      []string
+	ReauthPeriod int
+	ClientLimit  int
+	FallbackRole string
+}
+
+// portAccessAuthConfig is the REST representation of an authentication
+// method of a port.
+type portAccessAuthConfig struct {
+	AuthEnable   bool `json:"auth_enable"`
+	ReauthEnable bool `json:"reauth_enable"`
+	ReauthPeriod int  `json:"reauth_period"`
+}
+
+func (p *portAccess) path() string {
+	return "system/interfaces/" + restPath(p.Name)
+}
+
+func (p *portAccess) body() map[string]interface{} {
+	body := map[string]interface{}{
+		"port_access_clients_limit": p.ClientLimit,
+		"port_access_fallback_role": nil,
+	}
+	if p.FallbackRole != "" {
+		body["port_access_fallback_role"] = restURI(portAccessRolePath(p.FallbackRole))
+	}
+
+	return body
+}
+
+// Get retrieves the port access configuration of the interface p.Name.
+func (p *portAccess) Get(ctx context.Context, sw *aoscxgo.Client) error {
+	// Interfaces without port access configuration accept a single client
+	tmp_interface := struct {
+		ClientLimit  int    `json:"port_access_clients_limit"`
+		FallbackRole string `json:"port_access_fallback_role"`
+	}{
+		ClientLimit: 1,
+	}
+
+	err := restGet(ctx, sw, p.path()+"?attributes=port_access_clients_limit,port_access_fallback_role", &tmp_interface)
+	if err != nil {
+		return err
+	}
+
+	auth_configs := map[string]portAccessAuthConfig{}
+
+	err = restGet(ctx, sw, p.path()+"/port_access_auth_configurations?depth=2", &auth_configs)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+
+	p.ClientLimit = tmp_interface.ClientLimit
+	p.FallbackRole = ""
+	if tmp_interface.FallbackRole != "" {
+		p.FallbackRole = uriKey(tmp_interface.FallbackRole)
+	}
+	p.Methods = []string{}
+	p.ReauthPeriod = 0
+	for _, method := range []string{"dot1x", "mac_auth"} {
+		auth_config, ok := auth_configs[portAccessMethods[method]]
+		if !ok || !auth_config.AuthEnable {
+			continue
+		}
+		p.Methods = append(p.Methods, method)
+		if auth_config.ReauthEnable {
+			p.ReauthPeriod = auth_config.ReauthPeriod
+		}
+	}
+
+	return nil
+}
+
+// Update writes the port access configuration of the interface and
+// reconciles its authentication methods with p.Methods.
+func (p *portAccess) Update(ctx context.Context, sw *aoscxgo.Client) error {
+	err := restRequest(ctx, sw, http.MethodPatch, p.path(), p.body(), nil)
+	if err != nil {
+		return err
+	}
+
+	return p.updateMethods(ctx, sw)
+}
+
+func (p *portAccess) updateMethods(ctx context.Context, sw *aoscxgo.Client) error {
+	path := p.path() + "/port_access_auth_configurations"
+	current := map[string]portAccessAuthConfig{}
+
+	err := restGet(ctx, sw, path+"?depth=2", &current)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+
+	wanted := portAccessAuthConfig{
+		AuthEnable:   true,
+		ReauthEnable: p.ReauthPeriod != 0,
+		ReauthPeriod: p.ReauthPeriod,
+	}
+	enabled := map[string]bool{}
+	for _, method := range p.Methods {
+		enabled[portAccessMethods[method]] = true
+	}
+
+	for _, method := range []string{"dot1x", "mac_auth"} {
+		key := portAccessMethods[method]
+		current_config, ok := current[key]
+
+		if !enabled[key] {
+			if !ok {
+				continue
+			}
+			err = restRequest(ctx, sw, http.MethodDelete, path+"/"+restPath(key), nil, nil)
+			if isNotFound(err) {
+				err = nil
+			}
+		} else if !ok {
+			err = restRequest(ctx, sw, http.MethodPost, path, map[string]interface{}{
+				"authentication_method": key,
+				"auth_enable":           wanted.AuthEnable,
+				"reauth_enable":         wanted.ReauthEnable,
+				"reauth_period":         wanted.ReauthPeriod,
+			}, nil)
+		} else if current_config != wanted {
+			err = restRequest(ctx, sw, http.MethodPut, path+"/"+restPath(key), wanted, nil)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delete disables the authentication methods of the interface and restores
+// its port access defaults.
+func (p *portAccess) Delete(ctx context.Context, sw *aoscxgo.Client) error {
+	disabled := portAccess{Name: p.Name}
+	err := disabled.updateMethods(ctx, sw)
+	if err != nil {
+		return err
+	}
+
+	return restRequest(ctx, sw, http.MethodPatch, p.path(), map[string]interface{}{
+		"port_access_clients_limit": nil,
+		"port_access_fallback_role": nil,
+	}, nil)
+}
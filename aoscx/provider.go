@@ -2,13 +2,15 @@ package aoscx
 
 import (
 	"context"
-	"crypto/tls"
+	"fmt"
 	"net/http"
+	"time"
 
-	"github.com/aruba/aoscxgo"
+	"github.com/hashicorp/go-cty/cty"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 type Aoscx struct {
@@ -19,35 +21,146 @@ type Aoscx struct {
 	cookie       *http.Cookie
 }
 
+// defaultResourceTimeout is the default create, read, update and delete
+// timeout of resources managing individual switch objects.
+const defaultResourceTimeout = 5 * time.Minute
+
 func Provider() *schema.Provider {
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"hostname": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Optional:    false,
-				Description: "Hostname/IP address of the AOS-CX switch to connect to",
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AOSCX_HOSTNAME", nil),
+				Description: "Hostname/IP address of the AOS-CX switch to connect to. Can also be set with the AOSCX_HOSTNAME environment variable",
+			},
+			"switches": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Map of switch aliases to hostnames. Resources select one of them with their switch attribute, all switches share the provider credentials and TLS settings",
 			},
 			"username": {
 				Type:        schema.TypeString,
-				Optional:    false,
-				Required:    true,
-				Description: "Username used to authenticate",
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AOSCX_USERNAME", nil),
+				Description: "Username used to authenticate. Can also be set with the AOSCX_USERNAME environment variable",
 			},
 			"password": {
 				Type:        schema.TypeString,
-				Optional:    false,
-				Required:    true,
-				Description: "Password used to authenticate",
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("AOSCX_PASSWORD", nil),
+				Description: "Password used to authenticate. Can also be set with the AOSCX_PASSWORD environment variable",
+			},
+			"credentials_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AOSCX_CREDENTIALS_FILE", nil),
+				Description: "Path to an INI or YAML file holding hostname, username and password per profile. Values set in the provider block or environment take precedence. Can also be set with the AOSCX_CREDENTIALS_FILE environment variable",
+			},
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AOSCX_PROFILE", "default"),
+				Description: "Profile of credentials_file to use, defaults to default. Can also be set with the AOSCX_PROFILE environment variable",
+			},
+			"insecure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AOSCX_INSECURE", false),
+				Description: "Skip verification of the switch TLS certificate. Can also be set with the AOSCX_INSECURE environment variable",
+			},
+			"ca_cert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AOSCX_CA_CERT_FILE", nil),
+				Description: "Path to a PEM encoded CA bundle used to verify the switch TLS certificate. Can also be set with the AOSCX_CA_CERT_FILE environment variable",
+			},
+			"ca_cert_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AOSCX_CA_CERT_PEM", nil),
+				Description: "PEM encoded CA bundle used to verify the switch TLS certificate. Can also be set with the AOSCX_CA_CERT_PEM environment variable",
+			},
+			"client_cert": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AOSCX_CLIENT_CERT", nil),
+				Description: "PEM encoded client certificate, or path to one, used for mutual TLS. Can also be set with the AOSCX_CLIENT_CERT environment variable",
+			},
+			"client_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("AOSCX_CLIENT_KEY", nil),
+				Description: "PEM encoded client private key, or path to one, used for mutual TLS. Can also be set with the AOSCX_CLIENT_KEY environment variable",
+			},
+			"tls_server_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AOSCX_TLS_SERVER_NAME", nil),
+				Description: "Server name used to verify the switch TLS certificate when it differs from hostname. Can also be set with the AOSCX_TLS_SERVER_NAME environment variable",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("AOSCX_MAX_RETRIES", 3),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Number of times a request failing with a transient error is retried, 0 disables retries. Can also be set with the AOSCX_MAX_RETRIES environment variable",
+			},
+			"retry_min_backoff": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("AOSCX_RETRY_MIN_BACKOFF", "1s"),
+				ValidateDiagFunc: validateDuration,
+				Description:      "Minimum time to wait before retrying a request, e.g. 500ms. Can also be set with the AOSCX_RETRY_MIN_BACKOFF environment variable",
+			},
+			"retry_max_backoff": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("AOSCX_RETRY_MAX_BACKOFF", "30s"),
+				ValidateDiagFunc: validateDuration,
+				Description:      "Maximum time to wait before retrying a request, e.g. 30s. Can also be set with the AOSCX_RETRY_MAX_BACKOFF environment variable",
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"aoscx_vlan":           resourceVlan(),
-			"aoscx_interface":      resourceInterface(),
-			"aoscx_l2_interface":   resourceL2Interface(),
-			"aoscx_l3_interface":   resourceL3Interface(),
-			"aoscx_vlan_interface": resourceVlanInterface(),
-			"aoscx_full_config":    resourceFullConfig(),
+			"aoscx_vlan":                    resourceVlan(),
+			"aoscx_interface":               resourceInterface(),
+			"aoscx_l2_interface":            resourceL2Interface(),
+			"aoscx_l3_interface":            resourceL3Interface(),
+			"aoscx_vlan_interface":          resourceVlanInterface(),
+			"aoscx_full_config":             resourceFullConfig(),
+			"aoscx_vrf":                     resourceVrf(),
+			"aoscx_static_route":            resourceStaticRoute(),
+			"aoscx_lag":                     resourceLag(),
+			"aoscx_loopback_interface":      resourceLoopbackInterface(),
+			"aoscx_subinterface":            resourceSubinterface(),
+			"aoscx_vsx":                     resourceVsx(),
+			"aoscx_acl":                     resourceAcl(),
+			"aoscx_acl_application":         resourceAclApplication(),
+			"aoscx_ospf_router":             resourceOspfRouter(),
+			"aoscx_ospf_area":               resourceOspfArea(),
+			"aoscx_ospf_interface":          resourceOspfInterface(),
+			"aoscx_bgp_router":              resourceBgpRouter(),
+			"aoscx_bgp_neighbor":            resourceBgpNeighbor(),
+			"aoscx_bgp_address_family":      resourceBgpAddressFamily(),
+			"aoscx_prefix_list":             resourcePrefixList(),
+			"aoscx_route_map":               resourceRouteMap(),
+			"aoscx_community_list":          resourceCommunityList(),
+			"aoscx_spanning_tree":           resourceSpanningTree(),
+			"aoscx_spanning_tree_interface": resourceSpanningTreeInterface(),
+			"aoscx_radius_server":           resourceRadiusServer(),
+			"aoscx_aaa_server_group":        resourceAaaServerGroup(),
+			"aoscx_port_access_role":        resourcePortAccessRole(),
+			"aoscx_port_access":             resourcePortAccess(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"aoscx_vlan":       dataSourceVlan(),
+			"aoscx_vlans":      dataSourceVlans(),
+			"aoscx_interface":  dataSourceInterface(),
+			"aoscx_interfaces": dataSourceInterfaces(),
+			"aoscx_vrf":        dataSourceVrf(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
@@ -60,32 +173,98 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 
 	var diags diag.Diagnostics
 
-	if (hostname != "") && (username != "") && (password != "") {
-		tr := &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	// Values from the provider block or environment take precedence over the
+	// credentials file
+	if credentials_file := d.Get("credentials_file").(string); credentials_file != "" {
+		creds, err := readCredentialsProfile(credentials_file, d.Get("profile").(string))
+
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Unable to read AOS-CX credentials file",
+				Detail:        err.Error(),
+				AttributePath: cty.GetAttrPath("credentials_file"),
+			})
+			return nil, diags
+		}
+
+		if hostname == "" {
+			hostname = creds.Hostname
+		}
+		if username == "" {
+			username = creds.Username
+		}
+		if password == "" {
+			password = creds.Password
+		}
+	}
+
+	switches := map[string]string{}
+	for alias, switch_hostname := range d.Get("switches").(map[string]interface{}) {
+		switches[alias] = switch_hostname.(string)
+	}
+
+	if (hostname != "" || len(switches) > 0) && (username != "") && (password != "") {
+		tls_config, tls_diags := tlsConfigFromProvider(d)
+		if tls_diags.HasError() {
+			return nil, tls_diags
 		}
 
-		sw, err := aoscxgo.Connect(
-			&aoscxgo.Client{
-				Hostname:  hostname,
-				Username:  username,
-				Password:  password,
-				Transport: tr,
+		min_backoff, _ := time.ParseDuration(d.Get("retry_min_backoff").(string))
+		max_backoff, _ := time.ParseDuration(d.Get("retry_max_backoff").(string))
+		if max_backoff < min_backoff {
+			max_backoff = min_backoff
+		}
+
+		meta := &providerMeta{
+			hostname: hostname,
+			switches: switches,
+			username: username,
+			password: password,
+			transport: &retryTransport{
+				next: &http.Transport{
+					TLSClientConfig: tls_config,
+				},
+				max_retries: d.Get("max_retries").(int),
+				min_backoff: min_backoff,
+				max_backoff: max_backoff,
 			},
-		)
+			conns: map[string]*switchConn{},
+		}
 
-		if (sw.Cookie == nil) || (err != nil) {
-			return nil, diag.FromErr(err)
+		// Switches of the switches map are logged in to when first used
+		if diags = meta.connectDefault(); diags.HasError() {
+			return nil, diags
 		}
 
-		return sw, diags
+		return meta, diags
 	}
 
 	diags = append(diags, diag.Diagnostic{
 		Severity: diag.Error,
 		Summary:  "Unable to create AOS-CX client",
-		Detail:   "Invalid or no values found for hostname, username, password",
+		Detail: "Invalid or no values found for hostname or switches, username, password. Set them in the provider block, " +
+			"through the AOSCX_HOSTNAME, AOSCX_USERNAME and AOSCX_PASSWORD environment variables or in credentials_file",
 	})
 
 	return nil, diags
 }
+
+// validateDuration checks that a provider argument holds a positive Go
+// duration such as 500ms or 30s.
+func validateDuration(v interface{}, path cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	duration, err := time.ParseDuration(v.(string))
+
+	if err != nil || duration <= 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Invalid duration",
+			Detail:        fmt.Sprintf("%q is not a positive duration such as 500ms or 30s", v),
+			AttributePath: path,
+		})
+	}
+
+	return diags
+}
@@ -0,0 +1,167 @@
+package aoscx
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testProviderFactories serves the provider in-process to the Terraform CLI
+// run by resource.UnitTest.
+var testProviderFactories = map[string]func() (*schema.Provider, error){
+	"aoscx": func() (*schema.Provider, error) {
+		return Provider(), nil
+	},
+}
+
+// testProviderConfig returns a provider block pointing at the fake switch m
+// with short retry backoffs so fault injection tests run quickly.
+func testProviderConfig(m *mockSwitch) string {
+	return fmt.Sprintf(`
+provider "aoscx" {
+  hostname          = %q
+  username          = "admin"
+  password          = "admin"
+  insecure          = true
+  max_retries       = 2
+  retry_min_backoff = "10ms"
+  retry_max_backoff = "50ms"
+}
+`, m.hostname())
+}
+
+// testCheckMockExists checks that the fake switch stores an object at path.
+func testCheckMockExists(m *mockSwitch, path string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if !m.exists(path) {
+			return fmt.Errorf("%s does not exist on the mock switch", path)
+		}
+		return nil
+	}
+}
+
+// testCheckMockAttr checks that the attribute of the object at path is value,
+// a missing or null attribute matches "".
+func testCheckMockAttr(m *mockSwitch, path string, attribute string, value string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		obj := m.get(path)
+		if obj == nil {
+			return fmt.Errorf("%s does not exist on the mock switch", path)
+		}
+
+		actual := ""
+		if obj[attribute] != nil {
+			actual = fmt.Sprint(obj[attribute])
+		}
+		if actual != value {
+			return fmt.Errorf("%s of %s is %q, expected %q", attribute, path, actual, value)
+		}
+		return nil
+	}
+}
+
+// testCheckMockDestroyed checks that the object at path was deleted, or
+// reset to its defaults when it is seeded like a physical interface.
+func testCheckMockDestroyed(m *mockSwitch, path string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		obj := m.get(path)
+		if obj == nil {
+			return nil
+		}
+
+		m.mu.Lock()
+		seed, seeded := m.seeded[path]
+		m.mu.Unlock()
+
+		if !seeded {
+			return fmt.Errorf("%s still exists on the mock switch", path)
+		}
+		if fmt.Sprint(obj) != fmt.Sprint(seed) {
+			return fmt.Errorf("%s was not reset to its defaults: %v", path, obj)
+		}
+		return nil
+	}
+}
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestProviderLoginFailure(t *testing.T) {
+	m := newMockSwitch(t)
+	m.injectFault("POST", "login", 401, -1)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testProviderConfig(m) + `
+data "aoscx_vlan" "test" {
+  vlan_id = 1
+}
+`,
+				ExpectError: regexp.MustCompile(`Unable to log in to`),
+			},
+		},
+	})
+}
+
+func TestProviderMultipleSwitches(t *testing.T) {
+	leaf1 := newMockSwitch(t)
+	leaf2 := newMockSwitch(t)
+
+	config := fmt.Sprintf(`
+provider "aoscx" {
+  username = "admin"
+  password = "admin"
+  insecure = true
+  switches = {
+    leaf1 = %q
+    leaf2 = %q
+  }
+}
+
+resource "aoscx_vlan" "leaf1" {
+  switch  = "leaf1"
+  vlan_id = 42
+  name    = "leaf1 vlan"
+}
+
+resource "aoscx_vlan" "leaf2" {
+  switch  = "leaf2"
+  vlan_id = 42
+  name    = "leaf2 vlan"
+}
+`, leaf1.hostname(), leaf2.hostname())
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckMockDestroyed(leaf1, "system/vlans/42"),
+			testCheckMockDestroyed(leaf2, "system/vlans/42"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_vlan.leaf1", "id", "42@leaf1"),
+					resource.TestCheckResourceAttr("aoscx_vlan.leaf2", "id", "42@leaf2"),
+					testCheckMockExists(leaf1, "system/vlans/42"),
+					testCheckMockExists(leaf2, "system/vlans/42"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_vlan.leaf2",
+				ImportState:       true,
+				ImportStateId:     "42@leaf2",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
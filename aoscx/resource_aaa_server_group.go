@@ -0,0 +1,191 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceAaaServerGroup() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Resource to configure AAA server groups of RADIUS servers on AOS-CX switches.",
+		CreateContext: resourceAaaServerGroupCreate,
+		ReadContext:   resourceAaaServerGroupRead,
+		UpdateContext: resourceAaaServerGroupUpdate,
+		DeleteContext: resourceAaaServerGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceAaaServerGroupImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"switch": switchSchema(),
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.All(
+					validation.StringLenBetween(1, 32),
+					validation.StringDoesNotContainAny(", "),
+					validation.StringNotInSlice([]string{defaultServerGroup, "tacacs", "local", "none"}, true),
+				),
+				Description: "Name of the server group, the built-in radius, tacacs, local and none groups cannot be managed",
+			},
+			"dot1x_authentication": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Authenticate 802.1X clients against the servers of the group instead of the radius group",
+			},
+			"mac_authentication": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Authenticate MAC authentication clients against the servers of the group instead of the radius group",
+			},
+		},
+	}
+}
+
+func aaaServerGroupFromResourceData(d *schema.ResourceData) aaaServerGroup {
+	return aaaServerGroup{
+		Name:    d.Get("name").(string),
+		Dot1x:   d.Get("dot1x_authentication").(bool),
+		MacAuth: d.Get("mac_authentication").(bool),
+	}
+}
+
+func resourceAaaServerGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_group := aaaServerGroupFromResourceData(d)
+
+	created, err := tmp_group.Create(ctx, sw)
+
+	if requestFailed(err) {
+		// A server group created but not selected is kept in state so it
+		// is tainted and replaced on the next apply
+		if created {
+			d.SetId(switchID(d, tmp_group.Name))
+		}
+		diags = append(diags, errorDiagnostics("Error in Creating AAA Server Group", err, cty.GetAttrPath("name"))...)
+		return diags
+	}
+
+	d.SetId(switchID(d, tmp_group.Name))
+
+	return resourceAaaServerGroupRead(ctx, d, m)
+}
+
+func resourceAaaServerGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Retrieve AAA server group from sw if existing
+	tmp_group := aaaServerGroup{
+		Name: d.Get("name").(string),
+	}
+
+	err = tmp_group.Get(ctx, sw)
+
+	if isNotFound(err) {
+		// AAA server group was removed outside of Terraform
+		d.SetId("")
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "AAA Server Group Not Found",
+			Detail:   tmp_group.Name,
+		})
+		return diags
+	} else if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving AAA Server Group", err, nil)...)
+		return diags
+	}
+
+	d.Set("dot1x_authentication", tmp_group.Dot1x)
+	d.Set("mac_authentication", tmp_group.MacAuth)
+
+	return diags
+}
+
+func resourceAaaServerGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_group := aaaServerGroupFromResourceData(d)
+
+	err = tmp_group.Update(ctx, sw)
+
+	if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Updating AAA Server Group", err, nil)...)
+		return diags
+	}
+
+	return resourceAaaServerGroupRead(ctx, d, m)
+}
+
+func resourceAaaServerGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_group := aaaServerGroupFromResourceData(d)
+
+	err = tmp_group.Delete(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Deleting AAA Server Group does not exist", err, cty.GetAttrPath("name"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Deleting AAA Server Group", err, nil)...)
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceAaaServerGroupImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is the name of the server group, optionally followed by
+	// @switch, e.g.
+	// terraform import aoscx_aaa_server_group.campus CAMPUS
+	name, switch_name := parseSwitchID(d.Id())
+	if name == "" {
+		return nil, fmt.Errorf("Invalid import ID %q, expected <name>", d.Id())
+	}
+
+	d.Set("switch", switch_name)
+	d.Set("name", name)
+	d.SetId(switchID(d, name))
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,87 @@
+package aoscx
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testAaaServerGroupPath = "system/aaa_server_groups/CAMPUS"
+
+func testAaaServerGroupConfig(m *mockSwitch, body string) string {
+	return testProviderConfig(m) + `
+resource "aoscx_aaa_server_group" "test" {
+  name = "CAMPUS"
+` + body + `
+}
+`
+}
+
+func TestResourceAaaServerGroup(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckMockDestroyed(m, testAaaServerGroupPath),
+			testCheckMockAttr(m, "system", "dot1x_authentication_server_group", ""),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAaaServerGroupConfig(m, `
+  dot1x_authentication = true
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_aaa_server_group.test", "id", "CAMPUS"),
+					testCheckMockAttr(m, testAaaServerGroupPath, "group_type", "radius"),
+					testCheckMockAttr(m, "system", "dot1x_authentication_server_group", restURI(testAaaServerGroupPath)),
+					testCheckMockAttr(m, "system", "mac_authentication_server_group", ""),
+				),
+			},
+			{
+				Config: testAaaServerGroupConfig(m, `
+  mac_authentication = true
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_aaa_server_group.test", "dot1x_authentication", "false"),
+					testCheckMockAttr(m, "system", "dot1x_authentication_server_group", ""),
+					testCheckMockAttr(m, "system", "mac_authentication_server_group", restURI(testAaaServerGroupPath)),
+				),
+			},
+			{
+				ResourceName:      "aoscx_aaa_server_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					m.patch("system", map[string]interface{}{"mac_authentication_server_group": nil})
+				},
+				Config: testAaaServerGroupConfig(m, `
+  mac_authentication = true
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceAaaServerGroupInvalid(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testProviderConfig(m) + `
+resource "aoscx_aaa_server_group" "test" {
+  name = "radius"
+}
+`,
+				ExpectError: regexp.MustCompile(`expected name not to be any of`),
+			},
+		},
+	})
+}
@@ -0,0 +1,572 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// aclTypes are the types of ACLs supported by AOS-CX.
+var aclTypes = []string{"ipv4", "ipv6", "mac"}
+
+// aclProtocols maps the IP protocol names accepted in ACEs of IPv4 and IPv6
+// ACLs to their protocol number.
+var aclProtocols = map[string]int{
+	"icmp":   1,
+	"igmp":   2,
+	"tcp":    6,
+	"udp":    17,
+	"gre":    47,
+	"esp":    50,
+	"ah":     51,
+	"icmpv6": 58,
+	"ospf":   89,
+	"pim":    103,
+	"vrrp":   112,
+	"sctp":   132,
+}
+
+// aclEthertypes maps the EtherType names accepted in ACEs of MAC ACLs to
+// their value.
+var aclEthertypes = map[string]int{
+	"ipv4": 0x0800,
+	"arp":  0x0806,
+	"ipv6": 0x86dd,
+}
+
+// aclPortRegexp matches a layer 4 port or port range, e.g. 443 or
+// 1024-65535.
+var aclPortRegexp = regexp.MustCompile(`^([0-9]+)(-([0-9]+))?$`)
+
+func resourceAcl() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Resource to configure access control lists (ACLs) on AOS-CX switches.",
+		CreateContext: resourceAclCreate,
+		ReadContext:   resourceAclRead,
+		UpdateContext: resourceAclUpdate,
+		DeleteContext: resourceAclDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceAclImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		CustomizeDiff: resourceAclCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"switch": switchSchema(),
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.All(validation.StringLenBetween(1, 64), validation.StringDoesNotContainAny(", ")),
+				Description:  "Name of the ACL",
+			},
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "ipv4",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(aclTypes, false),
+				Description:  "Type of the ACL, either ipv4, ipv6 or mac",
+			},
+			"ace": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Entries of the ACL in ascending order of their sequence number, the order they are evaluated in",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"sequence": &schema.Schema{
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "Sequence number of the entry, unique within the ACL",
+						},
+						"action": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"permit", "deny"}, false),
+							Description:  "Action taken on matching traffic, either permit or deny",
+						},
+						"protocol": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "any",
+							Description: "IP protocol matched by the entry, e.g. tcp, udp, icmp or a protocol number. EtherType such as ipv4, ipv6, arp or a number in MAC ACLs",
+						},
+						"src": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "any",
+							Description: "Source matched by the entry, a prefix such as 10.0.0.0/8, a host address or a MAC address in MAC ACLs",
+						},
+						"dst": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "any",
+							Description: "Destination matched by the entry, a prefix such as 10.0.0.0/8, a host address or a MAC address in MAC ACLs",
+						},
+						"src_port": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringMatch(aclPortRegexp, "expected a port or port range such as 443 or 1024-65535"),
+							Description:  "Source port or port range of tcp, udp and sctp entries",
+						},
+						"dst_port": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringMatch(aclPortRegexp, "expected a port or port range such as 443 or 1024-65535"),
+							Description:  "Destination port or port range of tcp, udp and sctp entries",
+						},
+						"count": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Count the packets matching the entry",
+						},
+						"log": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Log the packets matching the entry",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceAclCustomizeDiff checks that the entries are in ascending order of
+// their sequence number and consistent with the type of the ACL.
+func resourceAclCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("ace") {
+		return nil
+	}
+
+	acl_type := d.Get("type").(string)
+	previous := 0
+
+	for _, item := range d.Get("ace").([]interface{}) {
+		ace := item.(map[string]interface{})
+		sequence := ace["sequence"].(int)
+
+		if sequence == previous {
+			return fmt.Errorf("ace sequence %d is used by more than one entry", sequence)
+		} else if sequence < previous {
+			return fmt.Errorf("ace sequence %d must come before sequence %d, entries are listed in ascending order of their sequence", sequence, previous)
+		}
+		previous = sequence
+
+		protocol := ace["protocol"].(string)
+		if _, err := aclProtocolNumber(acl_type, protocol); err != nil {
+			return fmt.Errorf("ace %d: %v", sequence, err)
+		}
+
+		for _, field := range []string{"src", "dst"} {
+			if _, err := aclAddress(acl_type, ace[field].(string)); err != nil {
+				return fmt.Errorf("ace %d: invalid %s: %v", sequence, field, err)
+			}
+		}
+
+		for _, field := range []string{"src_port", "dst_port"} {
+			ports := ace[field].(string)
+			if ports == "" {
+				continue
+			}
+			if acl_type == "mac" || (protocol != "tcp" && protocol != "udp" && protocol != "sctp") {
+				return fmt.Errorf("ace %d: %s can only be set on tcp, udp or sctp entries", sequence, field)
+			}
+			if _, _, err := aclPortRange(ports); err != nil {
+				return fmt.Errorf("ace %d: invalid %s: %v", sequence, field, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// aclProtocolNumber returns the protocol number, or EtherType for MAC ACLs,
+// matched by protocol. any is 0.
+func aclProtocolNumber(acl_type string, protocol string) (int, error) {
+	if protocol == "any" || protocol == "" {
+		return 0, nil
+	}
+
+	names := aclProtocols
+	max := 255
+	if acl_type == "mac" {
+		names = aclEthertypes
+		max = 0xffff
+	}
+
+	if number, ok := names[protocol]; ok {
+		return number, nil
+	}
+
+	number, err := strconv.Atoi(protocol)
+	if err != nil || number < 1 || number > max {
+		if acl_type == "mac" {
+			return 0, fmt.Errorf("protocol %q is not an EtherType name or a number between 1 and %d", protocol, max)
+		}
+		return 0, fmt.Errorf("protocol %q is not a protocol name or a number between 1 and %d", protocol, max)
+	}
+	for name, named_number := range names {
+		if named_number == number {
+			return 0, fmt.Errorf("protocol %s must be written as %s", protocol, name)
+		}
+	}
+
+	return number, nil
+}
+
+// aclProtocolName is the inverse of aclProtocolNumber.
+func aclProtocolName(acl_type string, number int) string {
+	if number == 0 {
+		return "any"
+	}
+
+	names := aclProtocols
+	if acl_type == "mac" {
+		names = aclEthertypes
+	}
+	for name, named_number := range names {
+		if named_number == number {
+			return name
+		}
+	}
+
+	return strconv.Itoa(number)
+}
+
+// aclAddress converts the src or dst of an ACE to the REST representation,
+// address/mask for IP ACLs and the MAC address for MAC ACLs. any is "".
+func aclAddress(acl_type string, address string) (string, error) {
+	if address == "any" || address == "" {
+		return "", nil
+	}
+
+	if acl_type == "mac" {
+		if !macRegexp.MatchString(address) {
+			return "", fmt.Errorf("expected any or a lowercase MAC address such as 02:00:00:00:01:00, got %q", address)
+		}
+		return address, nil
+	}
+
+	ip := net.ParseIP(address)
+	mask_bits := 32
+	if ip != nil && ip.To4() == nil {
+		mask_bits = 128
+	}
+	mask := net.CIDRMask(mask_bits, mask_bits)
+
+	if ip == nil {
+		var ipnet *net.IPNet
+		var err error
+		ip, ipnet, err = net.ParseCIDR(address)
+		if err != nil {
+			return "", fmt.Errorf("expected any, an IP address or a prefix in CIDR notation, got %q", address)
+		}
+		if !ip.Equal(ipnet.IP) {
+			return "", fmt.Errorf("expected the network address %s, got %q", ipnet.String(), address)
+		}
+		if ones, bits := ipnet.Mask.Size(); ones == bits {
+			return "", fmt.Errorf("expected the host address %s instead of %q", ip, address)
+		}
+		mask = ipnet.Mask
+	}
+
+	if (ip.To4() != nil) != (acl_type == "ipv4") {
+		return "", fmt.Errorf("%s is not an %s address", address, acl_type)
+	}
+	if ip.To4() != nil {
+		ip = ip.To4()
+	}
+
+	return ip.String() + "/" + net.IP(mask).String(), nil
+}
+
+// aclAddressFromRest is the inverse of aclAddress. Masks that are not
+// prefix lengths are kept as is.
+func aclAddressFromRest(address string) string {
+	if address == "" {
+		return "any"
+	}
+
+	ip_part, mask_part, found := strings.Cut(address, "/")
+	if !found {
+		return address
+	}
+
+	ip := net.ParseIP(ip_part)
+	mask_ip := net.ParseIP(mask_part)
+	if ip == nil || mask_ip == nil {
+		return address
+	}
+
+	mask := net.IPMask(mask_ip.To16())
+	if ip.To4() != nil {
+		mask = net.IPMask(mask_ip.To4())
+	}
+	ones, bits := mask.Size()
+	if bits == 0 {
+		return address
+	}
+	if ones == bits {
+		return ip.String()
+	}
+
+	return fmt.Sprintf("%s/%d", ip, ones)
+}
+
+// aclPortRange returns the first and last port of a port or port range.
+func aclPortRange(ports string) (int, int, error) {
+	match := aclPortRegexp.FindStringSubmatch(ports)
+	if match == nil {
+		return 0, 0, fmt.Errorf("expected a port or port range such as 443 or 1024-65535, got %q", ports)
+	}
+
+	min, _ := strconv.Atoi(match[1])
+	max := min
+	if match[3] != "" {
+		max, _ = strconv.Atoi(match[3])
+	}
+	if min < 1 || max > 65535 || min > max {
+		return 0, 0, fmt.Errorf("expected ports between 1 and 65535 with the first port not above the last, got %q", ports)
+	}
+
+	return min, max, nil
+}
+
+// aclPortString is the inverse of aclPortRange.
+func aclPortString(min int, max int) string {
+	switch {
+	case min == 0 && max == 0:
+		return ""
+	case max == 0 || min == max:
+		return strconv.Itoa(min)
+	default:
+		return fmt.Sprintf("%d-%d", min, max)
+	}
+}
+
+// aclFromResourceData builds the ACL described by the configuration of d,
+// which resourceAclCustomizeDiff validated and ordered by sequence.
+func aclFromResourceData(d *schema.ResourceData) acl {
+	tmp_acl := acl{
+		Name:    d.Get("name").(string),
+		Type:    d.Get("type").(string),
+		Entries: []aclEntry{},
+	}
+
+	for _, item := range d.Get("ace").([]interface{}) {
+		ace := item.(map[string]interface{})
+
+		entry := aclEntry{
+			Sequence: ace["sequence"].(int),
+			Action:   ace["action"].(string),
+			Count:    ace["count"].(bool),
+			Log:      ace["log"].(bool),
+		}
+
+		protocol, _ := aclProtocolNumber(tmp_acl.Type, ace["protocol"].(string))
+		src, _ := aclAddress(tmp_acl.Type, ace["src"].(string))
+		dst, _ := aclAddress(tmp_acl.Type, ace["dst"].(string))
+
+		if tmp_acl.Type == "mac" {
+			entry.Ethertype = protocol
+			entry.SrcMac = src
+			entry.DstMac = dst
+		} else {
+			entry.Protocol = protocol
+			entry.SrcIp = src
+			entry.DstIp = dst
+		}
+
+		if ports := ace["src_port"].(string); ports != "" {
+			entry.SrcPortMin, entry.SrcPortMax, _ = aclPortRange(ports)
+		}
+		if ports := ace["dst_port"].(string); ports != "" {
+			entry.DstPortMin, entry.DstPortMax, _ = aclPortRange(ports)
+		}
+
+		tmp_acl.Entries = append(tmp_acl.Entries, entry)
+	}
+
+	return tmp_acl
+}
+
+func resourceAclCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_acl := aclFromResourceData(d)
+
+	created, err := tmp_acl.Create(ctx, sw)
+
+	if requestFailed(err) {
+		// An ACL created without all of its entries is kept in state so it
+		// is tainted and replaced on the next apply
+		if created {
+			d.SetId(switchID(d, aclKey(tmp_acl.Name, tmp_acl.Type)))
+		}
+		diags = append(diags, errorDiagnostics("Error in Creating ACL", err, cty.GetAttrPath("name"))...)
+		return diags
+	}
+
+	d.SetId(switchID(d, aclKey(tmp_acl.Name, tmp_acl.Type)))
+
+	return resourceAclRead(ctx, d, m)
+}
+
+func resourceAclRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Retrieve ACL from sw if existing
+	tmp_acl := acl{
+		Name: d.Get("name").(string),
+		Type: d.Get("type").(string),
+	}
+
+	err = tmp_acl.Get(ctx, sw)
+
+	if isNotFound(err) {
+		// ACL was removed outside of Terraform
+		d.SetId("")
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "ACL Not Found",
+			Detail:   aclKey(tmp_acl.Name, tmp_acl.Type),
+		})
+		return diags
+	} else if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving ACL", err, nil)...)
+		return diags
+	}
+
+	aces := []interface{}{}
+	for _, entry := range tmp_acl.Entries {
+		protocol := entry.Protocol
+		src := entry.SrcIp
+		dst := entry.DstIp
+		if tmp_acl.Type == "mac" {
+			protocol = entry.Ethertype
+			src = entry.SrcMac
+			dst = entry.DstMac
+		}
+
+		aces = append(aces, map[string]interface{}{
+			"sequence": entry.Sequence,
+			"action":   entry.Action,
+			"protocol": aclProtocolName(tmp_acl.Type, protocol),
+			"src":      aclAddressFromRest(src),
+			"dst":      aclAddressFromRest(dst),
+			"src_port": aclPortString(entry.SrcPortMin, entry.SrcPortMax),
+			"dst_port": aclPortString(entry.DstPortMin, entry.DstPortMax),
+			"count":    entry.Count,
+			"log":      entry.Log,
+		})
+	}
+
+	d.Set("name", tmp_acl.Name)
+	d.Set("type", tmp_acl.Type)
+	d.Set("ace", aces)
+
+	return diags
+}
+
+func resourceAclUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_acl := aclFromResourceData(d)
+
+	err = tmp_acl.Update(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Updating ACL does not exist", err, cty.GetAttrPath("name"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Updating ACL", err, nil)...)
+		return diags
+	}
+
+	return resourceAclRead(ctx, d, m)
+}
+
+func resourceAclDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_acl := acl{
+		Name: d.Get("name").(string),
+		Type: d.Get("type").(string),
+	}
+
+	err = tmp_acl.Delete(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Deleting ACL does not exist", err, cty.GetAttrPath("name"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Deleting ACL", err, nil)...)
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceAclImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is the ACL name and type separated by a comma, optionally
+	// followed by @switch, e.g. terraform import aoscx_acl.web web,ipv4
+	import_id, switch_name := parseSwitchID(d.Id())
+	name, acl_type, found := strings.Cut(import_id, ",")
+
+	if !found || name == "" {
+		return nil, fmt.Errorf("Invalid ACL import ID %q, expected <name>,<type>", d.Id())
+	}
+	if _, errs := validation.StringInSlice(aclTypes, false)(acl_type, "type"); len(errs) > 0 {
+		return nil, fmt.Errorf("Invalid ACL import ID %q: %v", d.Id(), errs[0])
+	}
+
+	d.Set("switch", switch_name)
+	d.SetId(switchID(d, aclKey(name, acl_type)))
+	d.Set("name", name)
+	d.Set("type", acl_type)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,277 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// aclAttributePrefixes maps ACL types to the prefix of the attributes
+// referencing ACLs of that type, e.g. aclv4_in_cfg.
+var aclAttributePrefixes = map[string]string{
+	"ipv4": "aclv4",
+	"ipv6": "aclv6",
+	"mac":  "aclmac",
+}
+
+func resourceAclApplication() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Resource to apply an ACL on an interface, LAG, VLAN or VLAN interface of AOS-CX switches.",
+		CreateContext: resourceAclApplicationCreate,
+		ReadContext:   resourceAclApplicationRead,
+		UpdateContext: resourceAclApplicationUpdate,
+		DeleteContext: resourceAclApplicationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceAclApplicationImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		CustomizeDiff: resourceAclApplicationCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"switch": switchSchema(),
+			"acl_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the ACL, reference aoscx_acl.<name>.name so the ACL is created first",
+			},
+			"acl_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "ipv4",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(aclTypes, false),
+				Description:  "Type of the ACL, either ipv4, ipv6 or mac",
+			},
+			"direction": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"in", "out"}, false),
+				Description:  "Direction of the traffic filtered by the ACL, either in or out. On VLAN interfaces the ACL filters routed traffic",
+			},
+			"interface": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"interface", "vlan_id"},
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Interface, LAG or VLAN interface the ACL is applied on, e.g. 1/1/1, lag1 or vlan42",
+			},
+			"vlan_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"interface", "vlan_id"},
+				ValidateFunc: validation.IntBetween(1, 4094),
+				Description:  "VLAN the ACL is applied on",
+			},
+		},
+	}
+}
+
+// resourceAclApplicationCustomizeDiff rejects MAC ACLs on VLAN interfaces,
+// which only filter routed traffic.
+func resourceAclApplicationCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Get("acl_type").(string) == "mac" && isVlanInterface(d.Get("interface").(string)) {
+		return fmt.Errorf("MAC ACLs cannot be applied on VLAN interface %s", d.Get("interface").(string))
+	}
+
+	return nil
+}
+
+// isVlanInterface reports whether interface_name is a VLAN interface such as
+// vlan42.
+func isVlanInterface(interface_name string) bool {
+	vlan_id, err := strconv.Atoi(strings.TrimPrefix(interface_name, "vlan"))
+	return strings.HasPrefix(interface_name, "vlan") && err == nil && vlan_id > 0
+}
+
+// aclApplicationFromResourceData returns the target and attribute the ACL
+// configured on d is applied with.
+func aclApplicationFromResourceData(d *schema.ResourceData) aclApplication {
+	acl_type := d.Get("acl_type").(string)
+	direction := d.Get("direction").(string)
+	interface_name := d.Get("interface").(string)
+
+	tmp_application := aclApplication{
+		AclName: d.Get("acl_name").(string),
+		AclType: acl_type,
+	}
+
+	switch {
+	case interface_name == "":
+		tmp_application.Target = "system/vlans/" + strconv.Itoa(d.Get("vlan_id").(int))
+		tmp_application.Attribute = aclAttributePrefixes[acl_type] + "_" + direction + "_cfg"
+	case isVlanInterface(interface_name):
+		tmp_application.Target = "system/interfaces/" + restPath(interface_name)
+		tmp_application.Attribute = aclAttributePrefixes[acl_type] + "_routed_" + direction + "_cfg"
+	default:
+		tmp_application.Target = "system/interfaces/" + restPath(interface_name)
+		tmp_application.Attribute = aclAttributePrefixes[acl_type] + "_" + direction + "_cfg"
+	}
+
+	return tmp_application
+}
+
+// aclApplicationID returns the ID of the application configured on d, the
+// target, ACL type and direction identify it as a target holds one ACL per
+// type and direction.
+func aclApplicationID(d *schema.ResourceData) string {
+	target := d.Get("interface").(string)
+	if target == "" {
+		target = strconv.Itoa(d.Get("vlan_id").(int))
+	}
+
+	return switchID(d, target+","+d.Get("acl_type").(string)+","+d.Get("direction").(string))
+}
+
+func resourceAclApplicationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_application := aclApplicationFromResourceData(d)
+
+	err = tmp_application.Apply(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Creating ACL Application interface or VLAN does not exist", err, nil)...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Creating ACL Application", err, cty.GetAttrPath("acl_name"))...)
+		return diags
+	}
+
+	d.SetId(aclApplicationID(d))
+
+	return resourceAclApplicationRead(ctx, d, m)
+}
+
+func resourceAclApplicationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_application := aclApplicationFromResourceData(d)
+
+	err = tmp_application.Get(ctx, sw)
+
+	if isNotFound(err) || (err == nil && tmp_application.AclName == "") {
+		// ACL was removed from the target outside of Terraform
+		d.SetId("")
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "ACL Application Not Found",
+			Detail:   fmt.Sprintf("No ACL in %s of %s", tmp_application.Attribute, tmp_application.Target),
+		})
+		return diags
+	} else if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving ACL Application", err, nil)...)
+		return diags
+	}
+
+	d.Set("acl_name", tmp_application.AclName)
+
+	return diags
+}
+
+func resourceAclApplicationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Only acl_name can change, the new ACL replaces the applied one
+	tmp_application := aclApplicationFromResourceData(d)
+
+	err = tmp_application.Apply(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Updating ACL Application interface or VLAN does not exist", err, nil)...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Updating ACL Application", err, cty.GetAttrPath("acl_name"))...)
+		return diags
+	}
+
+	return resourceAclApplicationRead(ctx, d, m)
+}
+
+func resourceAclApplicationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_application := aclApplicationFromResourceData(d)
+
+	err = tmp_application.Remove(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Deleting ACL Application interface or VLAN does not exist", err, nil)...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Deleting ACL Application", err, nil)...)
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceAclApplicationImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is the interface name or VLAN ID, the ACL type and the
+	// direction separated by commas, optionally followed by @switch, e.g.
+	// terraform import aoscx_acl_application.web 1/1/1,ipv4,in
+	import_id, switch_name := parseSwitchID(d.Id())
+	parts := strings.Split(import_id, ",")
+
+	if len(parts) != 3 || parts[0] == "" {
+		return nil, fmt.Errorf("Invalid ACL Application import ID %q, expected <interface or VLAN ID>,<type>,<direction>", d.Id())
+	}
+	if _, ok := aclAttributePrefixes[parts[1]]; !ok {
+		return nil, fmt.Errorf("Invalid ACL Application import ID %q, type must be one of %s", d.Id(), strings.Join(aclTypes, ", "))
+	}
+	if parts[2] != "in" && parts[2] != "out" {
+		return nil, fmt.Errorf("Invalid ACL Application import ID %q, direction must be in or out", d.Id())
+	}
+
+	d.Set("switch", switch_name)
+	if vlan_id, err := strconv.Atoi(parts[0]); err == nil {
+		d.Set("vlan_id", vlan_id)
+	} else {
+		d.Set("interface", parts[0])
+	}
+	d.Set("acl_type", parts[1])
+	d.Set("direction", parts[2])
+	d.SetId(aclApplicationID(d))
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,164 @@
+package aoscx
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testAclApplicationConfig(m *mockSwitch, application string) string {
+	return testProviderConfig(m) + `
+resource "aoscx_acl" "web" {
+  name = "web"
+
+  ace {
+    sequence = 10
+    action   = "permit"
+    protocol = "tcp"
+    dst_port = "443"
+  }
+}
+
+resource "aoscx_acl" "deny" {
+  name = "deny"
+
+  ace {
+    sequence = 10
+    action   = "deny"
+  }
+}
+
+resource "aoscx_vlan" "test" {
+  vlan_id = 42
+  name    = "servers"
+}
+
+resource "aoscx_acl_application" "test" {
+` + application + `
+}
+`
+}
+
+func TestResourceAclApplication(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckMockAttr(m, "system/interfaces/1%2F1%2F1", "aclv4_in_cfg", ""),
+			testCheckMockDestroyed(m, "system/acls/web%2Cipv4"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAclApplicationConfig(m, `
+  acl_name  = aoscx_acl.web.name
+  direction = "in"
+  interface = "1/1/1"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_acl_application.test", "id", "1/1/1,ipv4,in"),
+					resource.TestCheckResourceAttr("aoscx_acl_application.test", "acl_name", "web"),
+					testCheckMockAttr(m, "system/interfaces/1%2F1%2F1", "aclv4_in_cfg", "/rest/"+restVersion+"/system/acls/web%2Cipv4"),
+				),
+			},
+			{
+				Config: testAclApplicationConfig(m, `
+  acl_name  = aoscx_acl.deny.name
+  direction = "in"
+  interface = "1/1/1"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_acl_application.test", "acl_name", "deny"),
+					testCheckMockAttr(m, "system/interfaces/1%2F1%2F1", "aclv4_in_cfg", "/rest/"+restVersion+"/system/acls/deny%2Cipv4"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_acl_application.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					m.patch("system/interfaces/1%2F1%2F1", map[string]interface{}{"aclv4_in_cfg": nil})
+				},
+				Config: testAclApplicationConfig(m, `
+  acl_name  = aoscx_acl.deny.name
+  direction = "in"
+  interface = "1/1/1"
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAclApplicationConfig(m, `
+  acl_name  = aoscx_acl.web.name
+  direction = "out"
+  vlan_id   = aoscx_vlan.test.vlan_id
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_acl_application.test", "id", "42,ipv4,out"),
+					testCheckMockAttr(m, "system/vlans/42", "aclv4_out_cfg", "/rest/"+restVersion+"/system/acls/web%2Cipv4"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_acl_application.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceAclApplicationVlanInterface(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAclApplicationConfig(m, `
+  acl_name  = aoscx_acl.web.name
+  direction = "in"
+  interface = "vlan42"
+
+  depends_on = [aoscx_vlan_interface.test]
+`) + `
+resource "aoscx_vlan_interface" "test" {
+  vlan_id = aoscx_vlan.test.vlan_id
+  ipv4    = ["10.42.0.1/24"]
+}
+`,
+				Check: testCheckMockAttr(m, "system/interfaces/vlan42", "aclv4_routed_in_cfg", "/rest/"+restVersion+"/system/acls/web%2Cipv4"),
+			},
+		},
+	})
+}
+
+func TestResourceAclApplicationInvalid(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAclApplicationConfig(m, `
+  acl_name  = "web"
+  acl_type  = "mac"
+  direction = "in"
+  interface = "vlan42"
+`),
+				ExpectError: regexp.MustCompile(`MAC ACLs cannot be applied on VLAN interface vlan42`),
+			},
+			{
+				Config: testAclApplicationConfig(m, `
+  acl_name  = "web"
+  direction = "in"
+  interface = "1/1/1"
+  vlan_id   = 42
+`),
+				ExpectError: regexp.MustCompile(`only one of`),
+			},
+		},
+	})
+}
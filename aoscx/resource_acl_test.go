@@ -0,0 +1,288 @@
+package aoscx
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testAclPath = "system/acls/web%2Cipv4"
+
+func testAclConfig(m *mockSwitch, aces string) string {
+	return testProviderConfig(m) + `
+resource "aoscx_acl" "test" {
+  name = "web"
+` + aces + `
+}
+`
+}
+
+const testAclAces = `
+  ace {
+    sequence = 10
+    action   = "permit"
+    protocol = "tcp"
+    src      = "10.0.0.0/8"
+    dst      = "192.168.1.10"
+    dst_port = "443"
+    count    = true
+  }
+
+  ace {
+    sequence = 100
+    action   = "deny"
+    log      = true
+  }
+`
+
+const testAclAcesUpdated = `
+  ace {
+    sequence = 10
+    action   = "permit"
+    protocol = "tcp"
+    src      = "10.0.0.0/8"
+    dst      = "192.168.1.10"
+    dst_port = "443"
+    count    = true
+  }
+
+  ace {
+    sequence = 20
+    action   = "permit"
+    protocol = "udp"
+    src_port = "1024-65535"
+    dst_port = "53"
+  }
+
+  ace {
+    sequence = 100
+    action   = "deny"
+  }
+`
+
+func TestResourceAcl(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, testAclPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testAclConfig(m, testAclAces),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_acl.test", "id", "web,ipv4"),
+					resource.TestCheckResourceAttr("aoscx_acl.test", "type", "ipv4"),
+					resource.TestCheckResourceAttr("aoscx_acl.test", "ace.#", "2"),
+					resource.TestCheckResourceAttr("aoscx_acl.test", "ace.0.sequence", "10"),
+					resource.TestCheckResourceAttr("aoscx_acl.test", "ace.0.protocol", "tcp"),
+					resource.TestCheckResourceAttr("aoscx_acl.test", "ace.0.src", "10.0.0.0/8"),
+					resource.TestCheckResourceAttr("aoscx_acl.test", "ace.0.dst", "192.168.1.10"),
+					resource.TestCheckResourceAttr("aoscx_acl.test", "ace.0.dst_port", "443"),
+					resource.TestCheckResourceAttr("aoscx_acl.test", "ace.0.count", "true"),
+					testCheckMockExists(m, testAclPath+"/cfg_aces/10"),
+					testCheckMockExists(m, testAclPath+"/cfg_aces/100"),
+				),
+			},
+			{
+				Config: testAclConfig(m, testAclAcesUpdated),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_acl.test", "ace.#", "3"),
+					resource.TestCheckResourceAttr("aoscx_acl.test", "ace.1.sequence", "20"),
+					resource.TestCheckResourceAttr("aoscx_acl.test", "ace.1.protocol", "udp"),
+					resource.TestCheckResourceAttr("aoscx_acl.test", "ace.1.src_port", "1024-65535"),
+					resource.TestCheckResourceAttr("aoscx_acl.test", "ace.1.dst_port", "53"),
+					resource.TestCheckResourceAttr("aoscx_acl.test", "ace.2.sequence", "100"),
+					resource.TestCheckResourceAttr("aoscx_acl.test", "ace.2.action", "deny"),
+					resource.TestCheckResourceAttr("aoscx_acl.test", "ace.2.log", "false"),
+					testCheckMockExists(m, testAclPath+"/cfg_aces/20"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_acl.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					m.patch(testAclPath+"/cfg_aces/20", map[string]interface{}{"action": "deny"})
+				},
+				Config:             testAclConfig(m, testAclAcesUpdated),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAclConfig(m, testAclAcesUpdated),
+			},
+			{
+				PreConfig: func() {
+					m.remove(testAclPath + "/cfg_aces/10")
+				},
+				Config:             testAclConfig(m, testAclAcesUpdated),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				PreConfig: func() {
+					m.remove(testAclPath)
+				},
+				Config:             testAclConfig(m, testAclAcesUpdated),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceAclIPv6AndMac(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckMockDestroyed(m, "system/acls/web6%2Cipv6"),
+			testCheckMockDestroyed(m, "system/acls/hosts%2Cmac"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testProviderConfig(m) + `
+resource "aoscx_acl" "ipv6" {
+  name = "web6"
+  type = "ipv6"
+
+  ace {
+    sequence = 10
+    action   = "permit"
+    protocol = "icmpv6"
+    src      = "2001:db8::/32"
+  }
+}
+
+resource "aoscx_acl" "mac" {
+  name = "hosts"
+  type = "mac"
+
+  ace {
+    sequence = 10
+    action   = "deny"
+    protocol = "arp"
+    src      = "02:00:00:00:00:01"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_acl.ipv6", "id", "web6,ipv6"),
+					resource.TestCheckResourceAttr("aoscx_acl.ipv6", "ace.0.protocol", "icmpv6"),
+					resource.TestCheckResourceAttr("aoscx_acl.ipv6", "ace.0.src", "2001:db8::/32"),
+					resource.TestCheckResourceAttr("aoscx_acl.ipv6", "ace.0.dst", "any"),
+					resource.TestCheckResourceAttr("aoscx_acl.mac", "id", "hosts,mac"),
+					resource.TestCheckResourceAttr("aoscx_acl.mac", "ace.0.protocol", "arp"),
+					resource.TestCheckResourceAttr("aoscx_acl.mac", "ace.0.src", "02:00:00:00:00:01"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceAclInvalid(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAclConfig(m, `
+  ace {
+    sequence = 10
+    action   = "permit"
+  }
+
+  ace {
+    sequence = 10
+    action   = "deny"
+  }
+`),
+				ExpectError: regexp.MustCompile(`ace sequence 10 is used by more than one entry`),
+			},
+			{
+				Config: testAclConfig(m, `
+  ace {
+    sequence = 20
+    action   = "permit"
+  }
+
+  ace {
+    sequence = 10
+    action   = "deny"
+  }
+`),
+				ExpectError: regexp.MustCompile(`ace sequence 10 must come before sequence 20`),
+			},
+			{
+				Config: testAclConfig(m, `
+  ace {
+    sequence = 10
+    action   = "permit"
+    dst_port = "80"
+  }
+`),
+				ExpectError: regexp.MustCompile(`dst_port can only be set on tcp, udp or sctp entries`),
+			},
+			{
+				Config: testAclConfig(m, `
+  ace {
+    sequence = 10
+    action   = "permit"
+    src      = "2001:db8::/32"
+  }
+`),
+				ExpectError: regexp.MustCompile(`is not an ipv4 address`),
+			},
+			{
+				Config: testAclConfig(m, `
+  ace {
+    sequence = 10
+    action   = "permit"
+    protocol = "6"
+  }
+`),
+				ExpectError: regexp.MustCompile(`protocol 6 must be written as tcp`),
+			},
+		},
+	})
+}
+
+func TestAclAddress(t *testing.T) {
+	cases := []struct {
+		acl_type string
+		address  string
+		rest     string
+	}{
+		{"ipv4", "any", ""},
+		{"ipv4", "10.0.0.0/8", "10.0.0.0/255.0.0.0"},
+		{"ipv4", "192.168.1.10", "192.168.1.10/255.255.255.255"},
+		{"ipv6", "2001:db8::/32", "2001:db8::/ffff:ffff::"},
+		{"ipv6", "2001:db8::1", "2001:db8::1/ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"},
+		{"mac", "02:00:00:00:00:01", "02:00:00:00:00:01"},
+	}
+
+	for _, c := range cases {
+		rest, err := aclAddress(c.acl_type, c.address)
+		if err != nil {
+			t.Errorf("aclAddress(%q, %q) failed: %v", c.acl_type, c.address, err)
+			continue
+		}
+		if rest != c.rest {
+			t.Errorf("aclAddress(%q, %q) = %q, expected %q", c.acl_type, c.address, rest, c.rest)
+		}
+		if address := aclAddressFromRest(rest); address != c.address {
+			t.Errorf("aclAddressFromRest(%q) = %q, expected %q", rest, address, c.address)
+		}
+	}
+
+	for _, address := range []string{"10.0.0.1/8", "10.0.0.1/32", "2001:db8::/32", "10.0.0.0/33", "web"} {
+		if _, err := aclAddress("ipv4", address); err == nil {
+			t.Errorf("aclAddress(ipv4, %q) succeeded, expected an error", address)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceBgpAddressFamily() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Resource to configure address families of BGP routers on AOS-CX switches.",
+		CreateContext: resourceBgpAddressFamilyCreate,
+		ReadContext:   resourceBgpAddressFamilyRead,
+		UpdateContext: resourceBgpAddressFamilyUpdate,
+		DeleteContext: resourceBgpAddressFamilyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceBgpAddressFamilyImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		CustomizeDiff: resourceBgpAddressFamilyCustomizeDiff,
+		Schema: bgpRouterSchema(map[string]*schema.Schema{
+			"address_family": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "ipv4-unicast",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"ipv4-unicast", "ipv6-unicast"}, false),
+				Description:  "Address family, either ipv4-unicast or ipv6-unicast",
+			},
+			"networks": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Prefixes advertised in the address family, in CIDR notation",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateCIDRNetwork,
+				},
+			},
+			"redistribute": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Route sources redistributed into the address family, any of connected, static and ospf",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"connected", "static", "ospf"}, false),
+				},
+			},
+			"neighbor": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Neighbors activated in the address family, other neighbors are deactivated in it",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"neighbor": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+							Description:  "Neighbor address or peer group name, reference aoscx_bgp_neighbor.<name>.neighbor so it is created first",
+						},
+						"route_map_in": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+							Description:  "Route map applied to routes received from the neighbor, reference aoscx_route_map.<name>.name so it is created first",
+						},
+						"route_map_out": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+							Description:  "Route map applied to routes advertised to the neighbor, reference aoscx_route_map.<name>.name so it is created first",
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+// resourceBgpAddressFamilyCustomizeDiff checks that the networks belong to
+// the address family and that each neighbor is listed once.
+func resourceBgpAddressFamilyCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	ipv6 := d.Get("address_family").(string) == "ipv6-unicast"
+
+	for _, network := range d.Get("networks").(*schema.Set).List() {
+		prefix, _ := network.(string)
+		if prefix != "" && strings.Contains(prefix, ":") != ipv6 {
+			return fmt.Errorf("network %s does not belong to address family %s", prefix, d.Get("address_family").(string))
+		}
+	}
+
+	neighbors := map[string]bool{}
+	for _, item := range d.Get("neighbor").(*schema.Set).List() {
+		neighbor, _ := item.(map[string]interface{})["neighbor"].(string)
+		if neighbor == "" {
+			continue
+		}
+		if neighbors[neighbor] {
+			return fmt.Errorf("neighbor %s is listed more than once", neighbor)
+		}
+		neighbors[neighbor] = true
+	}
+
+	return nil
+}
+
+func bgpAddressFamilyFromResourceData(d *schema.ResourceData) bgpAddressFamily {
+	tmp_family := bgpAddressFamily{
+		Vrf:          d.Get("vrf").(string),
+		Asn:          d.Get("asn").(int),
+		Family:       d.Get("address_family").(string),
+		Networks:     sortedStrings(d.Get("networks").(*schema.Set)),
+		Redistribute: sortedStrings(d.Get("redistribute").(*schema.Set)),
+		Neighbors:    map[string]bgpAddressFamilyNeighbor{},
+	}
+
+	for _, item := range d.Get("neighbor").(*schema.Set).List() {
+		neighbor := item.(map[string]interface{})
+		tmp_family.Neighbors[neighbor["neighbor"].(string)] = bgpAddressFamilyNeighbor{
+			RouteMapIn:  neighbor["route_map_in"].(string),
+			RouteMapOut: neighbor["route_map_out"].(string),
+		}
+	}
+
+	return tmp_family
+}
+
+func flattenBgpAddressFamilyNeighbors(neighbors map[string]bgpAddressFamilyNeighbor) []interface{} {
+	items := make([]interface{}, 0, len(neighbors))
+	for name, neighbor := range neighbors {
+		items = append(items, map[string]interface{}{
+			"neighbor":      name,
+			"route_map_in":  neighbor.RouteMapIn,
+			"route_map_out": neighbor.RouteMapOut,
+		})
+	}
+
+	return items
+}
+
+func resourceBgpAddressFamilyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_family := bgpAddressFamilyFromResourceData(d)
+
+	err = tmp_family.Update(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Creating BGP Address Family BGP router or neighbor does not exist", err, nil)...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Creating BGP Address Family", err, cty.GetAttrPath("address_family"))...)
+		return diags
+	}
+
+	d.SetId(switchID(d, bgpRouterID(d)+","+tmp_family.Family))
+
+	return resourceBgpAddressFamilyRead(ctx, d, m)
+}
+
+func resourceBgpAddressFamilyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Retrieve BGP address family from sw if the router exists
+	tmp_family := bgpAddressFamily{
+		Vrf:    d.Get("vrf").(string),
+		Asn:    d.Get("asn").(int),
+		Family: d.Get("address_family").(string),
+	}
+
+	err = tmp_family.Get(ctx, sw)
+
+	if isNotFound(err) {
+		// BGP router was removed outside of Terraform
+		d.SetId("")
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "BGP Address Family Not Found",
+			Detail:   bgpRouterID(d) + "," + tmp_family.Family,
+		})
+		return diags
+	} else if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving BGP Address Family", err, nil)...)
+		return diags
+	}
+
+	d.Set("networks", tmp_family.Networks)
+	d.Set("redistribute", tmp_family.Redistribute)
+	d.Set("neighbor", flattenBgpAddressFamilyNeighbors(tmp_family.Neighbors))
+
+	return diags
+}
+
+func resourceBgpAddressFamilyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_family := bgpAddressFamilyFromResourceData(d)
+
+	err = tmp_family.Update(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Updating BGP Address Family BGP router or neighbor does not exist", err, nil)...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Updating BGP Address Family", err, nil)...)
+		return diags
+	}
+
+	return resourceBgpAddressFamilyRead(ctx, d, m)
+}
+
+func resourceBgpAddressFamilyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_family := bgpAddressFamilyFromResourceData(d)
+
+	err = tmp_family.Delete(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Deleting BGP Address Family BGP router does not exist", err, cty.GetAttrPath("asn"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Deleting BGP Address Family", err, nil)...)
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceBgpAddressFamilyImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is the ID of the BGP router followed by the address family,
+	// optionally followed by @switch, e.g.
+	// terraform import aoscx_bgp_address_family.ipv4 default,65001,ipv4-unicast
+	fields, err := parseBgpImportID(d, "<vrf>,<AS number>,<ipv4-unicast or ipv6-unicast>", 1)
+	if err != nil {
+		return nil, err
+	}
+	if fields[0] != "ipv4-unicast" && fields[0] != "ipv6-unicast" {
+		return nil, fmt.Errorf("Invalid import ID %q, address family must be ipv4-unicast or ipv6-unicast", d.Id())
+	}
+
+	d.Set("address_family", fields[0])
+	d.SetId(switchID(d, bgpRouterID(d)+","+fields[0]))
+
+	return []*schema.ResourceData{d}, nil
+}
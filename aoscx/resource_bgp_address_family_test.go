@@ -0,0 +1,181 @@
+package aoscx
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testBgpAddressFamilyConfig(m *mockSwitch, body string) string {
+	return testBgpRouterConfig(m, "") + `
+resource "aoscx_bgp_neighbor" "test" {
+  asn       = aoscx_bgp_router.test.asn
+  neighbor  = "10.0.0.2"
+  remote_as = 65002
+}
+
+resource "aoscx_bgp_neighbor" "ipv6" {
+  asn       = aoscx_bgp_router.test.asn
+  neighbor  = "2001:db8::2"
+  remote_as = 65002
+}
+
+resource "aoscx_bgp_address_family" "test" {
+  asn = aoscx_bgp_router.test.asn
+` + body + `
+}
+`
+}
+
+func TestResourceBgpAddressFamily(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, testBgpRouterPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testBgpAddressFamilyConfig(m, `
+  networks     = ["10.1.0.0/24"]
+  redistribute = ["connected"]
+
+  neighbor {
+    neighbor = aoscx_bgp_neighbor.test.neighbor
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_bgp_address_family.test", "id", "default,65001,ipv4-unicast"),
+					resource.TestCheckResourceAttr("aoscx_bgp_address_family.test", "networks.#", "1"),
+					resource.TestCheckTypeSetElemAttr("aoscx_bgp_address_family.test", "networks.*", "10.1.0.0/24"),
+					resource.TestCheckResourceAttr("aoscx_bgp_address_family.test", "neighbor.#", "1"),
+					testCheckMockAttr(m, testBgpRouterPath, "networks", "map[ipv4-unicast:[10.1.0.0/24]]"),
+					testCheckMockAttr(m, testBgpNeighborPath, "activate", "map[ipv4-unicast:true]"),
+				),
+			},
+			{
+				Config: testBgpAddressFamilyConfig(m, `
+  networks     = ["10.1.0.0/24", "10.2.0.0/24"]
+  redistribute = ["connected", "static"]
+
+  neighbor {
+    neighbor      = aoscx_bgp_neighbor.test.neighbor
+    route_map_in  = "FROM-SPINE"
+    route_map_out = "TO-SPINE"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_bgp_address_family.test", "networks.#", "2"),
+					resource.TestCheckResourceAttr("aoscx_bgp_address_family.test", "redistribute.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("aoscx_bgp_address_family.test", "neighbor.*", map[string]string{
+						"neighbor":      "10.0.0.2",
+						"route_map_in":  "FROM-SPINE",
+						"route_map_out": "TO-SPINE",
+					}),
+				),
+			},
+			{
+				ResourceName:      "aoscx_bgp_address_family.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					m.patch(testBgpNeighborPath, map[string]interface{}{
+						"activate": map[string]interface{}{"ipv4-unicast": false},
+					})
+				},
+				Config: testBgpAddressFamilyConfig(m, `
+  networks     = ["10.1.0.0/24", "10.2.0.0/24"]
+  redistribute = ["connected", "static"]
+
+  neighbor {
+    neighbor      = aoscx_bgp_neighbor.test.neighbor
+    route_map_in  = "FROM-SPINE"
+    route_map_out = "TO-SPINE"
+  }
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testBgpAddressFamilyConfig(m, `
+  networks = ["10.1.0.0/24"]
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_bgp_address_family.test", "neighbor.#", "0"),
+					testCheckMockAttr(m, testBgpNeighborPath, "activate", "map[ipv4-unicast:false]"),
+					testCheckMockAttr(m, testBgpNeighborPath, "route_maps", "map[]"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceBgpAddressFamilyIPv6(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testBgpAddressFamilyConfig(m, `
+  address_family = "ipv6-unicast"
+  networks       = ["2001:db8:1::/64"]
+
+  neighbor {
+    neighbor = aoscx_bgp_neighbor.ipv6.neighbor
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_bgp_address_family.test", "id", "default,65001,ipv6-unicast"),
+					testCheckMockAttr(m, testBgpRouterPath, "networks", "map[ipv6-unicast:[2001:db8:1::/64]]"),
+					testCheckMockAttr(m, testBgpRouterPath+"/bgp_neighbors/2001:db8::2", "activate", "map[ipv6-unicast:true]"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_bgp_address_family.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceBgpAddressFamilyInvalid(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testBgpAddressFamilyConfig(m, `
+  networks = ["2001:db8:1::/64"]
+`),
+				ExpectError: regexp.MustCompile(`does not belong to address family ipv4-unicast`),
+			},
+			{
+				Config: testBgpAddressFamilyConfig(m, `
+  neighbor {
+    neighbor     = "10.0.0.2"
+    route_map_in = "A"
+  }
+
+  neighbor {
+    neighbor     = "10.0.0.2"
+    route_map_in = "B"
+  }
+`),
+				ExpectError: regexp.MustCompile(`neighbor 10.0.0.2 is listed more than once`),
+			},
+			{
+				Config: testBgpAddressFamilyConfig(m, `
+  neighbor {
+    neighbor = "10.9.9.9"
+  }
+`),
+				ExpectError: regexp.MustCompile(`BGP router or neighbor does not exist`),
+			},
+		},
+	})
+}
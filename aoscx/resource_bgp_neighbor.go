@@ -0,0 +1,279 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceBgpNeighbor() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Resource to configure BGP neighbors and peer groups on AOS-CX switches.",
+		CreateContext: resourceBgpNeighborCreate,
+		ReadContext:   resourceBgpNeighborRead,
+		UpdateContext: resourceBgpNeighborUpdate,
+		DeleteContext: resourceBgpNeighborDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceBgpNeighborImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		CustomizeDiff: resourceBgpNeighborCustomizeDiff,
+		Schema: bgpRouterSchema(map[string]*schema.Schema{
+			"neighbor": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "IPv4 or IPv6 address of the neighbor, or the name of the peer group when is_peer_group is set",
+			},
+			"is_peer_group": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Configure a peer group whose settings are inherited by its member neighbors instead of a neighbor",
+			},
+			"remote_as": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validateBgpAsn,
+				Description:  "AS number of the neighbor, required unless it is inherited from peer_group",
+			},
+			"update_source": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Interface whose address is the source of the BGP session, e.g. loopback0",
+			},
+			"password": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringLenBetween(1, 32),
+				Description:  "MD5 password of the BGP session, the switch does not return it so changes made on the switch are not detected",
+			},
+			"keepalive": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60,
+				ValidateFunc: validation.IntBetween(0, 65535),
+				Description:  "Seconds between keepalive messages",
+			},
+			"holdtime": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      180,
+				ValidateFunc: validation.IntBetween(0, 65535),
+				Description:  "Seconds without messages before the session is declared down, 0 disables the hold timer",
+			},
+			"bfd": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Use BFD to detect a failed neighbor",
+			},
+			"peer_group": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Peer group of the neighbor, reference aoscx_bgp_neighbor.<name>.neighbor of a peer group so it is created first",
+			},
+		}),
+	}
+}
+
+// resourceBgpNeighborCustomizeDiff checks the neighbor address, the remote AS
+// and the timers.
+func resourceBgpNeighborCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	neighbor := d.Get("neighbor").(string)
+	is_peer_group := d.Get("is_peer_group").(bool)
+
+	if is_peer_group {
+		if net.ParseIP(neighbor) != nil {
+			return fmt.Errorf("peer group name %s must not be an IP address", neighbor)
+		}
+		if d.NewValueKnown("peer_group") && d.Get("peer_group").(string) != "" {
+			return fmt.Errorf("peer_group cannot be set on a peer group")
+		}
+	} else {
+		if net.ParseIP(neighbor) == nil {
+			return fmt.Errorf("neighbor %s must be an IPv4 or IPv6 address unless is_peer_group is set", neighbor)
+		}
+		if d.NewValueKnown("peer_group") && d.Get("peer_group").(string) == "" && d.Get("remote_as").(int) == 0 {
+			return fmt.Errorf("neighbor %s needs a remote_as unless it is in a peer_group", neighbor)
+		}
+	}
+
+	keepalive := d.Get("keepalive").(int)
+	holdtime := d.Get("holdtime").(int)
+	if holdtime != 0 && holdtime < 3 {
+		return fmt.Errorf("holdtime %d must be 0 or at least 3", holdtime)
+	}
+	if holdtime != 0 && keepalive >= holdtime {
+		return fmt.Errorf("keepalive %d must be shorter than holdtime %d", keepalive, holdtime)
+	}
+
+	return nil
+}
+
+func bgpNeighborFromResourceData(d *schema.ResourceData) bgpNeighbor {
+	return bgpNeighbor{
+		Vrf:          d.Get("vrf").(string),
+		Asn:          d.Get("asn").(int),
+		Name:         d.Get("neighbor").(string),
+		IsPeerGroup:  d.Get("is_peer_group").(bool),
+		RemoteAs:     d.Get("remote_as").(int),
+		UpdateSource: d.Get("update_source").(string),
+		Password:     d.Get("password").(string),
+		Keepalive:    d.Get("keepalive").(int),
+		Holdtime:     d.Get("holdtime").(int),
+		Bfd:          d.Get("bfd").(bool),
+		PeerGroup:    d.Get("peer_group").(string),
+	}
+}
+
+func resourceBgpNeighborCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_neighbor := bgpNeighborFromResourceData(d)
+
+	err = tmp_neighbor.Create(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Creating BGP Neighbor BGP router does not exist", err, cty.GetAttrPath("asn"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Creating BGP Neighbor", err, cty.GetAttrPath("neighbor"))...)
+		return diags
+	}
+
+	d.SetId(switchID(d, bgpRouterID(d)+","+tmp_neighbor.Name))
+
+	return resourceBgpNeighborRead(ctx, d, m)
+}
+
+func resourceBgpNeighborRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Retrieve BGP neighbor from sw if existing
+	tmp_neighbor := bgpNeighbor{
+		Vrf:  d.Get("vrf").(string),
+		Asn:  d.Get("asn").(int),
+		Name: d.Get("neighbor").(string),
+	}
+
+	err = tmp_neighbor.Get(ctx, sw)
+
+	if isNotFound(err) {
+		// BGP neighbor was removed outside of Terraform
+		d.SetId("")
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "BGP Neighbor Not Found",
+			Detail:   bgpRouterID(d) + "," + tmp_neighbor.Name,
+		})
+		return diags
+	} else if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving BGP Neighbor", err, nil)...)
+		return diags
+	}
+
+	d.Set("is_peer_group", tmp_neighbor.IsPeerGroup)
+	d.Set("remote_as", tmp_neighbor.RemoteAs)
+	d.Set("update_source", tmp_neighbor.UpdateSource)
+	d.Set("keepalive", tmp_neighbor.Keepalive)
+	d.Set("holdtime", tmp_neighbor.Holdtime)
+	d.Set("bfd", tmp_neighbor.Bfd)
+	d.Set("peer_group", tmp_neighbor.PeerGroup)
+
+	return diags
+}
+
+func resourceBgpNeighborUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_neighbor := bgpNeighborFromResourceData(d)
+
+	err = tmp_neighbor.Update(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Updating BGP Neighbor does not exist", err, cty.GetAttrPath("neighbor"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Updating BGP Neighbor", err, nil)...)
+		return diags
+	}
+
+	return resourceBgpNeighborRead(ctx, d, m)
+}
+
+func resourceBgpNeighborDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_neighbor := bgpNeighborFromResourceData(d)
+
+	err = tmp_neighbor.Delete(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Deleting BGP Neighbor does not exist", err, cty.GetAttrPath("neighbor"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Deleting BGP Neighbor", err, nil)...)
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceBgpNeighborImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is the ID of the BGP router followed by the neighbor address
+	// or peer group name, optionally followed by @switch, e.g.
+	// terraform import aoscx_bgp_neighbor.spine1 default,65001,10.0.0.1
+	fields, err := parseBgpImportID(d, "<vrf>,<AS number>,<neighbor>", 1)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("neighbor", fields[0])
+	d.SetId(switchID(d, bgpRouterID(d)+","+fields[0]))
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,157 @@
+package aoscx
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testBgpNeighborPath = testBgpRouterPath + "/bgp_neighbors/10.0.0.2"
+
+func testBgpNeighborConfig(m *mockSwitch, body string) string {
+	return testBgpRouterConfig(m, "") + `
+resource "aoscx_bgp_neighbor" "spines" {
+  asn           = aoscx_bgp_router.test.asn
+  neighbor      = "SPINES"
+  is_peer_group = true
+  remote_as     = 65000
+  update_source = "loopback0"
+}
+
+resource "aoscx_bgp_neighbor" "test" {
+  asn      = aoscx_bgp_router.test.asn
+  neighbor = "10.0.0.2"
+` + body + `
+}
+`
+}
+
+func TestResourceBgpNeighbor(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, testBgpNeighborPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testBgpNeighborConfig(m, `
+  remote_as = 65002
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_bgp_neighbor.test", "id", "default,65001,10.0.0.2"),
+					resource.TestCheckResourceAttr("aoscx_bgp_neighbor.test", "remote_as", "65002"),
+					resource.TestCheckResourceAttr("aoscx_bgp_neighbor.test", "keepalive", "60"),
+					resource.TestCheckResourceAttr("aoscx_bgp_neighbor.test", "holdtime", "180"),
+					resource.TestCheckResourceAttr("aoscx_bgp_neighbor.test", "bfd", "false"),
+					resource.TestCheckResourceAttr("aoscx_bgp_neighbor.spines", "id", "default,65001,SPINES"),
+					resource.TestCheckResourceAttr("aoscx_bgp_neighbor.spines", "update_source", "loopback0"),
+					testCheckMockAttr(m, testBgpRouterPath+"/bgp_neighbors/SPINES", "is_peer_group", "true"),
+					testCheckMockExists(m, testBgpNeighborPath),
+				),
+			},
+			{
+				Config: testBgpNeighborConfig(m, `
+  peer_group = aoscx_bgp_neighbor.spines.neighbor
+  password   = "secret"
+  keepalive  = 10
+  holdtime   = 30
+  bfd        = true
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_bgp_neighbor.test", "remote_as", "0"),
+					resource.TestCheckResourceAttr("aoscx_bgp_neighbor.test", "peer_group", "SPINES"),
+					resource.TestCheckResourceAttr("aoscx_bgp_neighbor.test", "keepalive", "10"),
+					resource.TestCheckResourceAttr("aoscx_bgp_neighbor.test", "holdtime", "30"),
+					resource.TestCheckResourceAttr("aoscx_bgp_neighbor.test", "bfd", "true"),
+					testCheckMockAttr(m, testBgpNeighborPath, "password", "secret"),
+					testCheckMockAttr(m, testBgpNeighborPath, "remote_as", ""),
+				),
+			},
+			{
+				ResourceName:            "aoscx_bgp_neighbor.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+			{
+				PreConfig: func() {
+					m.patch(testBgpNeighborPath, map[string]interface{}{"bfd_enable": false})
+				},
+				Config: testBgpNeighborConfig(m, `
+  peer_group = aoscx_bgp_neighbor.spines.neighbor
+  password   = "secret"
+  keepalive  = 10
+  holdtime   = 30
+  bfd        = true
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				PreConfig: func() {
+					m.remove(testBgpNeighborPath)
+				},
+				Config: testBgpNeighborConfig(m, `
+  peer_group = aoscx_bgp_neighbor.spines.neighbor
+  password   = "secret"
+  keepalive  = 10
+  holdtime   = 30
+  bfd        = true
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceBgpNeighborInvalid(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testBgpNeighborConfig(m, ""),
+				ExpectError: regexp.MustCompile(`needs a remote_as`),
+			},
+			{
+				Config: testBgpRouterConfig(m, "") + `
+resource "aoscx_bgp_neighbor" "test" {
+  asn       = aoscx_bgp_router.test.asn
+  neighbor  = "leaf2"
+  remote_as = 65002
+}
+`,
+				ExpectError: regexp.MustCompile(`must be an IPv4 or IPv6 address`),
+			},
+			{
+				Config: testBgpNeighborConfig(m, `
+  remote_as = 65002
+  keepalive = 60
+  holdtime  = 30
+`),
+				ExpectError: regexp.MustCompile(`keepalive 60 must be shorter than holdtime 30`),
+			},
+			{
+				Config: testBgpNeighborConfig(m, `
+  remote_as = 65002
+  keepalive = 0
+  holdtime  = 2
+`),
+				ExpectError: regexp.MustCompile(`holdtime 2 must be 0 or at least 3`),
+			},
+			{
+				Config: testProviderConfig(m) + `
+resource "aoscx_bgp_neighbor" "test" {
+  asn       = 65001
+  neighbor  = "10.0.0.2"
+  remote_as = 65002
+}
+`,
+				ExpectError: regexp.MustCompile(`BGP router does not exist`),
+			},
+		},
+	})
+}
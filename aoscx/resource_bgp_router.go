@@ -0,0 +1,249 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// maxBgpAsn is the largest 4-byte AS number.
+const maxBgpAsn = 4294967295
+
+// validateBgpAsn checks that an AS number is between 1 and maxBgpAsn. It
+// compares as int64 so the provider also builds for 32-bit platforms.
+func validateBgpAsn(i interface{}, k string) ([]string, []error) {
+	asn, ok := i.(int)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be integer", k)}
+	}
+	if asn < 1 || int64(asn) > maxBgpAsn {
+		return nil, []error{fmt.Errorf("expected %s to be in the range (1 - %d), got %d", k, int64(maxBgpAsn), asn)}
+	}
+
+	return nil, nil
+}
+
+// bgpRouterSchema returns the attributes selecting a BGP router, shared by
+// the BGP resources and merged with their own attributes.
+func bgpRouterSchema(resource_schema map[string]*schema.Schema) map[string]*schema.Schema {
+	resource_schema["switch"] = switchSchema()
+	resource_schema["vrf"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Default:     "default",
+		ForceNew:    true,
+		Description: "VRF of the BGP router, reference aoscx_vrf.<name>.name so the VRF is created first",
+	}
+	resource_schema["asn"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validateBgpAsn,
+		Description:  "Local AS number of the BGP router",
+	}
+
+	return resource_schema
+}
+
+// bgpRouterID returns the ID of the BGP router selected on d, e.g.
+// default,65001. The IDs of neighbors and address families extend it.
+func bgpRouterID(d *schema.ResourceData) string {
+	return fmt.Sprintf("%s,%d", d.Get("vrf").(string), d.Get("asn").(int))
+}
+
+// parseBgpImportID splits an import ID starting with a BGP router ID and sets
+// the router attributes of d. The remaining parts are returned.
+func parseBgpImportID(d *schema.ResourceData, format string, parts int) ([]string, error) {
+	import_id, switch_name := parseSwitchID(d.Id())
+	fields := strings.Split(import_id, ",")
+
+	if len(fields) != parts+2 || fields[0] == "" {
+		return nil, fmt.Errorf("Invalid import ID %q, expected %s", d.Id(), format)
+	}
+
+	asn, err := strconv.Atoi(fields[1])
+	if err != nil || asn < 1 || int64(asn) > maxBgpAsn {
+		return nil, fmt.Errorf("Invalid import ID %q, AS number must be between 1 and %d", d.Id(), int64(maxBgpAsn))
+	}
+
+	for _, field := range fields[2:] {
+		if field == "" {
+			return nil, fmt.Errorf("Invalid import ID %q, expected %s", d.Id(), format)
+		}
+	}
+
+	d.Set("switch", switch_name)
+	d.Set("vrf", fields[0])
+	d.Set("asn", asn)
+
+	return fields[2:], nil
+}
+
+func resourceBgpRouter() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Resource to configure BGP routers on AOS-CX switches.",
+		CreateContext: resourceBgpRouterCreate,
+		ReadContext:   resourceBgpRouterRead,
+		UpdateContext: resourceBgpRouterUpdate,
+		DeleteContext: resourceBgpRouterDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceBgpRouterImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		Schema: bgpRouterSchema(map[string]*schema.Schema{
+			"router_id": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsIPv4Address,
+				Description:  "Router ID in dotted decimal notation, by default the switch picks an interface address",
+			},
+		}),
+	}
+}
+
+func bgpRouterFromResourceData(d *schema.ResourceData) bgpRouter {
+	return bgpRouter{
+		Vrf:      d.Get("vrf").(string),
+		Asn:      d.Get("asn").(int),
+		RouterId: d.Get("router_id").(string),
+	}
+}
+
+func resourceBgpRouterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_router := bgpRouterFromResourceData(d)
+
+	err = tmp_router.Create(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Creating BGP Router VRF does not exist", err, cty.GetAttrPath("vrf"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Creating BGP Router", err, cty.GetAttrPath("asn"))...)
+		return diags
+	}
+
+	d.SetId(switchID(d, bgpRouterID(d)))
+
+	return resourceBgpRouterRead(ctx, d, m)
+}
+
+func resourceBgpRouterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Retrieve BGP router from sw if existing
+	tmp_router := bgpRouter{
+		Vrf: d.Get("vrf").(string),
+		Asn: d.Get("asn").(int),
+	}
+
+	err = tmp_router.Get(ctx, sw)
+
+	if isNotFound(err) {
+		// BGP router was removed outside of Terraform
+		d.SetId("")
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "BGP Router Not Found",
+			Detail:   bgpRouterID(d),
+		})
+		return diags
+	} else if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving BGP Router", err, nil)...)
+		return diags
+	}
+
+	d.Set("router_id", tmp_router.RouterId)
+
+	return diags
+}
+
+func resourceBgpRouterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_router := bgpRouterFromResourceData(d)
+
+	err = tmp_router.Update(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Updating BGP Router does not exist", err, cty.GetAttrPath("asn"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Updating BGP Router", err, nil)...)
+		return diags
+	}
+
+	return resourceBgpRouterRead(ctx, d, m)
+}
+
+func resourceBgpRouterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_router := bgpRouterFromResourceData(d)
+
+	err = tmp_router.Delete(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Deleting BGP Router does not exist", err, cty.GetAttrPath("asn"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Deleting BGP Router", err, nil)...)
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceBgpRouterImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is the VRF and AS number separated by a comma, optionally
+	// followed by @switch, e.g.
+	// terraform import aoscx_bgp_router.core default,65001
+	_, err := parseBgpImportID(d, "<vrf>,<AS number>", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(switchID(d, bgpRouterID(d)))
+
+	return []*schema.ResourceData{d}, nil
+}
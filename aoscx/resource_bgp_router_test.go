@@ -0,0 +1,96 @@
+package aoscx
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testBgpRouterPath = "system/vrfs/default/bgp_routers/65001"
+
+func testBgpRouterConfig(m *mockSwitch, body string) string {
+	return testProviderConfig(m) + `
+resource "aoscx_bgp_router" "test" {
+  asn = 65001
+` + body + `
+}
+`
+}
+
+func TestResourceBgpRouter(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, testBgpRouterPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testBgpRouterConfig(m, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_bgp_router.test", "id", "default,65001"),
+					resource.TestCheckResourceAttr("aoscx_bgp_router.test", "router_id", ""),
+					testCheckMockExists(m, testBgpRouterPath),
+				),
+			},
+			{
+				Config: testBgpRouterConfig(m, `
+  router_id = "10.255.0.1"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_bgp_router.test", "router_id", "10.255.0.1"),
+					testCheckMockAttr(m, testBgpRouterPath, "router_id", "10.255.0.1"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_bgp_router.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					m.patch(testBgpRouterPath, map[string]interface{}{"router_id": "10.255.0.2"})
+				},
+				Config: testBgpRouterConfig(m, `
+  router_id = "10.255.0.1"
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				PreConfig: func() {
+					m.remove(testBgpRouterPath)
+				},
+				Config: testBgpRouterConfig(m, `
+  router_id = "10.255.0.1"
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceBgpRouterInvalid(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testProviderConfig(m) + `
+resource "aoscx_bgp_router" "test" {
+  asn = 0
+}
+`,
+				ExpectError: regexp.MustCompile(`expected asn to be in the range`),
+			},
+			{
+				Config: testBgpRouterConfig(m, `
+  vrf = "missing"
+`),
+				ExpectError: regexp.MustCompile(`VRF does not exist`),
+			},
+		},
+	})
+}
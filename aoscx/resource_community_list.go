@@ -0,0 +1,247 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCommunityList() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Resource to configure BGP community lists on AOS-CX switches.",
+		CreateContext: resourceCommunityListCreate,
+		ReadContext:   resourceCommunityListRead,
+		UpdateContext: resourceCommunityListUpdate,
+		DeleteContext: resourceCommunityListDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCommunityListImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		CustomizeDiff: resourceCommunityListCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"switch": switchSchema(),
+			"name":   policyNameSchema("Name of the community list"),
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "standard",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"standard", "expanded"}, false),
+				Description:  "Type of the community list, standard entries match communities and expanded entries regular expressions",
+			},
+			"entry": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Entries of the community list, evaluated in the order of their sequence number",
+				Elem: &schema.Resource{
+					Schema: policyEntrySchema(map[string]*schema.Schema{
+						"community": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+							Description:  "Communities separated by spaces in AA:NN notation or well-known communities such as no-export, all of them must be present to match. A regular expression in expanded lists",
+						},
+					}),
+				},
+			},
+		},
+	}
+}
+
+// resourceCommunityListCustomizeDiff checks the sequence numbers and
+// communities of the entries.
+func resourceCommunityListCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	list_type := d.Get("type").(string)
+	entries := d.Get("entry").(*schema.Set).List()
+
+	if err := checkPolicySequences(entries); err != nil {
+		return err
+	}
+
+	for _, item := range entries {
+		entry := item.(map[string]interface{})
+		sequence := entry["sequence"].(int)
+		community := entry["community"].(string)
+		if community == "" {
+			continue
+		}
+
+		if list_type == "expanded" {
+			if _, err := regexp.Compile(community); err != nil {
+				return fmt.Errorf("entry %d: invalid regular expression %q: %v", sequence, community, err)
+			}
+		} else if err := checkCommunities(community); err != nil {
+			return fmt.Errorf("entry %d: %v", sequence, err)
+		}
+	}
+
+	return nil
+}
+
+func communityListFromResourceData(d *schema.ResourceData) communityList {
+	tmp_list := communityList{
+		Name:    d.Get("name").(string),
+		Type:    d.Get("type").(string),
+		Entries: []communityListEntry{},
+	}
+
+	for _, item := range d.Get("entry").(*schema.Set).List() {
+		entry := item.(map[string]interface{})
+		tmp_list.Entries = append(tmp_list.Entries, communityListEntry{
+			Preference:  entry["sequence"].(int),
+			Action:      entry["action"].(string),
+			MatchString: entry["community"].(string),
+		})
+	}
+
+	sort.Slice(tmp_list.Entries, func(i, j int) bool {
+		return tmp_list.Entries[i].Preference < tmp_list.Entries[j].Preference
+	})
+
+	return tmp_list
+}
+
+func resourceCommunityListCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_list := communityListFromResourceData(d)
+
+	created, err := tmp_list.Create(ctx, sw)
+
+	if requestFailed(err) {
+		return append(diags, policyCreateDiagnostics(d, created, tmp_list.Name, "Error in Creating Community List", err)...)
+	}
+
+	d.SetId(switchID(d, tmp_list.Name))
+
+	return resourceCommunityListRead(ctx, d, m)
+}
+
+func resourceCommunityListRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Retrieve community list from sw if existing
+	tmp_list := communityList{
+		Name: d.Get("name").(string),
+	}
+
+	err = tmp_list.Get(ctx, sw)
+
+	if isNotFound(err) {
+		// Community list was removed outside of Terraform
+		d.SetId("")
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Community List Not Found",
+			Detail:   tmp_list.Name,
+		})
+		return diags
+	} else if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving Community List", err, nil)...)
+		return diags
+	}
+
+	entries := []interface{}{}
+	for _, entry := range tmp_list.Entries {
+		entries = append(entries, map[string]interface{}{
+			"sequence":  entry.Preference,
+			"action":    entry.Action,
+			"community": entry.MatchString,
+		})
+	}
+
+	d.Set("type", tmp_list.Type)
+	d.Set("entry", entries)
+
+	return diags
+}
+
+func resourceCommunityListUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_list := communityListFromResourceData(d)
+
+	err = tmp_list.Update(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Updating Community List does not exist", err, cty.GetAttrPath("name"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Updating Community List", err, nil)...)
+		return diags
+	}
+
+	return resourceCommunityListRead(ctx, d, m)
+}
+
+func resourceCommunityListDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_list := communityListFromResourceData(d)
+
+	err = tmp_list.Delete(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Deleting Community List does not exist", err, cty.GetAttrPath("name"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Deleting Community List", err, nil)...)
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceCommunityListImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is the name of the community list, optionally followed by
+	// @switch, e.g.
+	// terraform import aoscx_community_list.customers CUSTOMERS
+	name, switch_name := parseSwitchID(d.Id())
+	if name == "" {
+		return nil, fmt.Errorf("Invalid import ID %q, expected <name>", d.Id())
+	}
+
+	d.Set("switch", switch_name)
+	d.Set("name", name)
+	d.SetId(switchID(d, name))
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,164 @@
+package aoscx
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testCommunityListPath = "system/community_lists/CUSTOMERS"
+
+func testCommunityListConfig(m *mockSwitch, body string) string {
+	return testProviderConfig(m) + `
+resource "aoscx_community_list" "test" {
+  name = "CUSTOMERS"
+` + body + `
+}
+`
+}
+
+const testCommunityListEntries = `
+  entry {
+    sequence  = 10
+    action    = "permit"
+    community = "65000:100"
+  }
+
+  entry {
+    sequence  = 20
+    action    = "deny"
+    community = "65000:200 no-export"
+  }
+`
+
+const testCommunityListEntriesUpdated = `
+  entry {
+    sequence  = 10
+    action    = "permit"
+    community = "65000:100"
+  }
+
+  entry {
+    sequence  = 30
+    action    = "permit"
+    community = "internet"
+  }
+`
+
+func TestResourceCommunityList(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, testCommunityListPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testCommunityListConfig(m, testCommunityListEntries),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_community_list.test", "id", "CUSTOMERS"),
+					resource.TestCheckResourceAttr("aoscx_community_list.test", "type", "standard"),
+					resource.TestCheckResourceAttr("aoscx_community_list.test", "entry.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("aoscx_community_list.test", "entry.*", map[string]string{
+						"sequence":  "20",
+						"action":    "deny",
+						"community": "65000:200 no-export",
+					}),
+					testCheckMockAttr(m, testCommunityListPath, "type", "community-list"),
+					testCheckMockAttr(m, testCommunityListPath+"/community_list_entries/10", "match_string", "65000:100"),
+				),
+			},
+			{
+				Config: testCommunityListConfig(m, testCommunityListEntriesUpdated),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_community_list.test", "entry.#", "2"),
+					testCheckMockExists(m, testCommunityListPath+"/community_list_entries/30"),
+					testCheckMockDestroyed(m, testCommunityListPath+"/community_list_entries/20"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_community_list.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					m.patch(testCommunityListPath+"/community_list_entries/30", map[string]interface{}{"action": "deny"})
+				},
+				Config:             testCommunityListConfig(m, testCommunityListEntriesUpdated),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				PreConfig: func() {
+					m.remove(testCommunityListPath)
+				},
+				Config:             testCommunityListConfig(m, testCommunityListEntriesUpdated),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceCommunityListExpanded(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testCommunityListConfig(m, `
+  type = "expanded"
+
+  entry {
+    sequence  = 10
+    action    = "permit"
+    community = "^65000:[0-9]+$"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_community_list.test", "type", "expanded"),
+					testCheckMockAttr(m, testCommunityListPath, "type", "community-expanded-list"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_community_list.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceCommunityListInvalid(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testCommunityListConfig(m, `
+  entry {
+    sequence  = 10
+    action    = "permit"
+    community = "no-exports"
+  }
+`),
+				ExpectError: regexp.MustCompile(`invalid community "no-exports"`),
+			},
+			{
+				Config: testCommunityListConfig(m, `
+  type = "expanded"
+
+  entry {
+    sequence  = 10
+    action    = "permit"
+    community = "65000:(["
+  }
+`),
+				ExpectError: regexp.MustCompile(`invalid regular expression`),
+			},
+		},
+	})
+}
@@ -2,11 +2,14 @@ package aoscx
 
 import (
 	"context"
+	"fmt"
 	"hash/fnv"
 	"strconv"
+	"time"
 
 	"github.com/aruba/aoscxgo"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -18,7 +21,17 @@ func resourceFullConfig() *schema.Resource {
 		ReadContext:   resourceFullConfigRead,
 		UpdateContext: resourceFullConfigUpdate,
 		DeleteContext: resourceFullConfigDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceFullConfigImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
 		Schema: map[string]*schema.Schema{
+			"switch": switchSchema(),
 			"filename": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
@@ -44,7 +57,10 @@ func resourceFullConfigCreate(ctx context.Context, d *schema.ResourceData, m int
 	var diags diag.Diagnostics
 	var err error
 
-	sw := m.(*aoscxgo.Client)
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
 	filename := d.Get("filename").(string)
 
 	config_obj := aoscxgo.FullConfig{
@@ -53,14 +69,14 @@ func resourceFullConfigCreate(ctx context.Context, d *schema.ResourceData, m int
 
 	_, err = config_obj.Create(sw)
 
-	if err != nil {
-		diags = append(diags, diag.Errorf("Error in Creating FullConfig\n%v", err)...)
+	if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Creating FullConfig", err, cty.GetAttrPath("filename"))...)
 		return diags
 	}
 
 	h := fnv.New32()
 	h.Write([]byte(config_obj.Config))
-	d.SetId(strconv.Itoa(int(h.Sum32())))
+	d.SetId(switchID(d, strconv.Itoa(int(h.Sum32()))))
 
 	d.Set("filename", filename)
 	d.Set("diff", "")
@@ -75,7 +91,10 @@ func resourceFullConfigRead(ctx context.Context, d *schema.ResourceData, m inter
 	var diags diag.Diagnostics
 	var err error
 
-	sw := m.(*aoscxgo.Client)
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
 
 	current_config := aoscxgo.FullConfig{}
 
@@ -87,8 +106,8 @@ func resourceFullConfigRead(ctx context.Context, d *schema.ResourceData, m inter
 
 	err = current_config.Get(sw)
 
-	if err != nil {
-		// Failure in Config retrieval
+	if isNotFound(err) {
+		// Config was removed outside of Terraform
 		d.SetId("")
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Warning,
@@ -96,6 +115,9 @@ func resourceFullConfigRead(ctx context.Context, d *schema.ResourceData, m inter
 			Detail:   "Config Not Found",
 		})
 		return diags
+	} else if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving FullConfig", err, nil)...)
+		return diags
 	}
 
 	d.Set("config", current_config.Config)
@@ -109,7 +131,10 @@ func resourceFullConfigUpdate(ctx context.Context, d *schema.ResourceData, m int
 	var diags diag.Diagnostics
 	var err error
 
-	sw := m.(*aoscxgo.Client)
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
 
 	current_config := aoscxgo.FullConfig{}
 
@@ -119,8 +144,8 @@ func resourceFullConfigUpdate(ctx context.Context, d *schema.ResourceData, m int
 
 	err = current_config.Get(sw)
 
-	if err != nil {
-		diags = append(diags, diag.Errorf("Error in Retrieving FullConfig %v", err)...)
+	if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving FullConfig", err, nil)...)
 		return diags
 	}
 
@@ -148,8 +173,8 @@ func resourceFullConfigUpdate(ctx context.Context, d *schema.ResourceData, m int
 
 		_, err := config_obj.Create(sw)
 
-		if err != nil {
-			diags = append(diags, diag.Errorf("Error in Updating FullConfig %v", err)...)
+		if requestFailed(err) {
+			diags = append(diags, errorDiagnostics("Error in Updating FullConfig", err, cty.GetAttrPath("filename"))...)
 			return diags
 		}
 	}
@@ -165,3 +190,32 @@ func resourceFullConfigDelete(ctx context.Context, d *schema.ResourceData, m int
 	d.Set("filename", "")
 	return nil
 }
+
+func resourceFullConfigImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is the local config filename the running-config is compared
+	// against, optionally followed by @switch
+	filename, switch_name := parseSwitchID(d.Id())
+
+	if filename == "" {
+		return nil, fmt.Errorf("Invalid FullConfig import ID, expected the path of a local config file")
+	}
+
+	config_obj := aoscxgo.FullConfig{
+		FileName: filename,
+	}
+
+	local_config_str, err := config_obj.ReadConfigFile(filename)
+
+	if err != nil {
+		return nil, fmt.Errorf("Error in Reading FullConfig file %s: %v", filename, err)
+	}
+
+	// ID is derived from the config content the same way as on create
+	h := fnv.New32()
+	h.Write([]byte(local_config_str))
+	d.Set("switch", switch_name)
+	d.SetId(switchID(d, strconv.Itoa(int(h.Sum32()))))
+	d.Set("filename", filename)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,87 @@
+package aoscx
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testFullConfigConfig(m *mockSwitch, filename string) string {
+	return testProviderConfig(m) + fmt.Sprintf(`
+resource "aoscx_full_config" "test" {
+  filename = %q
+}
+`, filename)
+}
+
+// testCheckFullConfigDiff checks whether the diff attribute reports drift
+// between the local config file and the running-config.
+func testCheckFullConfigDiff(drifted bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources["aoscx_full_config.test"]
+		if !ok {
+			return fmt.Errorf("aoscx_full_config.test not found in state")
+		}
+		if diff := rs.Primary.Attributes["diff"]; (diff != "") != drifted {
+			return fmt.Errorf("unexpected diff %q", diff)
+		}
+		return nil
+	}
+}
+
+func TestResourceFullConfig(t *testing.T) {
+	m := newMockSwitch(t)
+
+	filename := filepath.Join(t.TempDir(), "leaf1.json")
+	config := `{"System": {"hostname": "leaf1"}}`
+	if err := os.WriteFile(filename, []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testFullConfigConfig(m, filename),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_full_config.test", "filename", filename),
+					resource.TestCheckResourceAttrSet("aoscx_full_config.test", "config"),
+					testCheckFullConfigDiff(false),
+					testCheckMockExists(m, "fullconfigs/running-config"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_full_config.test",
+				ImportState:       true,
+				ImportStateId:     filename,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					m.patch("fullconfigs/running-config", map[string]interface{}{"System": map[string]interface{}{"hostname": "changed"}})
+				},
+				RefreshState: true,
+				Check:        testCheckFullConfigDiff(true),
+			},
+		},
+	})
+}
+
+func TestResourceFullConfigMissingFile(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testFullConfigConfig(m, filepath.Join(t.TempDir(), "missing.json")),
+				ExpectError: regexp.MustCompile(`Error in Creating FullConfig`),
+			},
+		},
+	})
+}
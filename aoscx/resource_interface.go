@@ -2,9 +2,12 @@ package aoscx
 
 import (
 	"context"
+	"fmt"
+	"sort"
 
 	"github.com/aruba/aoscxgo"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -12,13 +15,23 @@ import (
 
 func resourceInterface() *schema.Resource {
 	return &schema.Resource{
-		Description:   "Resource to configure interfaces physical attributes on AOS-CX switches.",
+		Description:   "Resource to configure interfaces physical attributes on AOS-CX switches. Port settings that are not configured are left as they are on the switch.",
 		CreateContext: resourceInterfaceCreate,
 		ReadContext:   resourceInterfaceRead,
 		UpdateContext: resourceInterfaceUpdate,
 		DeleteContext: resourceInterfaceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceInterfaceImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
+			"switch": switchSchema(),
 			"name": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
@@ -35,8 +48,156 @@ func resourceInterface() *schema.Resource {
 				Optional:     true,
 				ValidateFunc: validation.StringInSlice([]string{"up", "down"}, true),
 			},
+			"mtu": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(46, 9198),
+				Description:  "MTU of the port in bytes, at most the maximum MTU reported by the switch for the port",
+			},
+			"speed": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(portSpeedValues(), false),
+				Description:  "Speed of the port, auto or one of the speeds the switch reports for the port, e.g. 1g or 25g. With autonegotiation only this speed is advertised",
+			},
+			"duplex": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"full", "half"}, false),
+				Description:  "Duplex of the port, either full or half. Half duplex needs a speed of 10m or 100m",
+			},
+			"autonegotiation": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Negotiate speed and duplex with the link partner, disabling it needs a speed",
+			},
+			"fec": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"auto", "off", "cl74", "cl91"}, false),
+				Description:  "Forward error correction of the port, auto, off or a mode the switch reports for the port, cl74 or cl91",
+			},
+			"flow_control": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"off", "rx", "tx", "rxtx"}, false),
+				Description:  "Pause frames the port honors and sends, off, rx, tx or rxtx",
+			},
+			"energy_efficient_ethernet": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Enable energy efficient ethernet on ports that support it",
+			},
 		},
+		CustomizeDiff: resourceInterfaceCustomizeDiff,
+	}
+}
+
+// portSpeedValues returns the values of the speed attribute.
+func portSpeedValues() []string {
+	values := []string{"auto"}
+	for speed := range portSpeeds {
+		values = append(values, speed)
+	}
+	sort.Strings(values)
+	return values
+}
+
+// resourceInterfaceCustomizeDiff checks that the speed, duplex and
+// autonegotiation settings fit together and that the port supports the
+// configured settings.
+func resourceInterfaceCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	configured := portSettingsConfigured(d.GetRawConfig())
+	speed := d.Get("speed").(string)
+
+	if configured["autonegotiation"] && !d.Get("autonegotiation").(bool) && (speed == "" || speed == "auto") {
+		return fmt.Errorf("a speed is needed when autonegotiation is disabled")
+	}
+	if configured["duplex"] && d.Get("duplex").(string) == "half" && speed != "10m" && speed != "100m" {
+		return fmt.Errorf("half duplex needs a speed of 10m or 100m")
+	}
+
+	// Only ask the switch when a setting depending on the port changes
+	changed := false
+	for _, attribute := range []string{"mtu", "speed", "fec", "flow_control", "energy_efficient_ethernet"} {
+		changed = changed || (configured[attribute] && d.HasChange(attribute))
+	}
+	if !changed || !d.NewValueKnown("name") || !d.NewValueKnown("switch") {
+		return nil
+	}
+
+	sw, diags := namedSwitchClient(ctx, m, d.Get("switch").(string), cty.GetAttrPath("switch"))
+	if diags.HasError() {
+		return fmt.Errorf("%s: %s", diags[0].Summary, diags[0].Detail)
+	}
+
+	caps, err := getPortCapabilities(ctx, sw, d.Get("name").(string))
+	if isNotFound(err) || caps == nil {
+		// Interfaces the switch does not have fail when they are created
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	tmp_port := portSettings{
+		Mtu:                     d.Get("mtu").(int),
+		Speed:                   speed,
+		Fec:                     d.Get("fec").(string),
+		FlowControl:             d.Get("flow_control").(string),
+		EnergyEfficientEthernet: d.Get("energy_efficient_ethernet").(bool),
+		Configured:              configured,
+	}
+	if _, err := tmp_port.check(*caps); err != nil {
+		return fmt.Errorf("interface %s: %s", d.Get("name").(string), err)
 	}
+
+	return nil
+}
+
+// portSettingsConfigured returns the port setting attributes set in the
+// configuration raw, the others are left as they are on the switch.
+func portSettingsConfigured(raw cty.Value) map[string]bool {
+	configured := map[string]bool{}
+	if raw.IsNull() || !raw.IsKnown() {
+		return configured
+	}
+
+	for attribute := range portSettingKeys {
+		configured[attribute] = !raw.GetAttr(attribute).IsNull()
+	}
+
+	return configured
+}
+
+func portSettingsFromResourceData(d *schema.ResourceData) portSettings {
+	return portSettings{
+		Mtu:                     d.Get("mtu").(int),
+		Speed:                   d.Get("speed").(string),
+		Duplex:                  d.Get("duplex").(string),
+		Autonegotiation:         d.Get("autonegotiation").(bool),
+		Fec:                     d.Get("fec").(string),
+		FlowControl:             d.Get("flow_control").(string),
+		EnergyEfficientEthernet: d.Get("energy_efficient_ethernet").(bool),
+		Configured:              portSettingsConfigured(d.GetRawConfig()),
+	}
+}
+
+// updatePortSettings writes the port settings configured on d, previous is
+// the user_config of the interface before aoscxgo replaced it. summary is
+// used for errors of the switch.
+func updatePortSettings(ctx context.Context, sw *aoscxgo.Client, d *schema.ResourceData, previous map[string]string, summary string) diag.Diagnostics {
+	tmp_port := portSettingsFromResourceData(d)
+
+	err := tmp_port.Update(ctx, sw, d.Get("name").(string), previous)
+
+	return errorDiagnostics(summary, err, nil)
 }
 
 func resourceInterfaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -44,7 +205,10 @@ func resourceInterfaceCreate(ctx context.Context, d *schema.ResourceData, m inte
 	var diags diag.Diagnostics
 	var err error
 
-	sw := m.(*aoscxgo.Client)
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
 
 	tmp_int := aoscxgo.Interface{
 		Name:        d.Get("name").(string),
@@ -52,42 +216,55 @@ func resourceInterfaceCreate(ctx context.Context, d *schema.ResourceData, m inte
 		AdminState:  d.Get("admin_state").(string),
 	}
 
-	err = tmp_int.Create(sw)
+	var previous map[string]string
 
-	//defer logout(tr, cookie, url)
+	err = tmp_int.Create(sw)
 
 	if materialized := tmp_int.GetStatus(); !materialized {
+		create_err := err
 
 		err = tmp_int.Get(sw)
 
-		if err != nil {
-			{
-				diags = append(diags, diag.Errorf("Error in Creating Interface ", err)...)
-				return diags
+		if requestFailed(err) {
+			if requestFailed(create_err) {
+				err = create_err
 			}
+			diags = append(diags, errorDiagnostics("Error in Creating Interface", err, nil)...)
+			return diags
 		}
 
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Warning,
 			Summary:  "Interface Already Existing",
-			Detail:   string(tmp_int.Name),
+			Detail:   tmp_int.Name,
 		})
 
+		// aoscxgo replaces user_config, keep the port settings of the switch
+		tmp_port_int, err := getPortInterface(ctx, sw, tmp_int.Name)
+		if requestFailed(err) {
+			diags = append(diags, errorDiagnostics("Error in Retrieving Interface", err, nil)...)
+			return diags
+		}
+		previous = tmp_port_int.UserConfig
+
 		err = tmp_int.Update(sw)
 
-		if err != nil {
-			if err.(*aoscxgo.RequestError).StatusCode == "404 Not Found" {
-				diags = append(diags, diag.Errorf("Error Updating Interface does not exist: %s", err.(*aoscxgo.RequestError).StatusCode)...)
-				return diags
-			} else if err.(*aoscxgo.RequestError).StatusCode != "204 No Content" {
-				diags = append(diags, diag.Errorf("Error in Updating Interface: %s", err.(*aoscxgo.RequestError).StatusCode)...)
+		if requestFailed(err) {
+			if isNotFound(err) {
+				diags = append(diags, errorDiagnostics("Error Updating Interface does not exist", err, cty.GetAttrPath("name"))...)
 				return diags
 			}
+			diags = append(diags, errorDiagnostics("Error in Updating Interface", err, nil)...)
+			return diags
 		}
 
 	}
 
-	d.SetId(d.Get("name").(string))
+	if port_diags := updatePortSettings(ctx, sw, d, previous, "Error in Creating Interface"); port_diags.HasError() {
+		return append(diags, port_diags...)
+	}
+
+	d.SetId(switchID(d, d.Get("name").(string)))
 	d.Set("name", d.Get("name").(string))
 
 	resourceInterfaceRead(ctx, d, m)
@@ -100,7 +277,10 @@ func resourceInterfaceRead(ctx context.Context, d *schema.ResourceData, m interf
 	var diags diag.Diagnostics
 	var err error
 
-	sw := m.(*aoscxgo.Client)
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
 
 	// Retrieve Interface from sw if existing
 	tmp_int := aoscxgo.Interface{
@@ -109,8 +289,8 @@ func resourceInterfaceRead(ctx context.Context, d *schema.ResourceData, m interf
 	//tmp_vlan.GetStatus() will return if existing
 	err = tmp_int.Get(sw)
 
-	if err != nil {
-		//Failure in VLAN retrieval
+	if isNotFound(err) {
+		// Interface was removed outside of Terraform
 		d.SetId("")
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Warning,
@@ -118,11 +298,30 @@ func resourceInterfaceRead(ctx context.Context, d *schema.ResourceData, m interf
 			Detail:   "Interface Not Found",
 		})
 		return diags
+	} else if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving Interface", err, nil)...)
+		return diags
+	}
+
+	tmp_port := portSettings{}
+
+	err = tmp_port.Get(ctx, sw, tmp_int.Name)
+
+	if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving Interface", err, nil)...)
+		return diags
 	}
 
 	d.Set("name", tmp_int.Name)
 	d.Set("description", tmp_int.Description)
 	d.Set("admin_state", tmp_int.AdminState)
+	d.Set("mtu", tmp_port.Mtu)
+	d.Set("speed", tmp_port.Speed)
+	d.Set("duplex", tmp_port.Duplex)
+	d.Set("autonegotiation", tmp_port.Autonegotiation)
+	d.Set("fec", tmp_port.Fec)
+	d.Set("flow_control", tmp_port.FlowControl)
+	d.Set("energy_efficient_ethernet", tmp_port.EnergyEfficientEthernet)
 
 	return diags
 }
@@ -132,41 +331,65 @@ func resourceInterfaceUpdate(ctx context.Context, d *schema.ResourceData, m inte
 	var diags diag.Diagnostics
 	var err error
 
-	sw := m.(*aoscxgo.Client)
-
-	// Retrieve Interface from sw if existing
-	tmp_int := aoscxgo.Interface{
-		Name: d.Get("name").(string),
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
 	}
-	//tmp_vlan.GetStatus() will return if existing
-	err = tmp_int.Get(sw)
 
-	if d.HasChange("description") {
-		tmp_int.Description = d.Get("description").(string)
-	}
+	var previous map[string]string
 
-	if d.HasChange("admin_state") {
-		tmp_state := d.Get("admin_state").(string)
-		if tmp_state == "" || (tmp_state != "down" && tmp_state != "up") {
-			tmp_int.AdminState = "down"
-			d.Set("admin_state", "down")
-			// Should enforce value can only be up or down
+	// aoscxgo replaces user_config, it is only called for its own attributes
+	// so port settings don't flap on every update
+	if d.HasChanges("description", "admin_state") {
+		// Retrieve Interface from sw if existing
+		tmp_int := aoscxgo.Interface{
+			Name: d.Get("name").(string),
 		}
-		tmp_int.AdminState = tmp_state
-	}
+		//tmp_vlan.GetStatus() will return if existing
+		err = tmp_int.Get(sw)
 
-	err = tmp_int.Update(sw)
+		if requestFailed(err) {
+			diags = append(diags, errorDiagnostics("Error in Retrieving Interface", err, cty.GetAttrPath("name"))...)
+			return diags
+		}
 
-	if err != nil {
-		if err.(*aoscxgo.RequestError).StatusCode == "404 Not Found" {
-			diags = append(diags, diag.Errorf("Error Updating Interface does not exist: %s", err.(*aoscxgo.RequestError).StatusCode)...)
+		tmp_port_int, err := getPortInterface(ctx, sw, tmp_int.Name)
+		if requestFailed(err) {
+			diags = append(diags, errorDiagnostics("Error in Retrieving Interface", err, cty.GetAttrPath("name"))...)
 			return diags
-		} else if err.(*aoscxgo.RequestError).StatusCode != "204 No Content" {
-			diags = append(diags, diag.Errorf("Error in Updating Interface: %s", err.(*aoscxgo.RequestError).StatusCode)...)
+		}
+		previous = tmp_port_int.UserConfig
+
+		if d.HasChange("description") {
+			tmp_int.Description = d.Get("description").(string)
+		}
+
+		if d.HasChange("admin_state") {
+			tmp_state := d.Get("admin_state").(string)
+			if tmp_state == "" || (tmp_state != "down" && tmp_state != "up") {
+				tmp_int.AdminState = "down"
+				d.Set("admin_state", "down")
+				// Should enforce value can only be up or down
+			}
+			tmp_int.AdminState = tmp_state
+		}
+
+		err = tmp_int.Update(sw)
+
+		if requestFailed(err) {
+			if isNotFound(err) {
+				diags = append(diags, errorDiagnostics("Error Updating Interface does not exist", err, cty.GetAttrPath("name"))...)
+				return diags
+			}
+			diags = append(diags, errorDiagnostics("Error in Updating Interface", err, nil)...)
 			return diags
 		}
 	}
 
+	if port_diags := updatePortSettings(ctx, sw, d, previous, "Error in Updating Interface"); port_diags.HasError() {
+		return append(diags, port_diags...)
+	}
+
 	return resourceInterfaceRead(ctx, d, m)
 }
 
@@ -175,7 +398,10 @@ func resourceInterfaceDelete(ctx context.Context, d *schema.ResourceData, m inte
 	var diags diag.Diagnostics
 	var err error
 
-	sw := m.(*aoscxgo.Client)
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
 
 	// Create Interface Obj
 	tmp_int := aoscxgo.Interface{
@@ -184,16 +410,31 @@ func resourceInterfaceDelete(ctx context.Context, d *schema.ResourceData, m inte
 
 	err = tmp_int.Delete(sw)
 
-	if err != nil {
-		if err.(*aoscxgo.RequestError).StatusCode == "404 Not Found" {
-			diags = append(diags, diag.Errorf("Error Updating Interface does not exist: %s", err.(*aoscxgo.RequestError).StatusCode)...)
-			return diags
-		} else if err.(*aoscxgo.RequestError).StatusCode != "204 No Content" {
-			diags = append(diags, diag.Errorf("Error in Updating Interface: %s ", err.(*aoscxgo.RequestError).StatusCode)...)
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Deleting Interface does not exist", err, cty.GetAttrPath("name"))...)
 			return diags
 		}
+		diags = append(diags, errorDiagnostics("Error in Deleting Interface", err, nil)...)
+		return diags
 	}
 
 	d.SetId("")
 	return nil
 }
+
+func resourceInterfaceImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is the interface name optionally followed by @switch, e.g.
+	// terraform import aoscx_interface.int_1_1_14 1/1/14
+	name, switch_name := parseSwitchID(d.Id())
+
+	if name == "" {
+		return nil, fmt.Errorf("Invalid Interface import ID, expected an interface name such as 1/1/1")
+	}
+
+	d.Set("switch", switch_name)
+	d.Set("name", name)
+	d.SetId(switchID(d, name))
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,218 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aruba/aoscxgo"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// withIpSettingsSchema adds the attributes of the IP options shared by
+// aoscx_l3_interface and aoscx_vlan_interface to resource_schema.
+func withIpSettingsSchema(resource_schema map[string]*schema.Schema) map[string]*schema.Schema {
+	ip_schema := map[string]*schema.Schema{
+		"dhcp_relay_servers": &schema.Schema{
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "DHCP servers the DHCP requests received on the interface are relayed to (ip helper-address)",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"vrf": &schema.Schema{
+						Type:        schema.TypeString,
+						Optional:    true,
+						Default:     "default",
+						Description: "VRF the DHCP servers are reached through",
+					},
+					"addresses": &schema.Schema{
+						Type:        schema.TypeList,
+						Required:    true,
+						MinItems:    1,
+						MaxItems:    8,
+						Description: "IPv4 addresses of the DHCP servers",
+						Elem: &schema.Schema{
+							Type:         schema.TypeString,
+							ValidateFunc: validation.IsIPv4Address,
+						},
+					},
+				},
+			},
+		},
+		"ip_mtu": &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      1500,
+			ValidateFunc: validation.IntBetween(68, 9198),
+			Description:  "IP MTU of the interface",
+		},
+		"proxy_arp": &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Answer ARP requests for addresses reachable through other interfaces",
+		},
+		"local_proxy_arp": &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Answer ARP requests between hosts of the subnet of the interface",
+		},
+		"ip_directed_broadcast": &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Forward directed broadcasts to the subnet of the interface",
+		},
+		"icmp_redirects": &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Send ICMP redirects for packets routed back out of the interface they were received on",
+		},
+		"ipv6_nd_ra": &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Send IPv6 router advertisements, they are suppressed without this block",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"interval": &schema.Schema{
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      600,
+						ValidateFunc: validation.IntBetween(4, 1800),
+						Description:  "Maximum seconds between router advertisements",
+					},
+					"lifetime": &schema.Schema{
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      1800,
+						ValidateFunc: validation.IntBetween(0, 9000),
+						Description:  "Seconds hosts use the switch as default router, 0 advertises it is not a default router",
+					},
+					"managed_config": &schema.Schema{
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     false,
+						Description: "Set the managed address configuration flag, hosts then get addresses from DHCPv6",
+					},
+					"other_config": &schema.Schema{
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     false,
+						Description: "Set the other configuration flag, hosts then get other settings from DHCPv6",
+					},
+				},
+			},
+		},
+	}
+
+	for key, value := range ip_schema {
+		resource_schema[key] = value
+	}
+
+	return resource_schema
+}
+
+// checkIpSettings checks that the DHCP servers of a VRF are in a single
+// dhcp_relay_servers block and that router advertisements outlive their
+// interval.
+func checkIpSettings(d *schema.ResourceDiff) error {
+	vrf_names := map[string]bool{}
+	for _, relay := range d.Get("dhcp_relay_servers").(*schema.Set).List() {
+		vrf_name := relay.(map[string]interface{})["vrf"].(string)
+		if vrf_names[vrf_name] {
+			return fmt.Errorf("DHCP relay servers of VRF %s are configured more than once", vrf_name)
+		}
+		vrf_names[vrf_name] = true
+	}
+
+	if len(d.Get("ipv6_nd_ra").([]interface{})) > 0 {
+		interval := d.Get("ipv6_nd_ra.0.interval").(int)
+		lifetime := d.Get("ipv6_nd_ra.0.lifetime").(int)
+		if lifetime != 0 && lifetime < interval {
+			return fmt.Errorf("ipv6_nd_ra lifetime %d must be 0 or at least the interval %d", lifetime, interval)
+		}
+	}
+
+	return nil
+}
+
+func ipSettingsFromResourceData(d *schema.ResourceData) ipSettings {
+	tmp_settings := ipSettings{
+		DhcpRelayServers:  map[string][]string{},
+		IpMtu:             d.Get("ip_mtu").(int),
+		ProxyArp:          d.Get("proxy_arp").(bool),
+		LocalProxyArp:     d.Get("local_proxy_arp").(bool),
+		DirectedBroadcast: d.Get("ip_directed_broadcast").(bool),
+		IcmpRedirects:     d.Get("icmp_redirects").(bool),
+	}
+
+	for _, relay := range d.Get("dhcp_relay_servers").(*schema.Set).List() {
+		relay_map := relay.(map[string]interface{})
+		servers := []string{}
+		for _, address := range relay_map["addresses"].([]interface{}) {
+			servers = append(servers, address.(string))
+		}
+		tmp_settings.DhcpRelayServers[relay_map["vrf"].(string)] = servers
+	}
+
+	if len(d.Get("ipv6_nd_ra").([]interface{})) > 0 {
+		tmp_settings.NdRa = &ndRaSettings{
+			Interval:      d.Get("ipv6_nd_ra.0.interval").(int),
+			Lifetime:      d.Get("ipv6_nd_ra.0.lifetime").(int),
+			ManagedConfig: d.Get("ipv6_nd_ra.0.managed_config").(bool),
+			OtherConfig:   d.Get("ipv6_nd_ra.0.other_config").(bool),
+		}
+	}
+
+	return tmp_settings
+}
+
+func setIpSettings(d *schema.ResourceData, tmp_settings ipSettings) {
+	vrf_names := []string{}
+	for vrf_name := range tmp_settings.DhcpRelayServers {
+		vrf_names = append(vrf_names, vrf_name)
+	}
+	sort.Strings(vrf_names)
+
+	relays := []interface{}{}
+	for _, vrf_name := range vrf_names {
+		relays = append(relays, map[string]interface{}{
+			"vrf":       vrf_name,
+			"addresses": tmp_settings.DhcpRelayServers[vrf_name],
+		})
+	}
+	d.Set("dhcp_relay_servers", relays)
+
+	d.Set("ip_mtu", tmp_settings.IpMtu)
+	d.Set("proxy_arp", tmp_settings.ProxyArp)
+	d.Set("local_proxy_arp", tmp_settings.LocalProxyArp)
+	d.Set("ip_directed_broadcast", tmp_settings.DirectedBroadcast)
+	d.Set("icmp_redirects", tmp_settings.IcmpRedirects)
+
+	nd_ra := []interface{}{}
+	if tmp_settings.NdRa != nil {
+		nd_ra = append(nd_ra, map[string]interface{}{
+			"interval":       tmp_settings.NdRa.Interval,
+			"lifetime":       tmp_settings.NdRa.Lifetime,
+			"managed_config": tmp_settings.NdRa.ManagedConfig,
+			"other_config":   tmp_settings.NdRa.OtherConfig,
+		})
+	}
+	d.Set("ipv6_nd_ra", nd_ra)
+}
+
+// updateIpSettings writes the IP options configured on d to the interface,
+// summary is the summary of the error diagnostic.
+func updateIpSettings(ctx context.Context, sw *aoscxgo.Client, d *schema.ResourceData, interface_name string, summary string) diag.Diagnostics {
+	tmp_settings := ipSettingsFromResourceData(d)
+
+	err := tmp_settings.Update(ctx, sw, interface_name)
+
+	return errorDiagnostics(summary, err, nil)
+}
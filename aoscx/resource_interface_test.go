@@ -0,0 +1,180 @@
+package aoscx
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testInterfaceConfig(m *mockSwitch, description string, admin_state string) string {
+	return testProviderConfig(m) + fmt.Sprintf(`
+resource "aoscx_interface" "test" {
+  name        = "1/1/1"
+  description = %q
+  admin_state = %q
+}
+`, description, admin_state)
+}
+
+func TestResourceInterface(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, "system/interfaces/1%2F1%2F1"),
+		Steps: []resource.TestStep{
+			{
+				Config: testInterfaceConfig(m, "uplink", "up"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_interface.test", "id", "1/1/1"),
+					resource.TestCheckResourceAttr("aoscx_interface.test", "description", "uplink"),
+					resource.TestCheckResourceAttr("aoscx_interface.test", "admin_state", "up"),
+				),
+			},
+			{
+				Config: testInterfaceConfig(m, "spare", "down"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_interface.test", "description", "spare"),
+					resource.TestCheckResourceAttr("aoscx_interface.test", "admin_state", "down"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_interface.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					m.patch("system/interfaces/1%2F1%2F1", map[string]interface{}{"description": "changed on switch"})
+				},
+				Config:             testInterfaceConfig(m, "spare", "down"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testInterfacePortConfig(m *mockSwitch, name string, body string) string {
+	return testProviderConfig(m) + fmt.Sprintf(`
+resource "aoscx_interface" "test" {
+  name = %q
+`, name) + body + `
+}
+`
+}
+
+func TestResourceInterfacePortSettings(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, "system/interfaces/1%2F1%2F5"),
+		Steps: []resource.TestStep{
+			{
+				Config: testInterfacePortConfig(m, "1/1/5", `
+  mtu          = 9198
+  speed        = "10g"
+  fec          = "cl74"
+  flow_control = "rxtx"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_interface.test", "mtu", "9198"),
+					resource.TestCheckResourceAttr("aoscx_interface.test", "speed", "10g"),
+					resource.TestCheckResourceAttr("aoscx_interface.test", "autonegotiation", "true"),
+					testCheckMockAttr(m, "system/interfaces/1%2F1%2F5", "user_config", "map[fec:cl74 mtu:9198 pause:rxtx speeds:10000]"),
+				),
+			},
+			{
+				Config: testInterfacePortConfig(m, "1/1/5", `
+  speed           = "25g"
+  autonegotiation = false
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_interface.test", "mtu", "9198"),
+					resource.TestCheckResourceAttr("aoscx_interface.test", "fec", "cl74"),
+					resource.TestCheckResourceAttr("aoscx_interface.test", "flow_control", "rxtx"),
+					testCheckMockAttr(m, "system/interfaces/1%2F1%2F5", "user_config", "map[autoneg:off fec:cl74 mtu:9198 pause:rxtx speeds:25000]"),
+				),
+			},
+			{
+				Config: testInterfacePortConfig(m, "1/1/5", `
+  description     = "uplink"
+  speed           = "25g"
+  autonegotiation = false
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_interface.test", "description", "uplink"),
+					testCheckMockAttr(m, "system/interfaces/1%2F1%2F5", "user_config", "map[autoneg:off fec:cl74 mtu:9198 pause:rxtx speeds:25000]"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_interface.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					m.patch("system/interfaces/1%2F1%2F5", map[string]interface{}{"user_config": map[string]interface{}{"speeds": "10000"}})
+				},
+				Config: testInterfacePortConfig(m, "1/1/5", `
+  description     = "uplink"
+  speed           = "25g"
+  autonegotiation = false
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceInterfacePortCapabilities(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testInterfacePortConfig(m, "1/1/1", `
+  speed = "10g"
+`),
+				ExpectError: regexp.MustCompile(`interface 1/1/1: speed 10g is not supported by the port, supported speeds are 10m, 100m, 1g`),
+			},
+			{
+				Config: testInterfacePortConfig(m, "1/1/1", `
+  fec = "cl91"
+`),
+				ExpectError: regexp.MustCompile(`fec cl91 is not supported by the port`),
+			},
+			{
+				Config: testInterfacePortConfig(m, "1/1/5", `
+  energy_efficient_ethernet = true
+`),
+				ExpectError: regexp.MustCompile(`the port does not support energy efficient ethernet`),
+			},
+			{
+				Config: testInterfacePortConfig(m, "1/1/1", `
+  duplex = "half"
+`),
+				ExpectError: regexp.MustCompile(`half duplex needs a speed of 10m or 100m`),
+			},
+			{
+				Config: testInterfacePortConfig(m, "1/1/1", `
+  autonegotiation = false
+`),
+				ExpectError: regexp.MustCompile(`a speed is needed when autonegotiation is disabled`),
+			},
+			{
+				Config: testInterfacePortConfig(m, "1/1/1", `
+  speed                     = "100m"
+  duplex                    = "half"
+  energy_efficient_ethernet = true
+`),
+				Check: testCheckMockAttr(m, "system/interfaces/1%2F1%2F1", "user_config", "map[duplex:half eee:true speeds:100]"),
+			},
+		},
+	})
+}
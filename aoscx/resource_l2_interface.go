@@ -2,9 +2,11 @@ package aoscx
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/aruba/aoscxgo"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -17,8 +19,18 @@ func resourceL2Interface() *schema.Resource {
 		ReadContext:   resourceL2InterfaceRead,
 		UpdateContext: resourceL2InterfaceUpdate,
 		DeleteContext: resourceL2InterfaceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceL2InterfaceImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
+			"switch": switchSchema(),
 			"interface": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
@@ -77,7 +89,10 @@ func resourceL2InterfaceCreate(ctx context.Context, d *schema.ResourceData, m in
 	var diags diag.Diagnostics
 	var err error
 
-	sw := m.(*aoscxgo.Client)
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
 
 	tmp_int := aoscxgo.Interface{
 		Name:       d.Get("interface").(string),
@@ -87,20 +102,22 @@ func resourceL2InterfaceCreate(ctx context.Context, d *schema.ResourceData, m in
 	err = tmp_int.Create(sw)
 
 	if materialized := tmp_int.GetStatus(); !materialized {
+		create_err := err
 
 		err = tmp_int.Get(sw)
 
-		if err != nil {
-			{
-				diags = append(diags, diag.Errorf("Error in Creating Interface ", err)...)
-				return diags
+		if requestFailed(err) {
+			if requestFailed(create_err) {
+				err = create_err
 			}
+			diags = append(diags, errorDiagnostics("Error in Creating Interface", err, nil)...)
+			return diags
 		}
 
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Warning,
 			Summary:  "Interface Already Existing",
-			Detail:   string(tmp_int.Name),
+			Detail:   tmp_int.Name,
 		})
 
 		vlan_mode := d.Get("vlan_mode").(string)
@@ -125,19 +142,18 @@ func resourceL2InterfaceCreate(ctx context.Context, d *schema.ResourceData, m in
 
 		err = tmp_l2_int.Create(sw)
 
-		if err != nil {
-			if err.(*aoscxgo.RequestError).StatusCode == "404 Not Found" {
-				diags = append(diags, diag.Errorf("Error Updating Interface does not exist: %s", err.(*aoscxgo.RequestError).StatusCode)...)
-				return diags
-			} else if err.(*aoscxgo.RequestError).StatusCode != "204 No Content" {
-				diags = append(diags, diag.Errorf("Error in Updating Interface: %s", err.(*aoscxgo.RequestError).StatusCode)...)
+		if requestFailed(err) {
+			if isNotFound(err) {
+				diags = append(diags, errorDiagnostics("Error Updating Interface does not exist", err, cty.GetAttrPath("interface"))...)
 				return diags
 			}
+			diags = append(diags, errorDiagnostics("Error in Updating Interface", err, nil)...)
+			return diags
 		}
 
 	}
 
-	d.SetId(d.Get("interface").(string))
+	d.SetId(switchID(d, d.Get("interface").(string)))
 	d.Set("interface", d.Get("interface").(string))
 
 	resourceL2InterfaceRead(ctx, d, m)
@@ -149,7 +165,10 @@ func resourceL2InterfaceRead(ctx context.Context, d *schema.ResourceData, m inte
 	var diags diag.Diagnostics
 	var err error
 
-	sw := m.(*aoscxgo.Client)
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
 
 	// Retrieve Interface from sw if existing
 	tmp_int := aoscxgo.L2Interface{
@@ -159,8 +178,8 @@ func resourceL2InterfaceRead(ctx context.Context, d *schema.ResourceData, m inte
 
 	err = tmp_int.Get(sw)
 
-	if err != nil {
-		//Failure in Interface retrieval
+	if isNotFound(err) {
+		// Interface was removed outside of Terraform
 		d.SetId("")
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Warning,
@@ -168,6 +187,9 @@ func resourceL2InterfaceRead(ctx context.Context, d *schema.ResourceData, m inte
 			Detail:   "Interface Not Found",
 		})
 		return diags
+	} else if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving Interface", err, nil)...)
+		return diags
 	}
 
 	if tmp_int.VlanMode == "access" || tmp_int.VlanMode == "" {
@@ -199,7 +221,10 @@ func resourceL2InterfaceUpdate(ctx context.Context, d *schema.ResourceData, m in
 	var diags diag.Diagnostics
 	var err error
 
-	sw := m.(*aoscxgo.Client)
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
 
 	// Retrieve Interface from sw if existing
 	tmp_l2_int := aoscxgo.L2Interface{
@@ -209,6 +234,11 @@ func resourceL2InterfaceUpdate(ctx context.Context, d *schema.ResourceData, m in
 
 	err = tmp_l2_int.Get(sw)
 
+	if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving Interface", err, cty.GetAttrPath("interface"))...)
+		return diags
+	}
+
 	// Flag to determine if put should be used instead of patch
 	use_put := false
 
@@ -263,14 +293,13 @@ func resourceL2InterfaceUpdate(ctx context.Context, d *schema.ResourceData, m in
 
 	err = tmp_l2_int.Update(sw, use_put)
 
-	if err != nil {
-		if err.(*aoscxgo.RequestError).StatusCode == "404 Not Found" {
-			diags = append(diags, diag.Errorf("Error Updating Interface does not exist: %s", err.(*aoscxgo.RequestError).StatusCode)...)
-			return diags
-		} else if err.(*aoscxgo.RequestError).StatusCode != "204 No Content" {
-			diags = append(diags, diag.Errorf("Error in Updating Interface: %s", err.(*aoscxgo.RequestError).StatusCode)...)
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Updating Interface does not exist", err, cty.GetAttrPath("interface"))...)
 			return diags
 		}
+		diags = append(diags, errorDiagnostics("Error in Updating Interface", err, nil)...)
+		return diags
 	}
 
 	return resourceL2InterfaceRead(ctx, d, m)
@@ -280,7 +309,10 @@ func resourceL2InterfaceDelete(ctx context.Context, d *schema.ResourceData, m in
 	var diags diag.Diagnostics
 	var err error
 
-	sw := m.(*aoscxgo.Client)
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
 
 	// Create Interface Obj
 	tmp_int := aoscxgo.Interface{
@@ -289,16 +321,31 @@ func resourceL2InterfaceDelete(ctx context.Context, d *schema.ResourceData, m in
 
 	err = tmp_int.Delete(sw)
 
-	if err != nil {
-		if err.(*aoscxgo.RequestError).StatusCode == "404 Not Found" {
-			diags = append(diags, diag.Errorf("Error Updating Interface does not exist: %s", err.(*aoscxgo.RequestError).StatusCode)...)
-			return diags
-		} else if err.(*aoscxgo.RequestError).StatusCode != "204 No Content" {
-			diags = append(diags, diag.Errorf("Error in Updating Interface: %s ", err.(*aoscxgo.RequestError).StatusCode)...)
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Deleting Interface does not exist", err, cty.GetAttrPath("interface"))...)
 			return diags
 		}
+		diags = append(diags, errorDiagnostics("Error in Deleting Interface", err, nil)...)
+		return diags
 	}
 
 	d.SetId("")
 	return nil
 }
+
+func resourceL2InterfaceImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is the interface name optionally followed by @switch, e.g.
+	// terraform import aoscx_l2_interface.int_1_1_15 1/1/15
+	name, switch_name := parseSwitchID(d.Id())
+
+	if name == "" {
+		return nil, fmt.Errorf("Invalid L2 Interface import ID, expected an interface name such as 1/1/1")
+	}
+
+	d.Set("switch", switch_name)
+	d.Set("interface", name)
+	d.SetId(switchID(d, name))
+
+	return []*schema.ResourceData{d}, nil
+}
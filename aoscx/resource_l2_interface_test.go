@@ -0,0 +1,90 @@
+package aoscx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testL2InterfaceAccessConfig(m *mockSwitch, vlan_tag int) string {
+	return testProviderConfig(m) + fmt.Sprintf(`
+resource "aoscx_vlan" "test" {
+  for_each = toset(["10", "20", "30"])
+  vlan_id  = each.value
+  name     = "vlan${each.value}"
+}
+
+resource "aoscx_l2_interface" "test" {
+  interface   = "1/1/2"
+  description = "access port"
+  vlan_mode   = "access"
+  vlan_tag    = %d
+  depends_on  = [aoscx_vlan.test]
+}
+`, vlan_tag)
+}
+
+func testL2InterfaceTrunkConfig(m *mockSwitch) string {
+	return testProviderConfig(m) + `
+resource "aoscx_vlan" "test" {
+  for_each = toset(["10", "20", "30"])
+  vlan_id  = each.value
+  name     = "vlan${each.value}"
+}
+
+resource "aoscx_l2_interface" "test" {
+  interface       = "1/1/2"
+  description     = "trunk port"
+  vlan_mode       = "trunk"
+  native_vlan_tag = true
+  vlan_tag        = 10
+  vlan_ids        = [10, 20, 30]
+  depends_on      = [aoscx_vlan.test]
+}
+`
+}
+
+func TestResourceL2Interface(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, "system/interfaces/1%2F1%2F2"),
+		Steps: []resource.TestStep{
+			{
+				Config: testL2InterfaceAccessConfig(m, 10),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_l2_interface.test", "id", "1/1/2"),
+					resource.TestCheckResourceAttr("aoscx_l2_interface.test", "vlan_mode", "access"),
+					resource.TestCheckResourceAttr("aoscx_l2_interface.test", "vlan_tag", "10"),
+				),
+			},
+			{
+				Config: testL2InterfaceAccessConfig(m, 20),
+				Check:  resource.TestCheckResourceAttr("aoscx_l2_interface.test", "vlan_tag", "20"),
+			},
+			{
+				Config: testL2InterfaceTrunkConfig(m),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_l2_interface.test", "vlan_mode", "trunk"),
+					resource.TestCheckResourceAttr("aoscx_l2_interface.test", "vlan_ids.#", "3"),
+					resource.TestCheckResourceAttr("aoscx_l2_interface.test", "native_vlan_tag", "true"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_l2_interface.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					m.patch("system/interfaces/1%2F1%2F2", map[string]interface{}{"description": "changed on switch"})
+				},
+				Config:             testL2InterfaceTrunkConfig(m),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
@@ -2,10 +2,12 @@ package aoscx
 
 import (
 	"context"
+	"fmt"
 	"sort"
 
 	"github.com/aruba/aoscxgo"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -18,8 +20,19 @@ func resourceL3Interface() *schema.Resource {
 		ReadContext:   resourceL3InterfaceRead,
 		UpdateContext: resourceL3InterfaceUpdate,
 		DeleteContext: resourceL3InterfaceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceL3InterfaceImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
 
-		Schema: map[string]*schema.Schema{
+		CustomizeDiff: resourceL3InterfaceCustomizeDiff,
+		Schema: withIpSettingsSchema(map[string]*schema.Schema{
+			"switch": switchSchema(),
 			"interface": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
@@ -56,20 +69,29 @@ func resourceL3Interface() *schema.Resource {
 				Default:  nil,
 			},
 			"vrf": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: false,
-				Default:  "default",
-				Optional: true,
+				Type:        schema.TypeString,
+				Required:    false,
+				Default:     "default",
+				Optional:    true,
+				Description: "VRF the interface is attached to, reference aoscx_vrf.<name>.name so the VRF is created first",
 			},
-		},
+		}),
 	}
 }
 
+// resourceL3InterfaceCustomizeDiff checks the IP options of the interface.
+func resourceL3InterfaceCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	return checkIpSettings(d)
+}
+
 func resourceL3InterfaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	var err error
 
-	sw := m.(*aoscxgo.Client)
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
 
 	tmp_int := aoscxgo.Interface{
 		Name:       d.Get("interface").(string),
@@ -79,20 +101,22 @@ func resourceL3InterfaceCreate(ctx context.Context, d *schema.ResourceData, m in
 	err = tmp_int.Create(sw)
 
 	if materialized := tmp_int.GetStatus(); !materialized {
+		create_err := err
 
 		err = tmp_int.Get(sw)
 
-		if err != nil {
-			{
-				diags = append(diags, diag.Errorf("Error in Creating Interface ", err)...)
-				return diags
+		if requestFailed(err) {
+			if requestFailed(create_err) {
+				err = create_err
 			}
+			diags = append(diags, errorDiagnostics("Error in Creating Interface", err, nil)...)
+			return diags
 		}
 
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Warning,
 			Summary:  "Interface Already Existing",
-			Detail:   string(tmp_int.Name),
+			Detail:   tmp_int.Name,
 		})
 	}
 
@@ -125,13 +149,20 @@ func resourceL3InterfaceCreate(ctx context.Context, d *schema.ResourceData, m in
 
 		get_err := tmp_l3_int.Get(sw)
 
-		if get_err != nil {
-			diags = append(diags, diag.Errorf("Error in Creating L3 Interface: %s", err.(*aoscxgo.RequestError).StatusCode)...)
+		if requestFailed(get_err) {
+			if !requestFailed(err) {
+				err = get_err
+			}
+			diags = append(diags, errorDiagnostics("Error in Creating L3 Interface", err, nil)...)
 			return diags
 		}
 	}
 
-	d.SetId(d.Get("interface").(string))
+	if ip_diags := updateIpSettings(ctx, sw, d, tmp_l3_int.Interface.Name, "Error in Creating L3 Interface"); ip_diags.HasError() {
+		return append(diags, ip_diags...)
+	}
+
+	d.SetId(switchID(d, d.Get("interface").(string)))
 	d.Set("interface", d.Get("interface").(string))
 
 	resourceL3InterfaceRead(ctx, d, m)
@@ -143,7 +174,10 @@ func resourceL3InterfaceRead(ctx context.Context, d *schema.ResourceData, m inte
 	var diags diag.Diagnostics
 	var err error
 
-	sw := m.(*aoscxgo.Client)
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
 
 	// Retrieve Interface from sw if existing
 	tmp_int := aoscxgo.L3Interface{
@@ -152,8 +186,8 @@ func resourceL3InterfaceRead(ctx context.Context, d *schema.ResourceData, m inte
 		}}
 	err = tmp_int.Get(sw)
 
-	if err != nil {
-		//Failure in L3Interface retrieval
+	if isNotFound(err) {
+		// Interface was removed outside of Terraform
 		d.SetId("")
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Warning,
@@ -161,6 +195,9 @@ func resourceL3InterfaceRead(ctx context.Context, d *schema.ResourceData, m inte
 			Detail:   "Interface Not Found",
 		})
 		return diags
+	} else if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving Interface", err, nil)...)
+		return diags
 	}
 
 	d.Set("interface", tmp_int.Interface.Name)
@@ -172,6 +209,14 @@ func resourceL3InterfaceRead(ctx context.Context, d *schema.ResourceData, m inte
 	d.Set("ipv6", tmp_int.Ipv6)
 	d.Set("vrf", tmp_int.Vrf)
 
+	tmp_settings := ipSettings{}
+	err = tmp_settings.Get(ctx, sw, tmp_int.Interface.Name)
+	if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving Interface", err, nil)...)
+		return diags
+	}
+	setIpSettings(d, tmp_settings)
+
 	return diags
 }
 
@@ -180,7 +225,10 @@ func resourceL3InterfaceUpdate(ctx context.Context, d *schema.ResourceData, m in
 	var diags diag.Diagnostics
 	var err error
 
-	sw := m.(*aoscxgo.Client)
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
 
 	// Retrieve Interface from sw if existing
 	tmp_l3_int := aoscxgo.L3Interface{
@@ -191,11 +239,9 @@ func resourceL3InterfaceUpdate(ctx context.Context, d *schema.ResourceData, m in
 	//tmp_vlan.GetStatus() will return if existing
 	err = tmp_l3_int.Get(sw)
 
-	if err != nil {
-		{
-			diags = append(diags, diag.Errorf("Error in Retrieving Interface ", err)...)
-			return diags
-		}
+	if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving Interface", err, cty.GetAttrPath("interface"))...)
+		return diags
 	}
 
 	// Flag to determine if put should be used instead of patch
@@ -246,14 +292,17 @@ func resourceL3InterfaceUpdate(ctx context.Context, d *schema.ResourceData, m in
 
 	err = tmp_l3_int.Update(sw, use_put)
 
-	if err != nil {
-		if err.(*aoscxgo.RequestError).StatusCode == "404 Not Found" {
-			diags = append(diags, diag.Errorf("Error Updating Interface does not exist: %s", err.(*aoscxgo.RequestError).StatusCode)...)
-			return diags
-		} else if err.(*aoscxgo.RequestError).StatusCode != "204 No Content" {
-			diags = append(diags, diag.Errorf("Error in Updating Interface: %s", err.(*aoscxgo.RequestError).StatusCode)...)
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Updating Interface does not exist", err, cty.GetAttrPath("interface"))...)
 			return diags
 		}
+		diags = append(diags, errorDiagnostics("Error in Updating Interface", err, nil)...)
+		return diags
+	}
+
+	if ip_diags := updateIpSettings(ctx, sw, d, tmp_l3_int.Interface.Name, "Error in Updating Interface"); ip_diags.HasError() {
+		return append(diags, ip_diags...)
 	}
 
 	return resourceL3InterfaceRead(ctx, d, m)
@@ -264,25 +313,51 @@ func resourceL3InterfaceDelete(ctx context.Context, d *schema.ResourceData, m in
 	var diags diag.Diagnostics
 	var err error
 
-	sw := m.(*aoscxgo.Client)
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
 
 	// Delete Interface Obj
 	tmp_int := aoscxgo.Interface{
 		Name: d.Get("interface").(string),
 	}
 
+	// DHCP relay entries reference the interface, remove them first
+	tmp_settings := ipSettings{}
+	err = tmp_settings.Delete(ctx, sw, tmp_int.Name)
+	if requestFailed(err) && !isNotFound(err) {
+		diags = append(diags, errorDiagnostics("Error in Deleting Interface", err, nil)...)
+		return diags
+	}
+
 	err = tmp_int.Delete(sw)
 
-	if err != nil {
-		if err.(*aoscxgo.RequestError).StatusCode == "404 Not Found" {
-			diags = append(diags, diag.Errorf("Error Deleting Interface does not exist: %s", err.(*aoscxgo.RequestError).StatusCode)...)
-			return diags
-		} else if err.(*aoscxgo.RequestError).StatusCode != "204 No Content" {
-			diags = append(diags, diag.Errorf("Error in Deleting Interface: %s ", err.(*aoscxgo.RequestError).StatusCode)...)
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Deleting Interface does not exist", err, cty.GetAttrPath("interface"))...)
 			return diags
 		}
+		diags = append(diags, errorDiagnostics("Error in Deleting Interface", err, nil)...)
+		return diags
 	}
 
 	d.SetId("")
 	return nil
 }
+
+func resourceL3InterfaceImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is the interface name optionally followed by @switch, e.g.
+	// terraform import aoscx_l3_interface.int_1_1_20 1/1/20
+	name, switch_name := parseSwitchID(d.Id())
+
+	if name == "" {
+		return nil, fmt.Errorf("Invalid L3 Interface import ID, expected an interface name such as 1/1/1")
+	}
+
+	d.Set("switch", switch_name)
+	d.Set("interface", name)
+	d.SetId(switchID(d, name))
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,96 @@
+package aoscx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testL3InterfaceConfig(m *mockSwitch, ipv4 string) string {
+	return testProviderConfig(m) + fmt.Sprintf(`
+resource "aoscx_l3_interface" "test" {
+  interface   = "1/1/3"
+  description = "routed uplink"
+  ipv4        = [%q]
+  ipv6        = ["2001:db8::1/64"]
+  vrf         = "default"
+}
+`, ipv4)
+}
+
+func TestResourceL3Interface(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, "system/interfaces/1%2F1%2F3"),
+		Steps: []resource.TestStep{
+			{
+				Config: testL3InterfaceConfig(m, "10.0.0.1/31"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_l3_interface.test", "id", "1/1/3"),
+					resource.TestCheckResourceAttr("aoscx_l3_interface.test", "ipv4.0", "10.0.0.1/31"),
+					resource.TestCheckResourceAttr("aoscx_l3_interface.test", "ipv6.#", "1"),
+					resource.TestCheckResourceAttr("aoscx_l3_interface.test", "vrf", "default"),
+				),
+			},
+			{
+				Config: testL3InterfaceConfig(m, "10.0.0.3/31"),
+				Check:  resource.TestCheckResourceAttr("aoscx_l3_interface.test", "ipv4.0", "10.0.0.3/31"),
+			},
+			{
+				ResourceName:      "aoscx_l3_interface.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					m.patch("system/interfaces/1%2F1%2F3", map[string]interface{}{"description": "changed on switch"})
+				},
+				Config:             testL3InterfaceConfig(m, "10.0.0.3/31"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+const testL3InterfaceIpSettings = `
+resource "aoscx_l3_interface" "test" {
+  interface = "1/1/3"
+  ipv4      = ["10.0.3.1/24"]
+  ip_mtu    = 9000
+  proxy_arp = true
+
+  dhcp_relay_servers {
+    addresses = ["10.0.0.10", "10.0.0.11"]
+  }
+}
+`
+
+func TestResourceL3InterfaceIpSettings(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, "system/dhcp_relays/1%2F1%2F3,default"),
+		Steps: []resource.TestStep{
+			{
+				Config: testProviderConfig(m) + testL3InterfaceIpSettings,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_l3_interface.test", "ip_mtu", "9000"),
+					resource.TestCheckResourceAttr("aoscx_l3_interface.test", "proxy_arp", "true"),
+					resource.TestCheckResourceAttr("aoscx_l3_interface.test", "dhcp_relay_servers.#", "1"),
+					testCheckMockAttr(m, "system/interfaces/1%2F1%2F3", "ip_mtu", "9000"),
+					testCheckMockAttr(m, "system/dhcp_relays/1%2F1%2F3,default", "ipv4_ucast_server", "[10.0.0.10 10.0.0.11]"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_l3_interface.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,363 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceLag() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Resource to configure link aggregation (LAG) interfaces on AOS-CX switches.",
+		CreateContext: resourceLagCreate,
+		ReadContext:   resourceLagRead,
+		UpdateContext: resourceLagUpdate,
+		DeleteContext: resourceLagDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceLagImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		CustomizeDiff: resourceLagCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"switch": switchSchema(),
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^lag[1-9][0-9]*$`), "expected a LAG name such as lag1"),
+				Description:  "Name of the LAG, e.g. lag1",
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"admin_state": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "up",
+				ValidateFunc: validation.StringInSlice([]string{"up", "down"}, true),
+			},
+			"members": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Physical interfaces aggregated by the LAG, e.g. 1/1/1",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"lacp_mode": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "active",
+				ValidateFunc: validation.StringInSlice([]string{"active", "passive", "static"}, false),
+				Description:  "LACP mode, either active, passive or static for a LAG without LACP",
+			},
+			"lacp_rate": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "slow",
+				ValidateFunc: validation.StringInSlice([]string{"slow", "fast"}, false),
+				Description:  "Rate LACPDUs are requested from the peer, either slow or fast",
+			},
+			"lacp_fallback": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Keep forwarding on a single member when the peer does not run LACP",
+			},
+			"hash": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "l3-src-dst",
+				ValidateFunc: validation.StringInSlice([]string{"l2-src-dst", "l3-src-dst", "l4-src-dst"}, false),
+				Description:  "Hash algorithm balancing traffic over the members",
+			},
+			"multi_chassis": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Create a multi-chassis LAG spanning both switches of a VSX pair, configure it with the same name on both switches",
+			},
+			"routing": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Configure the LAG as a routed (L3) interface using ipv4, ipv6 and vrf instead of the VLAN settings",
+			},
+			"vlan_mode": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "access",
+				ValidateFunc: validation.StringInSlice([]string{"access", "trunk"}, true),
+			},
+			"vlan_tag": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  1,
+			},
+			"vlan_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"trunk_allowed_all": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"native_vlan_tag": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"ipv4": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ipv6": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"vrf": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "default",
+				Description: "VRF the interface is attached to, reference aoscx_vrf.<name>.name so the VRF is created first",
+			},
+		},
+	}
+}
+
+// resourceLagCustomizeDiff rejects members that are not physical ports and
+// settings of the mode the LAG is not configured for.
+func resourceLagCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	for _, member := range d.Get("members").(*schema.Set).List() {
+		if strings.HasPrefix(member.(string), "lag") || strings.HasPrefix(member.(string), "vlan") {
+			return fmt.Errorf("LAG member %s is not a physical interface", member)
+		}
+	}
+
+	if d.Get("routing").(bool) {
+		if d.Get("vlan_ids").(*schema.Set).Len() > 0 || d.Get("vlan_mode").(string) != "access" {
+			return fmt.Errorf("vlan_mode and vlan_ids cannot be set on a LAG with routing enabled")
+		}
+		return nil
+	}
+
+	if len(d.Get("ipv4").([]interface{})) > 0 || d.Get("ipv6").(*schema.Set).Len() > 0 {
+		return fmt.Errorf("ipv4 and ipv6 can only be set on a LAG with routing enabled")
+	}
+
+	if strings.EqualFold(d.Get("vlan_mode").(string), "trunk") && !d.Get("trunk_allowed_all").(bool) && d.Get("vlan_ids").(*schema.Set).Len() == 0 {
+		return fmt.Errorf("a trunk LAG needs vlan_ids or trunk_allowed_all")
+	}
+
+	if d.Get("trunk_allowed_all").(bool) && d.Get("vlan_ids").(*schema.Set).Len() > 0 {
+		return fmt.Errorf("vlan_ids cannot be set together with trunk_allowed_all")
+	}
+
+	return nil
+}
+
+// lagFromResourceData builds the LAG described by the configuration of d.
+func lagFromResourceData(d *schema.ResourceData) lag {
+	tmp_lag := lag{
+		Name:            d.Get("name").(string),
+		Description:     d.Get("description").(string),
+		AdminState:      strings.ToLower(d.Get("admin_state").(string)),
+		Members:         sortedStrings(d.Get("members").(*schema.Set)),
+		LacpMode:        d.Get("lacp_mode").(string),
+		LacpRate:        d.Get("lacp_rate").(string),
+		Fallback:        d.Get("lacp_fallback").(bool),
+		Hash:            d.Get("hash").(string),
+		MultiChassis:    d.Get("multi_chassis").(bool),
+		Routing:         d.Get("routing").(bool),
+		VlanMode:        strings.ToLower(d.Get("vlan_mode").(string)),
+		NativeVlanTag:   d.Get("native_vlan_tag").(bool),
+		VlanTag:         d.Get("vlan_tag").(int),
+		TrunkAllowedAll: d.Get("trunk_allowed_all").(bool),
+		Ipv6:            sortedStrings(d.Get("ipv6").(*schema.Set)),
+		Vrf:             d.Get("vrf").(string),
+	}
+
+	for _, vlan_id := range d.Get("vlan_ids").(*schema.Set).List() {
+		tmp_lag.VlanIds = append(tmp_lag.VlanIds, vlan_id.(int))
+	}
+	sort.Ints(tmp_lag.VlanIds)
+
+	for _, address := range d.Get("ipv4").([]interface{}) {
+		tmp_lag.Ipv4 = append(tmp_lag.Ipv4, address.(string))
+	}
+
+	return tmp_lag
+}
+
+func resourceLagCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_lag := lagFromResourceData(d)
+
+	created, err := tmp_lag.Create(ctx, sw)
+
+	if requestFailed(err) {
+		// A LAG created without all of its addresses is kept in state so
+		// it is tainted and replaced on the next apply
+		if created {
+			d.SetId(switchID(d, tmp_lag.Name))
+		}
+		diags = append(diags, errorDiagnostics("Error in Creating LAG", err, cty.GetAttrPath("name"))...)
+		return diags
+	}
+
+	d.SetId(switchID(d, tmp_lag.Name))
+
+	return resourceLagRead(ctx, d, m)
+}
+
+func resourceLagRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Retrieve LAG from sw if existing
+	tmp_lag := lag{
+		Name: d.Get("name").(string),
+	}
+
+	err = tmp_lag.Get(ctx, sw)
+
+	if isNotFound(err) {
+		// LAG was removed outside of Terraform
+		d.SetId("")
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "LAG Not Found",
+			Detail:   tmp_lag.Name,
+		})
+		return diags
+	} else if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving LAG", err, nil)...)
+		return diags
+	}
+
+	d.Set("name", tmp_lag.Name)
+	d.Set("description", tmp_lag.Description)
+	d.Set("admin_state", tmp_lag.AdminState)
+	d.Set("members", tmp_lag.Members)
+	d.Set("lacp_mode", tmp_lag.LacpMode)
+	d.Set("lacp_rate", tmp_lag.LacpRate)
+	d.Set("lacp_fallback", tmp_lag.Fallback)
+	d.Set("hash", tmp_lag.Hash)
+	d.Set("multi_chassis", tmp_lag.MultiChassis)
+	d.Set("routing", tmp_lag.Routing)
+	d.Set("vlan_mode", tmp_lag.VlanMode)
+	d.Set("native_vlan_tag", tmp_lag.NativeVlanTag)
+	d.Set("vlan_tag", tmp_lag.VlanTag)
+	d.Set("vlan_ids", tmp_lag.VlanIds)
+	d.Set("trunk_allowed_all", tmp_lag.TrunkAllowedAll)
+	d.Set("ipv4", tmp_lag.Ipv4)
+	d.Set("ipv6", tmp_lag.Ipv6)
+	d.Set("vrf", tmp_lag.Vrf)
+
+	return diags
+}
+
+func resourceLagUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_lag := lagFromResourceData(d)
+
+	err = tmp_lag.Update(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Updating LAG does not exist", err, cty.GetAttrPath("name"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Updating LAG", err, nil)...)
+		return diags
+	}
+
+	return resourceLagRead(ctx, d, m)
+}
+
+func resourceLagDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_lag := lag{
+		Name: d.Get("name").(string),
+	}
+
+	err = tmp_lag.Delete(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Deleting LAG does not exist", err, cty.GetAttrPath("name"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Deleting LAG", err, nil)...)
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceLagImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is the LAG name optionally followed by @switch, e.g.
+	// terraform import aoscx_lag.lag1 lag1
+	name, switch_name := parseSwitchID(d.Id())
+
+	if !strings.HasPrefix(name, "lag") {
+		return nil, fmt.Errorf("Invalid LAG import ID %q, expected a LAG name such as lag1", d.Id())
+	}
+
+	d.Set("switch", switch_name)
+	d.SetId(switchID(d, name))
+	d.Set("name", name)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,192 @@
+package aoscx
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testLagConfig(m *mockSwitch, body string) string {
+	return testProviderConfig(m) + `
+resource "aoscx_vlan" "test" {
+  for_each = toset(["10", "20"])
+  vlan_id  = each.value
+  name     = "vlan${each.value}"
+}
+
+resource "aoscx_lag" "test" {
+  name       = "lag1"
+  depends_on = [aoscx_vlan.test]
+` + body + `
+}
+`
+}
+
+const testLagAccess = `
+  description = "server bond"
+  members     = ["1/1/1", "1/1/2"]
+  vlan_tag    = 10
+`
+
+const testLagTrunk = `
+  description   = "uplink"
+  members       = ["1/1/1", "1/1/2", "1/1/3"]
+  lacp_mode     = "passive"
+  lacp_rate     = "fast"
+  lacp_fallback = true
+  hash          = "l4-src-dst"
+  vlan_mode     = "trunk"
+  vlan_tag      = 10
+  vlan_ids      = [10, 20]
+`
+
+const testLagRouted = `
+  members   = ["1/1/1"]
+  lacp_mode = "static"
+  routing   = true
+  ipv4      = ["10.0.0.1/31", "10.1.0.1/24"]
+  ipv6      = ["2001:db8::1/64"]
+`
+
+func TestResourceLag(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, "system/interfaces/lag1"),
+		Steps: []resource.TestStep{
+			{
+				Config: testLagConfig(m, testLagAccess),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_lag.test", "id", "lag1"),
+					resource.TestCheckResourceAttr("aoscx_lag.test", "members.#", "2"),
+					resource.TestCheckResourceAttr("aoscx_lag.test", "lacp_mode", "active"),
+					resource.TestCheckResourceAttr("aoscx_lag.test", "vlan_mode", "access"),
+					resource.TestCheckResourceAttr("aoscx_lag.test", "vlan_tag", "10"),
+					testCheckMockExists(m, "system/interfaces/lag1"),
+				),
+			},
+			{
+				Config: testLagConfig(m, testLagTrunk),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_lag.test", "members.#", "3"),
+					resource.TestCheckResourceAttr("aoscx_lag.test", "lacp_mode", "passive"),
+					resource.TestCheckResourceAttr("aoscx_lag.test", "lacp_rate", "fast"),
+					resource.TestCheckResourceAttr("aoscx_lag.test", "lacp_fallback", "true"),
+					resource.TestCheckResourceAttr("aoscx_lag.test", "hash", "l4-src-dst"),
+					resource.TestCheckResourceAttr("aoscx_lag.test", "vlan_mode", "trunk"),
+					resource.TestCheckResourceAttr("aoscx_lag.test", "vlan_ids.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_lag.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testLagConfig(m, testLagRouted),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_lag.test", "lacp_mode", "static"),
+					resource.TestCheckResourceAttr("aoscx_lag.test", "routing", "true"),
+					resource.TestCheckResourceAttr("aoscx_lag.test", "ipv4.0", "10.0.0.1/31"),
+					resource.TestCheckResourceAttr("aoscx_lag.test", "ipv4.1", "10.1.0.1/24"),
+					resource.TestCheckResourceAttr("aoscx_lag.test", "ipv6.#", "1"),
+					resource.TestCheckResourceAttr("aoscx_lag.test", "vlan_ids.#", "0"),
+					testCheckMockExists(m, "system/interfaces/lag1/ip6_addresses/2001:db8::1%2F64"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_lag.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					m.patch("system/interfaces/lag1", map[string]interface{}{"lacp": "active"})
+				},
+				Config:             testLagConfig(m, testLagRouted),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testLagConfig(m, testLagRouted),
+			},
+			{
+				PreConfig: func() {
+					m.remove("system/interfaces/lag1")
+				},
+				Config:             testLagConfig(m, testLagRouted),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceLagMultiChassis(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, "system/interfaces/lag1"),
+		Steps: []resource.TestStep{
+			{
+				Config: testLagConfig(m, testLagAccess+`
+  multi_chassis = true
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_lag.test", "multi_chassis", "true"),
+					func(s *terraform.State) error {
+						other_config, _ := m.get("system/interfaces/lag1")["other_config"].(map[string]interface{})
+						if other_config["mclag_enabled"] != "true" {
+							return fmt.Errorf("expected mclag_enabled true, got %v", other_config["mclag_enabled"])
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "aoscx_lag.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					m.patch("system/interfaces/lag1", map[string]interface{}{
+						"other_config": map[string]interface{}{"mclag_enabled": "false"},
+					})
+				},
+				Config: testLagConfig(m, testLagAccess+`
+  multi_chassis = true
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceLagInvalid(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testLagConfig(m, `ipv4 = ["10.0.0.1/31"]`),
+				ExpectError: regexp.MustCompile(`only be set on a LAG with routing enabled`),
+			},
+			{
+				Config:      testLagConfig(m, `vlan_mode = "trunk"`),
+				ExpectError: regexp.MustCompile(`needs vlan_ids or trunk_allowed_all`),
+			},
+			{
+				Config:      testLagConfig(m, `members = ["lag2"]`),
+				ExpectError: regexp.MustCompile(`not a physical interface`),
+			},
+		},
+	})
+}
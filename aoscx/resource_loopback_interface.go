@@ -0,0 +1,228 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceLoopbackInterface() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Resource to configure loopback interfaces on AOS-CX switches.",
+		CreateContext: resourceLoopbackInterfaceCreate,
+		ReadContext:   resourceLoopbackInterfaceRead,
+		UpdateContext: resourceLoopbackInterfaceUpdate,
+		DeleteContext: resourceLoopbackInterfaceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceLoopbackInterfaceImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"switch": switchSchema(),
+			"loopback_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(0, 255),
+				Description:  "Number of the loopback, e.g. 0 for loopback0",
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"admin_state": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "up",
+				ValidateFunc: validation.StringInSlice([]string{"up", "down"}, true),
+			},
+			"ipv4": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "IPv4 addresses in CIDR notation, e.g. 10.255.0.1/32. The first address is the primary address",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.All(validation.IsCIDR, validation.StringDoesNotContainAny(":")),
+				},
+			},
+			"ipv6": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "IPv6 addresses in CIDR notation, e.g. 2001:db8::1/128",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.All(validation.IsCIDR, validation.StringMatch(regexp.MustCompile(`:`), "expected an IPv6 address")),
+				},
+			},
+			"vrf": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "default",
+				Description: "VRF the loopback is attached to, reference aoscx_vrf.<name>.name so the VRF is created first",
+			},
+		},
+	}
+}
+
+func loopbackInterfaceFromResourceData(d *schema.ResourceData) loopbackInterface {
+	tmp_loopback := loopbackInterface{
+		Id:          d.Get("loopback_id").(int),
+		Description: d.Get("description").(string),
+		AdminState:  strings.ToLower(d.Get("admin_state").(string)),
+		Ipv4:        []string{},
+		Ipv6:        sortedStrings(d.Get("ipv6").(*schema.Set)),
+		Vrf:         d.Get("vrf").(string),
+	}
+
+	for _, address := range d.Get("ipv4").([]interface{}) {
+		tmp_loopback.Ipv4 = append(tmp_loopback.Ipv4, address.(string))
+	}
+
+	return tmp_loopback
+}
+
+func resourceLoopbackInterfaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_loopback := loopbackInterfaceFromResourceData(d)
+
+	created, err := tmp_loopback.Create(ctx, sw)
+
+	if requestFailed(err) {
+		// A loopback created without all of its addresses is kept in state
+		// so it is tainted and replaced on the next apply
+		if created {
+			d.SetId(switchID(d, loopbackName(tmp_loopback.Id)))
+		}
+		diags = append(diags, errorDiagnostics("Error in Creating Loopback Interface", err, cty.GetAttrPath("loopback_id"))...)
+		return diags
+	}
+
+	d.SetId(switchID(d, loopbackName(tmp_loopback.Id)))
+
+	return resourceLoopbackInterfaceRead(ctx, d, m)
+}
+
+func resourceLoopbackInterfaceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Retrieve loopback from sw if existing
+	tmp_loopback := loopbackInterface{
+		Id: d.Get("loopback_id").(int),
+	}
+
+	err = tmp_loopback.Get(ctx, sw)
+
+	if isNotFound(err) {
+		// Loopback was removed outside of Terraform
+		d.SetId("")
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Loopback Interface Not Found",
+			Detail:   loopbackName(tmp_loopback.Id),
+		})
+		return diags
+	} else if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving Loopback Interface", err, nil)...)
+		return diags
+	}
+
+	d.Set("description", tmp_loopback.Description)
+	d.Set("admin_state", tmp_loopback.AdminState)
+	d.Set("ipv4", tmp_loopback.Ipv4)
+	d.Set("ipv6", tmp_loopback.Ipv6)
+	d.Set("vrf", tmp_loopback.Vrf)
+
+	return diags
+}
+
+func resourceLoopbackInterfaceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_loopback := loopbackInterfaceFromResourceData(d)
+
+	err = tmp_loopback.Update(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Updating Loopback Interface does not exist", err, cty.GetAttrPath("loopback_id"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Updating Loopback Interface", err, nil)...)
+		return diags
+	}
+
+	return resourceLoopbackInterfaceRead(ctx, d, m)
+}
+
+func resourceLoopbackInterfaceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_loopback := loopbackInterfaceFromResourceData(d)
+
+	err = tmp_loopback.Delete(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Deleting Loopback Interface does not exist", err, cty.GetAttrPath("loopback_id"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Deleting Loopback Interface", err, nil)...)
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceLoopbackInterfaceImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is the loopback name optionally followed by @switch, e.g.
+	// terraform import aoscx_loopback_interface.loopback0 loopback0
+	name, switch_name := parseSwitchID(d.Id())
+	id, err := strconv.Atoi(strings.TrimPrefix(name, "loopback"))
+	if !strings.HasPrefix(name, "loopback") || err != nil || id < 0 || id > 255 {
+		return nil, fmt.Errorf("Invalid loopback import ID %q, expected a loopback name such as loopback0", d.Id())
+	}
+
+	d.Set("switch", switch_name)
+	d.Set("loopback_id", id)
+	d.SetId(switchID(d, loopbackName(id)))
+
+	return []*schema.ResourceData{d}, nil
+}
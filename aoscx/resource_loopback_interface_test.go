@@ -0,0 +1,111 @@
+package aoscx
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testLoopbackInterfaceConfig(m *mockSwitch, body string) string {
+	return testProviderConfig(m) + `
+resource "aoscx_vrf" "test" {
+  name = "blue"
+}
+
+resource "aoscx_loopback_interface" "test" {
+  loopback_id = 0
+` + body + `
+}
+`
+}
+
+const testLoopbackInterface = `
+  description = "router-id"
+  ipv4        = ["10.255.0.1/32"]
+  ipv6        = ["2001:db8::1/128"]
+`
+
+const testLoopbackInterfaceVrf = `
+  admin_state = "down"
+  ipv4        = ["10.255.1.1/32", "10.255.2.1/32"]
+  ipv6        = ["2001:db8::2/128"]
+  vrf         = aoscx_vrf.test.name
+`
+
+func TestResourceLoopbackInterface(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, "system/interfaces/loopback0"),
+		Steps: []resource.TestStep{
+			{
+				Config: testLoopbackInterfaceConfig(m, testLoopbackInterface),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_loopback_interface.test", "id", "loopback0"),
+					resource.TestCheckResourceAttr("aoscx_loopback_interface.test", "admin_state", "up"),
+					resource.TestCheckResourceAttr("aoscx_loopback_interface.test", "vrf", "default"),
+					testCheckMockAttr(m, "system/interfaces/loopback0", "type", "loopback"),
+					testCheckMockAttr(m, "system/interfaces/loopback0", "ip4_address", "10.255.0.1/32"),
+					testCheckMockExists(m, "system/interfaces/loopback0/ip6_addresses/2001:db8::1%2F128"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_loopback_interface.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testLoopbackInterfaceConfig(m, testLoopbackInterfaceVrf),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_loopback_interface.test", "admin_state", "down"),
+					resource.TestCheckResourceAttr("aoscx_loopback_interface.test", "description", ""),
+					resource.TestCheckResourceAttr("aoscx_loopback_interface.test", "ipv4.1", "10.255.2.1/32"),
+					resource.TestCheckResourceAttr("aoscx_loopback_interface.test", "vrf", "blue"),
+					testCheckMockAttr(m, "system/interfaces/loopback0", "ip4_address_secondary", "[10.255.2.1/32]"),
+					testCheckMockDestroyed(m, "system/interfaces/loopback0/ip6_addresses/2001:db8::1%2F128"),
+					testCheckMockExists(m, "system/interfaces/loopback0/ip6_addresses/2001:db8::2%2F128"),
+				),
+			},
+			{
+				PreConfig: func() {
+					m.patch("system/interfaces/loopback0", map[string]interface{}{"ip4_address": "10.255.9.1/32"})
+				},
+				Config:             testLoopbackInterfaceConfig(m, testLoopbackInterfaceVrf),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testLoopbackInterfaceConfig(m, testLoopbackInterfaceVrf),
+				Check:  testCheckMockAttr(m, "system/interfaces/loopback0", "ip4_address", "10.255.1.1/32"),
+			},
+			{
+				PreConfig: func() {
+					m.remove("system/interfaces/loopback0")
+				},
+				Config:             testLoopbackInterfaceConfig(m, testLoopbackInterfaceVrf),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceLoopbackInterfaceValidation(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testLoopbackInterfaceConfig(m, `ipv4 = ["2001:db8::1/128"]`),
+				ExpectError: regexp.MustCompile(`to not contain any of`),
+			},
+			{
+				Config:      testLoopbackInterfaceConfig(m, `ipv6 = ["10.255.0.1/32"]`),
+				ExpectError: regexp.MustCompile(`expected an IPv6 address`),
+			},
+		},
+	})
+}
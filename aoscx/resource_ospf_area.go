@@ -0,0 +1,225 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ospfBackbone is the ID of the OSPF backbone area.
+const ospfBackbone = "0.0.0.0"
+
+func resourceOspfArea() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Resource to configure areas of OSPF routers on AOS-CX switches.",
+		CreateContext: resourceOspfAreaCreate,
+		ReadContext:   resourceOspfAreaRead,
+		UpdateContext: resourceOspfAreaUpdate,
+		DeleteContext: resourceOspfAreaDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceOspfAreaImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		CustomizeDiff: resourceOspfAreaCustomizeDiff,
+		Schema: ospfRouterSchema(map[string]*schema.Schema{
+			"area_id": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsIPv4Address,
+				Description:  "Area ID in dotted decimal notation, e.g. 0.0.0.0 for the backbone",
+			},
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "default",
+				ValidateFunc: validation.StringInSlice([]string{"default", "stub", "nssa"}, false),
+				Description:  "Type of the area, either default, stub or nssa",
+			},
+			"no_summary": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Don't send summary LSAs into a stub or NSSA area, making it totally stubby",
+			},
+			"default_cost": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntBetween(0, 16777215),
+				Description:  "Cost of the default route advertised into a stub or NSSA area",
+			},
+		}),
+	}
+}
+
+// resourceOspfAreaCustomizeDiff checks the stub and NSSA options.
+func resourceOspfAreaCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	area_type := d.Get("type").(string)
+
+	if area_type != "default" && d.Get("area_id").(string) == ospfBackbone {
+		return fmt.Errorf("the backbone area %s cannot be a %s area", ospfBackbone, area_type)
+	}
+	if area_type == "default" && d.Get("no_summary").(bool) {
+		return fmt.Errorf("no_summary can only be set on stub and nssa areas")
+	}
+
+	return nil
+}
+
+func ospfAreaFromResourceData(d *schema.ResourceData) ospfArea {
+	return ospfArea{
+		Vrf:         d.Get("vrf").(string),
+		Version:     d.Get("version").(int),
+		InstanceId:  d.Get("instance_id").(int),
+		AreaId:      d.Get("area_id").(string),
+		Type:        d.Get("type").(string),
+		NoSummary:   d.Get("no_summary").(bool),
+		DefaultCost: d.Get("default_cost").(int),
+	}
+}
+
+func resourceOspfAreaCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_area := ospfAreaFromResourceData(d)
+
+	err = tmp_area.Create(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Creating OSPF Area OSPF router does not exist", err, cty.GetAttrPath("instance_id"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Creating OSPF Area", err, cty.GetAttrPath("area_id"))...)
+		return diags
+	}
+
+	d.SetId(switchID(d, ospfRouterID(d)+","+tmp_area.AreaId))
+
+	return resourceOspfAreaRead(ctx, d, m)
+}
+
+func resourceOspfAreaRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Retrieve OSPF area from sw if existing
+	tmp_area := ospfArea{
+		Vrf:        d.Get("vrf").(string),
+		Version:    d.Get("version").(int),
+		InstanceId: d.Get("instance_id").(int),
+		AreaId:     d.Get("area_id").(string),
+	}
+
+	err = tmp_area.Get(ctx, sw)
+
+	if isNotFound(err) {
+		// OSPF area was removed outside of Terraform
+		d.SetId("")
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "OSPF Area Not Found",
+			Detail:   ospfRouterID(d) + "," + tmp_area.AreaId,
+		})
+		return diags
+	} else if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving OSPF Area", err, nil)...)
+		return diags
+	}
+
+	d.Set("type", tmp_area.Type)
+	d.Set("no_summary", tmp_area.NoSummary)
+	d.Set("default_cost", tmp_area.DefaultCost)
+
+	return diags
+}
+
+func resourceOspfAreaUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_area := ospfAreaFromResourceData(d)
+
+	err = tmp_area.Update(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Updating OSPF Area does not exist", err, cty.GetAttrPath("area_id"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Updating OSPF Area", err, nil)...)
+		return diags
+	}
+
+	return resourceOspfAreaRead(ctx, d, m)
+}
+
+func resourceOspfAreaDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_area := ospfAreaFromResourceData(d)
+
+	err = tmp_area.Delete(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Deleting OSPF Area does not exist", err, cty.GetAttrPath("area_id"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Deleting OSPF Area", err, nil)...)
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceOspfAreaImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is the ID of the OSPF router followed by the area ID,
+	// optionally followed by @switch, e.g.
+	// terraform import aoscx_ospf_area.backbone default,ospfv2,1,0.0.0.0
+	fields, err := parseOspfImportID(d, "<vrf>,<ospfv2 or ospfv3>,<instance ID>,<area ID>", 1)
+	if err != nil {
+		return nil, err
+	}
+	if _, errs := validation.IsIPv4Address(fields[0], "area_id"); len(errs) > 0 {
+		return nil, fmt.Errorf("Invalid OSPF Area import ID %q: %v", d.Id(), errs[0])
+	}
+
+	d.Set("area_id", fields[0])
+	d.SetId(switchID(d, ospfRouterID(d)+","+fields[0]))
+
+	return []*schema.ResourceData{d}, nil
+}
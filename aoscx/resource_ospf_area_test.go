@@ -0,0 +1,109 @@
+package aoscx
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testOspfAreaPath = testOspfRouterPath + "/areas/0.0.0.10"
+
+func testOspfAreaConfig(m *mockSwitch, body string) string {
+	return testOspfRouterConfig(m, "") + `
+resource "aoscx_ospf_area" "test" {
+  instance_id = aoscx_ospf_router.test.instance_id
+` + body + `
+}
+`
+}
+
+func TestResourceOspfArea(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, testOspfAreaPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testOspfAreaConfig(m, `
+  area_id = "0.0.0.10"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_ospf_area.test", "id", "default,ospfv2,1,0.0.0.10"),
+					resource.TestCheckResourceAttr("aoscx_ospf_area.test", "type", "default"),
+					testCheckMockExists(m, testOspfAreaPath),
+				),
+			},
+			{
+				Config: testOspfAreaConfig(m, `
+  area_id      = "0.0.0.10"
+  type         = "nssa"
+  no_summary   = true
+  default_cost = 10
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_ospf_area.test", "type", "nssa"),
+					resource.TestCheckResourceAttr("aoscx_ospf_area.test", "no_summary", "true"),
+					resource.TestCheckResourceAttr("aoscx_ospf_area.test", "default_cost", "10"),
+					testCheckMockAttr(m, testOspfAreaPath, "area_type", "nssa_no_summary"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_ospf_area.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					m.patch(testOspfAreaPath, map[string]interface{}{"area_type": "stub"})
+				},
+				Config: testOspfAreaConfig(m, `
+  area_id      = "0.0.0.10"
+  type         = "nssa"
+  no_summary   = true
+  default_cost = 10
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				PreConfig: func() {
+					m.remove(testOspfAreaPath)
+				},
+				Config: testOspfAreaConfig(m, `
+  area_id      = "0.0.0.10"
+  type         = "nssa"
+  no_summary   = true
+  default_cost = 10
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceOspfAreaInvalid(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testOspfAreaConfig(m, `
+  area_id = "0.0.0.0"
+  type    = "stub"
+`),
+				ExpectError: regexp.MustCompile(`backbone area 0.0.0.0 cannot be a stub area`),
+			},
+			{
+				Config: testOspfAreaConfig(m, `
+  area_id    = "0.0.0.10"
+  no_summary = true
+`),
+				ExpectError: regexp.MustCompile(`no_summary can only be set on stub and nssa areas`),
+			},
+		},
+	})
+}
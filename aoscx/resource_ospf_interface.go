@@ -0,0 +1,289 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceOspfInterface() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Resource to enable OSPF on routed interfaces of AOS-CX switches.",
+		CreateContext: resourceOspfInterfaceCreate,
+		ReadContext:   resourceOspfInterfaceRead,
+		UpdateContext: resourceOspfInterfaceUpdate,
+		DeleteContext: resourceOspfInterfaceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceOspfInterfaceImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		CustomizeDiff: resourceOspfInterfaceCustomizeDiff,
+		Schema: ospfRouterSchema(map[string]*schema.Schema{
+			"area_id": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsIPv4Address,
+				Description:  "Area the interface is in, reference aoscx_ospf_area.<name>.area_id so the area is created first",
+			},
+			"interface": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Routed interface, e.g. 1/1/1 of an aoscx_l3_interface or vlan42 of an aoscx_vlan_interface",
+			},
+			"cost": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntBetween(0, 65535),
+				Description:  "OSPF cost of the interface, 0 derives it from the interface speed",
+			},
+			"priority": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntBetween(0, 255),
+				Description:  "Priority in the designated router election, 0 never becomes designated router",
+			},
+			"network_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "broadcast",
+				ValidateFunc: validation.StringInSlice([]string{"broadcast", "point-to-point"}, false),
+				Description:  "OSPF network type, either broadcast or point-to-point",
+			},
+			"hello_interval": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntBetween(1, 65535),
+				Description:  "Seconds between hello packets",
+			},
+			"dead_interval": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      40,
+				ValidateFunc: validation.IntBetween(1, 65535),
+				Description:  "Seconds without hello packets before a neighbor is declared down",
+			},
+			"authentication": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "none",
+				ValidateFunc: validation.StringInSlice([]string{"none", "text", "md5"}, false),
+				Description:  "OSPFv2 authentication, either none, text or md5",
+			},
+			"authentication_key": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringLenBetween(1, 16),
+				Description:  "Authentication key, the switch does not return it so changes made on the switch are not detected",
+			},
+			"authentication_key_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 255),
+				Description:  "Key ID of md5 authentication",
+			},
+		}),
+	}
+}
+
+// resourceOspfInterfaceCustomizeDiff checks the timers and authentication
+// settings.
+func resourceOspfInterfaceCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	hello_interval := d.Get("hello_interval").(int)
+	dead_interval := d.Get("dead_interval").(int)
+	if dead_interval <= hello_interval {
+		return fmt.Errorf("dead_interval %d must be longer than hello_interval %d", dead_interval, hello_interval)
+	}
+
+	authentication := d.Get("authentication").(string)
+	if authentication == "none" {
+		return nil
+	}
+
+	if d.Get("version").(int) == 3 {
+		return fmt.Errorf("authentication is only supported by OSPFv2")
+	}
+	if d.NewValueKnown("authentication_key") && d.Get("authentication_key").(string) == "" {
+		return fmt.Errorf("%s authentication needs an authentication_key", authentication)
+	}
+	if authentication == "md5" && d.Get("authentication_key_id").(int) == 0 {
+		return fmt.Errorf("md5 authentication needs an authentication_key_id")
+	}
+
+	return nil
+}
+
+func ospfInterfaceFromResourceData(d *schema.ResourceData) ospfInterface {
+	return ospfInterface{
+		Vrf:           d.Get("vrf").(string),
+		Version:       d.Get("version").(int),
+		InstanceId:    d.Get("instance_id").(int),
+		AreaId:        d.Get("area_id").(string),
+		Name:          d.Get("interface").(string),
+		Cost:          d.Get("cost").(int),
+		Priority:      d.Get("priority").(int),
+		NetworkType:   d.Get("network_type").(string),
+		HelloInterval: d.Get("hello_interval").(int),
+		DeadInterval:  d.Get("dead_interval").(int),
+		AuthType:      d.Get("authentication").(string),
+		AuthKey:       d.Get("authentication_key").(string),
+		AuthKeyId:     d.Get("authentication_key_id").(int),
+	}
+}
+
+func resourceOspfInterfaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_interface := ospfInterfaceFromResourceData(d)
+
+	err = tmp_interface.Create(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Creating OSPF Interface OSPF area does not exist", err, cty.GetAttrPath("area_id"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Creating OSPF Interface", err, cty.GetAttrPath("interface"))...)
+		return diags
+	}
+
+	d.SetId(switchID(d, ospfRouterID(d)+","+tmp_interface.AreaId+","+tmp_interface.Name))
+
+	return resourceOspfInterfaceRead(ctx, d, m)
+}
+
+func resourceOspfInterfaceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Retrieve OSPF interface from sw if existing
+	tmp_interface := ospfInterface{
+		Vrf:        d.Get("vrf").(string),
+		Version:    d.Get("version").(int),
+		InstanceId: d.Get("instance_id").(int),
+		AreaId:     d.Get("area_id").(string),
+		Name:       d.Get("interface").(string),
+	}
+
+	err = tmp_interface.Get(ctx, sw)
+
+	if isNotFound(err) {
+		// OSPF interface was removed outside of Terraform
+		d.SetId("")
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "OSPF Interface Not Found",
+			Detail:   ospfRouterID(d) + "," + tmp_interface.AreaId + "," + tmp_interface.Name,
+		})
+		return diags
+	} else if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving OSPF Interface", err, nil)...)
+		return diags
+	}
+
+	d.Set("cost", tmp_interface.Cost)
+	d.Set("priority", tmp_interface.Priority)
+	d.Set("network_type", tmp_interface.NetworkType)
+	d.Set("hello_interval", tmp_interface.HelloInterval)
+	d.Set("dead_interval", tmp_interface.DeadInterval)
+	d.Set("authentication", tmp_interface.AuthType)
+	d.Set("authentication_key_id", tmp_interface.AuthKeyId)
+
+	return diags
+}
+
+func resourceOspfInterfaceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_interface := ospfInterfaceFromResourceData(d)
+
+	err = tmp_interface.Update(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Updating OSPF Interface does not exist", err, cty.GetAttrPath("interface"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Updating OSPF Interface", err, nil)...)
+		return diags
+	}
+
+	return resourceOspfInterfaceRead(ctx, d, m)
+}
+
+func resourceOspfInterfaceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_interface := ospfInterfaceFromResourceData(d)
+
+	err = tmp_interface.Delete(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Deleting OSPF Interface does not exist", err, cty.GetAttrPath("interface"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Deleting OSPF Interface", err, nil)...)
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceOspfInterfaceImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is the ID of the OSPF area followed by the interface name,
+	// optionally followed by @switch, e.g.
+	// terraform import aoscx_ospf_interface.uplink default,ospfv2,1,0.0.0.0,1/1/1
+	fields, err := parseOspfImportID(d, "<vrf>,<ospfv2 or ospfv3>,<instance ID>,<area ID>,<interface>", 2)
+	if err != nil {
+		return nil, err
+	}
+	if _, errs := validation.IsIPv4Address(fields[0], "area_id"); len(errs) > 0 {
+		return nil, fmt.Errorf("Invalid OSPF Interface import ID %q: %v", d.Id(), errs[0])
+	}
+
+	d.Set("area_id", fields[0])
+	d.Set("interface", fields[1])
+	d.SetId(switchID(d, ospfRouterID(d)+","+fields[0]+","+fields[1]))
+
+	return []*schema.ResourceData{d}, nil
+}
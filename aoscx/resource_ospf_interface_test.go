@@ -0,0 +1,134 @@
+package aoscx
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testOspfInterfacePath = testOspfRouterPath + "/areas/0.0.0.0/ospf_interfaces/1%2F1%2F1"
+
+func testOspfInterfaceConfig(m *mockSwitch, body string) string {
+	return testOspfRouterConfig(m, "") + `
+resource "aoscx_l3_interface" "test" {
+  interface = "1/1/1"
+  ipv4      = ["10.0.0.1/31"]
+}
+
+resource "aoscx_ospf_area" "test" {
+  instance_id = aoscx_ospf_router.test.instance_id
+  area_id     = "0.0.0.0"
+}
+
+resource "aoscx_ospf_interface" "test" {
+  instance_id = aoscx_ospf_router.test.instance_id
+  area_id     = aoscx_ospf_area.test.area_id
+  interface   = aoscx_l3_interface.test.interface
+` + body + `
+}
+`
+}
+
+func TestResourceOspfInterface(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, testOspfInterfacePath),
+		Steps: []resource.TestStep{
+			{
+				Config: testOspfInterfaceConfig(m, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_ospf_interface.test", "id", "default,ospfv2,1,0.0.0.0,1/1/1"),
+					resource.TestCheckResourceAttr("aoscx_ospf_interface.test", "network_type", "broadcast"),
+					resource.TestCheckResourceAttr("aoscx_ospf_interface.test", "hello_interval", "10"),
+					resource.TestCheckResourceAttr("aoscx_ospf_interface.test", "dead_interval", "40"),
+					resource.TestCheckResourceAttr("aoscx_ospf_interface.test", "authentication", "none"),
+					testCheckMockExists(m, testOspfInterfacePath),
+				),
+			},
+			{
+				Config: testOspfInterfaceConfig(m, `
+  cost                  = 100
+  priority              = 0
+  network_type          = "point-to-point"
+  hello_interval        = 5
+  dead_interval         = 20
+  authentication        = "md5"
+  authentication_key    = "secret"
+  authentication_key_id = 1
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_ospf_interface.test", "cost", "100"),
+					resource.TestCheckResourceAttr("aoscx_ospf_interface.test", "priority", "0"),
+					resource.TestCheckResourceAttr("aoscx_ospf_interface.test", "network_type", "point-to-point"),
+					resource.TestCheckResourceAttr("aoscx_ospf_interface.test", "authentication", "md5"),
+					resource.TestCheckResourceAttr("aoscx_ospf_interface.test", "authentication_key", "secret"),
+					testCheckMockAttr(m, testOspfInterfacePath, "ospf_if_type", "ospf_iftype_pointopoint"),
+				),
+			},
+			{
+				ResourceName:            "aoscx_ospf_interface.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"authentication_key"},
+			},
+			{
+				PreConfig: func() {
+					m.patch(testOspfInterfacePath, map[string]interface{}{"ospf_if_out_cost": 10})
+				},
+				Config: testOspfInterfaceConfig(m, `
+  cost                  = 100
+  priority              = 0
+  network_type          = "point-to-point"
+  hello_interval        = 5
+  dead_interval         = 20
+  authentication        = "md5"
+  authentication_key    = "secret"
+  authentication_key_id = 1
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				PreConfig: func() {
+					m.remove(testOspfInterfacePath)
+				},
+				Config:             testOspfInterfaceConfig(m, ""),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceOspfInterfaceInvalid(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testOspfInterfaceConfig(m, `
+  hello_interval = 40
+  dead_interval  = 40
+`),
+				ExpectError: regexp.MustCompile(`dead_interval 40 must be longer than hello_interval 40`),
+			},
+			{
+				Config: testOspfInterfaceConfig(m, `
+  authentication = "text"
+`),
+				ExpectError: regexp.MustCompile(`text authentication needs an authentication_key`),
+			},
+			{
+				Config: testOspfInterfaceConfig(m, `
+  authentication     = "md5"
+  authentication_key = "secret"
+`),
+				ExpectError: regexp.MustCompile(`md5 authentication needs an authentication_key_id`),
+			},
+		},
+	})
+}
@@ -0,0 +1,267 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ospfRouterSchema returns the attributes selecting an OSPF router, shared by
+// the OSPF resources and merged with their own attributes.
+func ospfRouterSchema(resource_schema map[string]*schema.Schema) map[string]*schema.Schema {
+	resource_schema["switch"] = switchSchema()
+	resource_schema["vrf"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Default:     "default",
+		ForceNew:    true,
+		Description: "VRF of the OSPF router, reference aoscx_vrf.<name>.name so the VRF is created first",
+	}
+	resource_schema["version"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Default:      2,
+		ForceNew:     true,
+		ValidateFunc: validation.IntInSlice([]int{2, 3}),
+		Description:  "OSPF version, 2 for IPv4 or 3 for IPv6",
+	}
+	resource_schema["instance_id"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.IntBetween(1, 65535),
+		Description:  "Instance ID of the OSPF router",
+	}
+
+	return resource_schema
+}
+
+// ospfRouterID returns the ID of the OSPF router selected on d, e.g.
+// default,ospfv2,1. The IDs of areas and interfaces extend it.
+func ospfRouterID(d *schema.ResourceData) string {
+	return fmt.Sprintf("%s,ospfv%d,%d", d.Get("vrf").(string), d.Get("version").(int), d.Get("instance_id").(int))
+}
+
+// parseOspfImportID splits an import ID starting with an OSPF router ID and
+// sets the router attributes of d. The remaining parts are returned.
+func parseOspfImportID(d *schema.ResourceData, format string, parts int) ([]string, error) {
+	import_id, switch_name := parseSwitchID(d.Id())
+	fields := strings.Split(import_id, ",")
+
+	if len(fields) != parts+3 || fields[0] == "" {
+		return nil, fmt.Errorf("Invalid import ID %q, expected %s", d.Id(), format)
+	}
+
+	version, err := strconv.Atoi(strings.TrimPrefix(fields[1], "ospfv"))
+	if err != nil || !strings.HasPrefix(fields[1], "ospfv") || (version != 2 && version != 3) {
+		return nil, fmt.Errorf("Invalid import ID %q, version must be ospfv2 or ospfv3", d.Id())
+	}
+
+	instance_id, err := strconv.Atoi(fields[2])
+	if err != nil || instance_id < 1 || instance_id > 65535 {
+		return nil, fmt.Errorf("Invalid import ID %q, instance ID must be between 1 and 65535", d.Id())
+	}
+
+	for _, field := range fields[3:] {
+		if field == "" {
+			return nil, fmt.Errorf("Invalid import ID %q, expected %s", d.Id(), format)
+		}
+	}
+
+	d.Set("switch", switch_name)
+	d.Set("vrf", fields[0])
+	d.Set("version", version)
+	d.Set("instance_id", instance_id)
+
+	return fields[3:], nil
+}
+
+func resourceOspfRouter() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Resource to configure OSPFv2 and OSPFv3 routers on AOS-CX switches.",
+		CreateContext: resourceOspfRouterCreate,
+		ReadContext:   resourceOspfRouterRead,
+		UpdateContext: resourceOspfRouterUpdate,
+		DeleteContext: resourceOspfRouterDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceOspfRouterImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		Schema: ospfRouterSchema(map[string]*schema.Schema{
+			"router_id": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsIPv4Address,
+				Description:  "Router ID in dotted decimal notation, by default the switch picks an interface address",
+			},
+			"passive_interface_default": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Make interfaces passive unless they are configured otherwise",
+			},
+			"redistribute": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Route sources redistributed into OSPF, any of connected, static and bgp",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"connected", "static", "bgp"}, false),
+				},
+			},
+		}),
+	}
+}
+
+func ospfRouterFromResourceData(d *schema.ResourceData) ospfRouter {
+	return ospfRouter{
+		Vrf:                     d.Get("vrf").(string),
+		Version:                 d.Get("version").(int),
+		InstanceId:              d.Get("instance_id").(int),
+		RouterId:                d.Get("router_id").(string),
+		PassiveInterfaceDefault: d.Get("passive_interface_default").(bool),
+		Redistribute:            sortedStrings(d.Get("redistribute").(*schema.Set)),
+	}
+}
+
+func resourceOspfRouterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_router := ospfRouterFromResourceData(d)
+
+	err = tmp_router.Create(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Creating OSPF Router VRF does not exist", err, cty.GetAttrPath("vrf"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Creating OSPF Router", err, cty.GetAttrPath("instance_id"))...)
+		return diags
+	}
+
+	d.SetId(switchID(d, ospfRouterID(d)))
+
+	return resourceOspfRouterRead(ctx, d, m)
+}
+
+func resourceOspfRouterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Retrieve OSPF router from sw if existing
+	tmp_router := ospfRouter{
+		Vrf:        d.Get("vrf").(string),
+		Version:    d.Get("version").(int),
+		InstanceId: d.Get("instance_id").(int),
+	}
+
+	err = tmp_router.Get(ctx, sw)
+
+	if isNotFound(err) {
+		// OSPF router was removed outside of Terraform
+		d.SetId("")
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "OSPF Router Not Found",
+			Detail:   ospfRouterID(d),
+		})
+		return diags
+	} else if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving OSPF Router", err, nil)...)
+		return diags
+	}
+
+	d.Set("router_id", tmp_router.RouterId)
+	d.Set("passive_interface_default", tmp_router.PassiveInterfaceDefault)
+	d.Set("redistribute", tmp_router.Redistribute)
+
+	return diags
+}
+
+func resourceOspfRouterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_router := ospfRouterFromResourceData(d)
+
+	err = tmp_router.Update(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Updating OSPF Router does not exist", err, cty.GetAttrPath("instance_id"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Updating OSPF Router", err, nil)...)
+		return diags
+	}
+
+	return resourceOspfRouterRead(ctx, d, m)
+}
+
+func resourceOspfRouterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_router := ospfRouterFromResourceData(d)
+
+	err = tmp_router.Delete(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Deleting OSPF Router does not exist", err, cty.GetAttrPath("instance_id"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Deleting OSPF Router", err, nil)...)
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceOspfRouterImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is the VRF, OSPF version and instance ID separated by commas,
+	// optionally followed by @switch, e.g.
+	// terraform import aoscx_ospf_router.core default,ospfv2,1
+	_, err := parseOspfImportID(d, "<vrf>,<ospfv2 or ospfv3>,<instance ID>", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(switchID(d, ospfRouterID(d)))
+
+	return []*schema.ResourceData{d}, nil
+}
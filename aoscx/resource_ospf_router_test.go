@@ -0,0 +1,124 @@
+package aoscx
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testOspfRouterPath = "system/vrfs/default/ospf_routers/1"
+
+func testOspfRouterConfig(m *mockSwitch, body string) string {
+	return testProviderConfig(m) + `
+resource "aoscx_ospf_router" "test" {
+  instance_id = 1
+` + body + `
+}
+`
+}
+
+func TestResourceOspfRouter(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, testOspfRouterPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testOspfRouterConfig(m, `
+  router_id = "10.255.0.1"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_ospf_router.test", "id", "default,ospfv2,1"),
+					resource.TestCheckResourceAttr("aoscx_ospf_router.test", "version", "2"),
+					resource.TestCheckResourceAttr("aoscx_ospf_router.test", "router_id", "10.255.0.1"),
+					resource.TestCheckResourceAttr("aoscx_ospf_router.test", "passive_interface_default", "false"),
+					testCheckMockExists(m, testOspfRouterPath),
+				),
+			},
+			{
+				Config: testOspfRouterConfig(m, `
+  router_id                 = "10.255.0.1"
+  passive_interface_default = true
+  redistribute              = ["connected", "static"]
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_ospf_router.test", "passive_interface_default", "true"),
+					resource.TestCheckResourceAttr("aoscx_ospf_router.test", "redistribute.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_ospf_router.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					m.patch(testOspfRouterPath, map[string]interface{}{"redistribute": []interface{}{"bgp"}})
+				},
+				Config: testOspfRouterConfig(m, `
+  router_id                 = "10.255.0.1"
+  passive_interface_default = true
+  redistribute              = ["connected", "static"]
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				PreConfig: func() {
+					m.remove(testOspfRouterPath)
+				},
+				Config: testOspfRouterConfig(m, `
+  router_id                 = "10.255.0.1"
+  passive_interface_default = true
+  redistribute              = ["connected", "static"]
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceOspfRouterV3(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, "system/vrfs/default/ospfv3_routers/1"),
+		Steps: []resource.TestStep{
+			{
+				Config: testOspfRouterConfig(m, `
+  version   = 3
+  router_id = "10.255.0.1"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_ospf_router.test", "id", "default,ospfv3,1"),
+					testCheckMockExists(m, "system/vrfs/default/ospfv3_routers/1"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_ospf_router.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceOspfRouterMissingVrf(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testOspfRouterConfig(m, `
+  vrf = "missing"
+`),
+				ExpectError: regexp.MustCompile(`VRF does not exist`),
+			},
+		},
+	})
+}
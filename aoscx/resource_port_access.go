@@ -0,0 +1,238 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourcePortAccess() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Resource to configure 802.1X and MAC authentication on ports of AOS-CX switches, destroying it disables port access on the port.",
+		CreateContext: resourcePortAccessCreate,
+		ReadContext:   resourcePortAccessRead,
+		UpdateContext: resourcePortAccessUpdate,
+		DeleteContext: resourcePortAccessDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePortAccessImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		CustomizeDiff: resourcePortAccessCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"switch": switchSchema(),
+			"interface": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Layer 2 port, e.g. 1/1/1 of an aoscx_l2_interface",
+			},
+			"dot1x": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Enable the 802.1X authenticator on the port",
+			},
+			"mac_auth": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Enable MAC authentication on the port",
+			},
+			"reauth_period": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntBetween(0, 86400),
+				Description:  "Seconds between reauthentications of the clients, 0 disables reauthentication",
+			},
+			"client_limit": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntBetween(1, 256),
+				Description:  "Maximum number of clients authenticated on the port",
+			},
+			"fallback_role": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Role of the clients when no RADIUS server is reachable, reference aoscx_port_access_role.<name>.name so the role is created first",
+			},
+		},
+	}
+}
+
+// resourcePortAccessCustomizeDiff checks that an authentication method is
+// enabled.
+func resourcePortAccessCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.Get("dot1x").(bool) && !d.Get("mac_auth").(bool) {
+		return fmt.Errorf("at least one of dot1x and mac_auth must be enabled")
+	}
+
+	return nil
+}
+
+func portAccessFromResourceData(d *schema.ResourceData) portAccess {
+	tmp_access := portAccess{
+		Name:         d.Get("interface").(string),
+		Methods:      []string{},
+		ReauthPeriod: d.Get("reauth_period").(int),
+		ClientLimit:  d.Get("client_limit").(int),
+		FallbackRole: d.Get("fallback_role").(string),
+	}
+
+	for _, method := range []string{"dot1x", "mac_auth"} {
+		if d.Get(method).(bool) {
+			tmp_access.Methods = append(tmp_access.Methods, method)
+		}
+	}
+
+	return tmp_access
+}
+
+func resourcePortAccessCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_access := portAccessFromResourceData(d)
+
+	err = tmp_access.Update(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Creating Port Access interface does not exist", err, cty.GetAttrPath("interface"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Creating Port Access", err, nil)...)
+		return diags
+	}
+
+	d.SetId(switchID(d, tmp_access.Name))
+
+	return resourcePortAccessRead(ctx, d, m)
+}
+
+func resourcePortAccessRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Retrieve port access configuration of the interface from sw if existing
+	tmp_access := portAccess{
+		Name: d.Get("interface").(string),
+	}
+
+	err = tmp_access.Get(ctx, sw)
+
+	if isNotFound(err) {
+		// Interface was removed outside of Terraform
+		d.SetId("")
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Port Access Not Found",
+			Detail:   tmp_access.Name,
+		})
+		return diags
+	} else if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving Port Access", err, nil)...)
+		return diags
+	}
+
+	methods := map[string]bool{}
+	for _, method := range tmp_access.Methods {
+		methods[method] = true
+	}
+
+	d.Set("dot1x", methods["dot1x"])
+	d.Set("mac_auth", methods["mac_auth"])
+	d.Set("reauth_period", tmp_access.ReauthPeriod)
+	d.Set("client_limit", tmp_access.ClientLimit)
+	d.Set("fallback_role", tmp_access.FallbackRole)
+
+	return diags
+}
+
+func resourcePortAccessUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_access := portAccessFromResourceData(d)
+
+	err = tmp_access.Update(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Updating Port Access interface does not exist", err, cty.GetAttrPath("interface"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Updating Port Access", err, nil)...)
+		return diags
+	}
+
+	return resourcePortAccessRead(ctx, d, m)
+}
+
+func resourcePortAccessDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_access := portAccessFromResourceData(d)
+
+	err = tmp_access.Delete(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Deleting Port Access interface does not exist", err, cty.GetAttrPath("interface"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Deleting Port Access", err, nil)...)
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourcePortAccessImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is the interface name, optionally followed by @switch, e.g.
+	// terraform import aoscx_port_access.int_1_1_10 1/1/10
+	name, switch_name := parseSwitchID(d.Id())
+	if name == "" {
+		return nil, fmt.Errorf("Invalid import ID %q, expected <interface>", d.Id())
+	}
+
+	d.Set("switch", switch_name)
+	d.Set("interface", name)
+	d.SetId(switchID(d, name))
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,224 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourcePortAccessRole() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Resource to configure port access roles assigned to authenticated clients on AOS-CX switches.",
+		CreateContext: resourcePortAccessRoleCreate,
+		ReadContext:   resourcePortAccessRoleRead,
+		UpdateContext: resourcePortAccessRoleUpdate,
+		DeleteContext: resourcePortAccessRoleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePortAccessRoleImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		CustomizeDiff: resourcePortAccessRoleCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"switch": switchSchema(),
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.All(validation.StringLenBetween(1, 64), validation.StringDoesNotContainAny(", ")),
+				Description:  "Name of the role, RADIUS servers assign it with the Aruba-User-Role attribute",
+			},
+			"description": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(1, 64),
+				Description:  "Description of the role",
+			},
+			"vlan_access": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 4094),
+				Description:  "Untagged VLAN of clients in the role, defaults to the VLAN of the port",
+			},
+			"vlan_trunks": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Tagged VLANs of clients in the role",
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(1, 4094),
+				},
+			},
+			"reauth_period": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 86400),
+				Description:  "Seconds between reauthentications of clients in the role, defaults to the reauthentication period of the port",
+			},
+		},
+	}
+}
+
+// resourcePortAccessRoleCustomizeDiff checks that the untagged VLAN is not
+// also tagged.
+func resourcePortAccessRoleCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	vlan_access := d.Get("vlan_access").(int)
+	if vlan_access != 0 && d.Get("vlan_trunks").(*schema.Set).Contains(vlan_access) {
+		return fmt.Errorf("VLAN %d cannot be both vlan_access and in vlan_trunks", vlan_access)
+	}
+
+	return nil
+}
+
+func portAccessRoleFromResourceData(d *schema.ResourceData) portAccessRole {
+	tmp_role := portAccessRole{
+		Name:         d.Get("name").(string),
+		Description:  d.Get("description").(string),
+		VlanAccess:   d.Get("vlan_access").(int),
+		VlanTrunks:   []int{},
+		ReauthPeriod: d.Get("reauth_period").(int),
+	}
+
+	for _, vlan := range d.Get("vlan_trunks").(*schema.Set).List() {
+		tmp_role.VlanTrunks = append(tmp_role.VlanTrunks, vlan.(int))
+	}
+	sort.Ints(tmp_role.VlanTrunks)
+
+	return tmp_role
+}
+
+func resourcePortAccessRoleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_role := portAccessRoleFromResourceData(d)
+
+	err = tmp_role.Create(ctx, sw)
+
+	if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Creating Port Access Role", err, cty.GetAttrPath("name"))...)
+		return diags
+	}
+
+	d.SetId(switchID(d, tmp_role.Name))
+
+	return resourcePortAccessRoleRead(ctx, d, m)
+}
+
+func resourcePortAccessRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Retrieve port access role from sw if existing
+	tmp_role := portAccessRole{
+		Name: d.Get("name").(string),
+	}
+
+	err = tmp_role.Get(ctx, sw)
+
+	if isNotFound(err) {
+		// Port access role was removed outside of Terraform
+		d.SetId("")
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Port Access Role Not Found",
+			Detail:   tmp_role.Name,
+		})
+		return diags
+	} else if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving Port Access Role", err, nil)...)
+		return diags
+	}
+
+	d.Set("description", tmp_role.Description)
+	d.Set("vlan_access", tmp_role.VlanAccess)
+	d.Set("vlan_trunks", tmp_role.VlanTrunks)
+	d.Set("reauth_period", tmp_role.ReauthPeriod)
+
+	return diags
+}
+
+func resourcePortAccessRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_role := portAccessRoleFromResourceData(d)
+
+	err = tmp_role.Update(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Updating Port Access Role does not exist", err, cty.GetAttrPath("name"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Updating Port Access Role", err, nil)...)
+		return diags
+	}
+
+	return resourcePortAccessRoleRead(ctx, d, m)
+}
+
+func resourcePortAccessRoleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_role := portAccessRoleFromResourceData(d)
+
+	err = tmp_role.Delete(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Deleting Port Access Role does not exist", err, cty.GetAttrPath("name"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Deleting Port Access Role", err, nil)...)
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourcePortAccessRoleImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is the name of the role, optionally followed by @switch, e.g.
+	// terraform import aoscx_port_access_role.employee EMPLOYEE
+	name, switch_name := parseSwitchID(d.Id())
+	if name == "" {
+		return nil, fmt.Errorf("Invalid import ID %q, expected <name>", d.Id())
+	}
+
+	d.Set("switch", switch_name)
+	d.Set("name", name)
+	d.SetId(switchID(d, name))
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,88 @@
+package aoscx
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testPortAccessRolePath = "system/port_access_roles/EMPLOYEE"
+
+func testPortAccessRoleConfig(m *mockSwitch, body string) string {
+	return testProviderConfig(m) + `
+resource "aoscx_port_access_role" "test" {
+  name = "EMPLOYEE"
+` + body + `
+}
+`
+}
+
+func TestResourcePortAccessRole(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, testPortAccessRolePath),
+		Steps: []resource.TestStep{
+			{
+				Config: testPortAccessRoleConfig(m, `
+  description = "Employees"
+  vlan_access = 10
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_port_access_role.test", "id", "EMPLOYEE"),
+					resource.TestCheckResourceAttr("aoscx_port_access_role.test", "vlan_trunks.#", "0"),
+					testCheckMockAttr(m, testPortAccessRolePath, "vlan_tag", restURI("system/vlans/10")),
+					testCheckMockAttr(m, testPortAccessRolePath, "reauth_period", ""),
+				),
+			},
+			{
+				Config: testPortAccessRoleConfig(m, `
+  vlan_trunks   = [20, 30]
+  reauth_period = 3600
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_port_access_role.test", "description", ""),
+					resource.TestCheckResourceAttr("aoscx_port_access_role.test", "vlan_access", "0"),
+					resource.TestCheckResourceAttr("aoscx_port_access_role.test", "vlan_trunks.#", "2"),
+					testCheckMockAttr(m, testPortAccessRolePath, "vlan_tag", ""),
+					testCheckMockAttr(m, testPortAccessRolePath, "reauth_period", "3600"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_port_access_role.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					m.remove(testPortAccessRolePath)
+				},
+				Config: testPortAccessRoleConfig(m, `
+  vlan_trunks   = [20, 30]
+  reauth_period = 3600
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourcePortAccessRoleInvalid(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testPortAccessRoleConfig(m, `
+  vlan_access = 10
+  vlan_trunks = [10, 20]
+`),
+				ExpectError: regexp.MustCompile(`VLAN 10 cannot be both vlan_access and in vlan_trunks`),
+			},
+		},
+	})
+}
@@ -0,0 +1,110 @@
+package aoscx
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testPortAccessPath = "system/interfaces/1%2F1%2F1"
+
+func testPortAccessConfig(m *mockSwitch, body string) string {
+	return testProviderConfig(m) + `
+resource "aoscx_port_access_role" "guest" {
+  name        = "GUEST"
+  vlan_access = 99
+}
+
+resource "aoscx_port_access" "test" {
+  interface = "1/1/1"
+` + body + `
+}
+`
+}
+
+func TestResourcePortAccess(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckMockAttr(m, testPortAccessPath, "port_access_clients_limit", ""),
+			testCheckMockAttr(m, testPortAccessPath, "port_access_fallback_role", ""),
+			testCheckMockDestroyed(m, testPortAccessPath+"/port_access_auth_configurations/802.1x"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testPortAccessConfig(m, `
+  dot1x         = true
+  reauth_period = 3600
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_port_access.test", "id", "1/1/1"),
+					resource.TestCheckResourceAttr("aoscx_port_access.test", "client_limit", "1"),
+					testCheckMockAttr(m, testPortAccessPath+"/port_access_auth_configurations/802.1x", "reauth_period", "3600"),
+					testCheckMockAttr(m, testPortAccessPath+"/port_access_auth_configurations/802.1x", "reauth_enable", "true"),
+				),
+			},
+			{
+				Config: testPortAccessConfig(m, `
+  dot1x         = true
+  mac_auth      = true
+  client_limit  = 4
+  fallback_role = aoscx_port_access_role.guest.name
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_port_access.test", "reauth_period", "0"),
+					resource.TestCheckResourceAttr("aoscx_port_access.test", "fallback_role", "GUEST"),
+					testCheckMockAttr(m, testPortAccessPath, "port_access_clients_limit", "4"),
+					testCheckMockAttr(m, testPortAccessPath, "port_access_fallback_role", restURI("system/port_access_roles/GUEST")),
+					testCheckMockAttr(m, testPortAccessPath+"/port_access_auth_configurations/802.1x", "reauth_enable", "false"),
+					testCheckMockExists(m, testPortAccessPath+"/port_access_auth_configurations/mac-auth"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_port_access.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					m.remove(testPortAccessPath + "/port_access_auth_configurations/mac-auth")
+				},
+				Config: testPortAccessConfig(m, `
+  dot1x         = true
+  mac_auth      = true
+  client_limit  = 4
+  fallback_role = aoscx_port_access_role.guest.name
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourcePortAccessInvalid(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testPortAccessConfig(m, `
+  client_limit = 2
+`),
+				ExpectError: regexp.MustCompile(`at least one of dot1x and mac_auth must be enabled`),
+			},
+			{
+				Config: testProviderConfig(m) + `
+resource "aoscx_port_access" "test" {
+  interface = "1/1/99"
+  dot1x     = true
+}
+`,
+				ExpectError: regexp.MustCompile(`Error Creating Port Access interface does not exist`),
+			},
+		},
+	})
+}
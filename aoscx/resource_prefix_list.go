@@ -0,0 +1,331 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// policyNameSchema returns the name attribute of a routing policy.
+func policyNameSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.All(validation.StringLenBetween(1, 64), validation.StringDoesNotContainAny(", ")),
+		Description:  description,
+	}
+}
+
+// policyEntrySchema returns the sequence and action attributes of the
+// entries of a routing policy, merged with the attributes of the entry.
+func policyEntrySchema(entry_schema map[string]*schema.Schema) map[string]*schema.Schema {
+	entry_schema["sequence"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Required:     true,
+		ValidateFunc: validation.IntAtLeast(1),
+		Description:  "Sequence number of the entry, unique within the list",
+	}
+	entry_schema["action"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.StringInSlice([]string{"permit", "deny"}, false),
+		Description:  "Action of the entry, either permit or deny",
+	}
+
+	return entry_schema
+}
+
+// policyCreateDiagnostics returns the diagnostics of a routing policy that
+// failed to be created. A policy created without all of its entries is kept
+// in state so it is tainted and replaced on the next apply, one that was not
+// created, e.g. because it already exists, is not.
+func policyCreateDiagnostics(d *schema.ResourceData, created bool, name string, summary string, err error) diag.Diagnostics {
+	if created {
+		d.SetId(switchID(d, name))
+	}
+	return errorDiagnostics(summary, err, cty.GetAttrPath("name"))
+}
+
+// checkPolicySequences checks that the sequence numbers of the entries are
+// unique.
+func checkPolicySequences(entries []interface{}) error {
+	sequences := map[int]bool{}
+
+	for _, item := range entries {
+		sequence := item.(map[string]interface{})["sequence"].(int)
+		if sequences[sequence] {
+			return fmt.Errorf("entry sequence %d is used by more than one entry", sequence)
+		}
+		sequences[sequence] = true
+	}
+
+	return nil
+}
+
+func resourcePrefixList() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Resource to configure IPv4 and IPv6 prefix lists on AOS-CX switches.",
+		CreateContext: resourcePrefixListCreate,
+		ReadContext:   resourcePrefixListRead,
+		UpdateContext: resourcePrefixListUpdate,
+		DeleteContext: resourcePrefixListDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePrefixListImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		CustomizeDiff: resourcePrefixListCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"switch": switchSchema(),
+			"name":   policyNameSchema("Name of the prefix list"),
+			"address_family": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "ipv4",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"ipv4", "ipv6"}, false),
+				Description:  "Address family of the prefix list, either ipv4 or ipv6",
+			},
+			"entry": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Entries of the prefix list, evaluated in the order of their sequence number",
+				Elem: &schema.Resource{
+					Schema: policyEntrySchema(map[string]*schema.Schema{
+						"prefix": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateCIDRNetwork,
+							Description:  "Prefix matched by the entry in CIDR notation, e.g. 10.0.0.0/8",
+						},
+						"ge": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntBetween(0, 128),
+							Description:  "Minimum length of the matched prefixes, 0 matches only the prefix length unless le is set",
+						},
+						"le": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntBetween(0, 128),
+							Description:  "Maximum length of the matched prefixes, 0 matches only the prefix length unless ge is set",
+						},
+					}),
+				},
+			},
+		},
+	}
+}
+
+// resourcePrefixListCustomizeDiff checks that the entries belong to the
+// address family of the list and that their ge and le ranges are valid.
+func resourcePrefixListCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	address_family := d.Get("address_family").(string)
+	entries := d.Get("entry").(*schema.Set).List()
+
+	if err := checkPolicySequences(entries); err != nil {
+		return err
+	}
+
+	for _, item := range entries {
+		entry := item.(map[string]interface{})
+		sequence := entry["sequence"].(int)
+
+		_, prefix, err := net.ParseCIDR(entry["prefix"].(string))
+		if err != nil {
+			// Unknown or rejected by validateCIDRNetwork
+			continue
+		}
+		if strings.Contains(prefix.String(), ":") != (address_family == "ipv6") {
+			return fmt.Errorf("entry %d: prefix %s does not belong to address family %s", sequence, prefix, address_family)
+		}
+
+		length, max_length := prefix.Mask.Size()
+		ge := entry["ge"].(int)
+		le := entry["le"].(int)
+		if ge != 0 && (ge <= length || ge > max_length) {
+			return fmt.Errorf("entry %d: ge %d must be longer than the prefix length %d and at most %d", sequence, ge, length, max_length)
+		}
+		if le != 0 && (le <= length || le > max_length) {
+			return fmt.Errorf("entry %d: le %d must be longer than the prefix length %d and at most %d", sequence, le, length, max_length)
+		}
+		if ge != 0 && le != 0 && le < ge {
+			return fmt.Errorf("entry %d: le %d must not be shorter than ge %d", sequence, le, ge)
+		}
+	}
+
+	return nil
+}
+
+func prefixListFromResourceData(d *schema.ResourceData) prefixList {
+	tmp_list := prefixList{
+		Name:          d.Get("name").(string),
+		AddressFamily: d.Get("address_family").(string),
+		Entries:       []prefixListEntry{},
+	}
+
+	for _, item := range d.Get("entry").(*schema.Set).List() {
+		entry := item.(map[string]interface{})
+		tmp_list.Entries = append(tmp_list.Entries, prefixListEntry{
+			Preference: entry["sequence"].(int),
+			Action:     entry["action"].(string),
+			Prefix:     entry["prefix"].(string),
+			Ge:         entry["ge"].(int),
+			Le:         entry["le"].(int),
+		})
+	}
+
+	sort.Slice(tmp_list.Entries, func(i, j int) bool {
+		return tmp_list.Entries[i].Preference < tmp_list.Entries[j].Preference
+	})
+
+	return tmp_list
+}
+
+func resourcePrefixListCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_list := prefixListFromResourceData(d)
+
+	created, err := tmp_list.Create(ctx, sw)
+
+	if requestFailed(err) {
+		return append(diags, policyCreateDiagnostics(d, created, tmp_list.Name, "Error in Creating Prefix List", err)...)
+	}
+
+	d.SetId(switchID(d, tmp_list.Name))
+
+	return resourcePrefixListRead(ctx, d, m)
+}
+
+func resourcePrefixListRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Retrieve prefix list from sw if existing
+	tmp_list := prefixList{
+		Name: d.Get("name").(string),
+	}
+
+	err = tmp_list.Get(ctx, sw)
+
+	if isNotFound(err) {
+		// Prefix list was removed outside of Terraform
+		d.SetId("")
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Prefix List Not Found",
+			Detail:   tmp_list.Name,
+		})
+		return diags
+	} else if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving Prefix List", err, nil)...)
+		return diags
+	}
+
+	entries := []interface{}{}
+	for _, entry := range tmp_list.Entries {
+		entries = append(entries, map[string]interface{}{
+			"sequence": entry.Preference,
+			"action":   entry.Action,
+			"prefix":   entry.Prefix,
+			"ge":       entry.Ge,
+			"le":       entry.Le,
+		})
+	}
+
+	d.Set("address_family", tmp_list.AddressFamily)
+	d.Set("entry", entries)
+
+	return diags
+}
+
+func resourcePrefixListUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_list := prefixListFromResourceData(d)
+
+	err = tmp_list.Update(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Updating Prefix List does not exist", err, cty.GetAttrPath("name"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Updating Prefix List", err, nil)...)
+		return diags
+	}
+
+	return resourcePrefixListRead(ctx, d, m)
+}
+
+func resourcePrefixListDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_list := prefixListFromResourceData(d)
+
+	err = tmp_list.Delete(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Deleting Prefix List does not exist", err, cty.GetAttrPath("name"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Deleting Prefix List", err, nil)...)
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourcePrefixListImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is the name of the prefix list, optionally followed by
+	// @switch, e.g.
+	// terraform import aoscx_prefix_list.loopbacks LOOPBACKS
+	name, switch_name := parseSwitchID(d.Id())
+	if name == "" {
+		return nil, fmt.Errorf("Invalid import ID %q, expected <name>", d.Id())
+	}
+
+	d.Set("switch", switch_name)
+	d.Set("name", name)
+	d.SetId(switchID(d, name))
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,222 @@
+package aoscx
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testPrefixListPath = "system/prefix_lists/LOOPBACKS"
+
+func testPrefixListConfig(m *mockSwitch, entries string) string {
+	return testProviderConfig(m) + `
+resource "aoscx_prefix_list" "test" {
+  name = "LOOPBACKS"
+` + entries + `
+}
+`
+}
+
+const testPrefixListEntries = `
+  entry {
+    sequence = 10
+    action   = "permit"
+    prefix   = "10.255.0.0/16"
+    ge       = 32
+  }
+
+  entry {
+    sequence = 100
+    action   = "deny"
+    prefix   = "0.0.0.0/0"
+    le       = 32
+  }
+`
+
+const testPrefixListEntriesUpdated = `
+  entry {
+    sequence = 10
+    action   = "permit"
+    prefix   = "10.255.0.0/16"
+    ge       = 32
+  }
+
+  entry {
+    sequence = 20
+    action   = "permit"
+    prefix   = "10.0.0.0/8"
+    ge       = 24
+    le       = 28
+  }
+`
+
+func TestResourcePrefixList(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, testPrefixListPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testPrefixListConfig(m, testPrefixListEntries),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_prefix_list.test", "id", "LOOPBACKS"),
+					resource.TestCheckResourceAttr("aoscx_prefix_list.test", "address_family", "ipv4"),
+					resource.TestCheckResourceAttr("aoscx_prefix_list.test", "entry.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("aoscx_prefix_list.test", "entry.*", map[string]string{
+						"sequence": "10",
+						"action":   "permit",
+						"prefix":   "10.255.0.0/16",
+						"ge":       "32",
+						"le":       "0",
+					}),
+					testCheckMockExists(m, testPrefixListPath+"/prefix_list_entries/10"),
+					testCheckMockExists(m, testPrefixListPath+"/prefix_list_entries/100"),
+				),
+			},
+			{
+				Config: testPrefixListConfig(m, testPrefixListEntriesUpdated),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_prefix_list.test", "entry.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("aoscx_prefix_list.test", "entry.*", map[string]string{
+						"sequence": "20",
+						"prefix":   "10.0.0.0/8",
+						"ge":       "24",
+						"le":       "28",
+					}),
+					testCheckMockExists(m, testPrefixListPath+"/prefix_list_entries/20"),
+					testCheckMockDestroyed(m, testPrefixListPath+"/prefix_list_entries/100"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_prefix_list.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					m.patch(testPrefixListPath+"/prefix_list_entries/20", map[string]interface{}{"le": 32})
+				},
+				Config:             testPrefixListConfig(m, testPrefixListEntriesUpdated),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testPrefixListConfig(m, testPrefixListEntriesUpdated),
+			},
+			{
+				PreConfig: func() {
+					m.remove(testPrefixListPath)
+				},
+				Config:             testPrefixListConfig(m, testPrefixListEntriesUpdated),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourcePrefixListIPv6(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testProviderConfig(m) + `
+resource "aoscx_prefix_list" "test" {
+  name           = "LOOPBACKS6"
+  address_family = "ipv6"
+
+  entry {
+    sequence = 10
+    action   = "permit"
+    prefix   = "2001:db8::/32"
+    le       = 128
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_prefix_list.test", "address_family", "ipv6"),
+					testCheckMockAttr(m, "system/prefix_lists/LOOPBACKS6", "address_family", "ipv6"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_prefix_list.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourcePrefixListInvalid(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testPrefixListConfig(m, `
+  entry {
+    sequence = 10
+    action   = "permit"
+    prefix   = "10.0.0.0/8"
+  }
+
+  entry {
+    sequence = 10
+    action   = "deny"
+    prefix   = "10.0.0.0/16"
+  }
+`),
+				ExpectError: regexp.MustCompile(`entry sequence 10 is used by more than one entry`),
+			},
+			{
+				Config: testPrefixListConfig(m, `
+  entry {
+    sequence = 10
+    action   = "permit"
+    prefix   = "2001:db8::/32"
+  }
+`),
+				ExpectError: regexp.MustCompile(`prefix 2001:db8::/32 does not belong to address family ipv4`),
+			},
+			{
+				Config: testPrefixListConfig(m, `
+  entry {
+    sequence = 10
+    action   = "permit"
+    prefix   = "10.0.0.0/8"
+    ge       = 8
+  }
+`),
+				ExpectError: regexp.MustCompile(`ge 8 must be longer than the prefix length 8`),
+			},
+			{
+				Config: testPrefixListConfig(m, `
+  entry {
+    sequence = 10
+    action   = "permit"
+    prefix   = "10.0.0.0/8"
+    le       = 33
+  }
+`),
+				ExpectError: regexp.MustCompile(`le 33 must be longer than the prefix length 8 and at most 32`),
+			},
+			{
+				Config: testPrefixListConfig(m, `
+  entry {
+    sequence = 10
+    action   = "permit"
+    prefix   = "10.0.0.0/8"
+    ge       = 24
+    le       = 16
+  }
+`),
+				ExpectError: regexp.MustCompile(`le 16 must not be shorter than ge 24`),
+			},
+		},
+	})
+}
@@ -0,0 +1,242 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceRadiusServer() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Resource to configure RADIUS servers used for port access authentication on AOS-CX switches.",
+		CreateContext: resourceRadiusServerCreate,
+		ReadContext:   resourceRadiusServerRead,
+		UpdateContext: resourceRadiusServerUpdate,
+		DeleteContext: resourceRadiusServerDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRadiusServerImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"switch": switchSchema(),
+			"address": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsIPAddress,
+				Description:  "IPv4 or IPv6 address of the RADIUS server",
+			},
+			"port": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1812,
+				ForceNew:     true,
+				ValidateFunc: validation.IsPortNumber,
+				Description:  "UDP authentication port of the RADIUS server",
+			},
+			"vrf": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "default",
+				ForceNew:    true,
+				Description: "VRF the RADIUS server is reached through, reference aoscx_vrf.<name>.name so the VRF is created first",
+			},
+			"key": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringLenBetween(1, 32),
+				Description:  "Shared secret of the RADIUS server, the switch does not return it so changes made on the switch are not detected",
+			},
+			"timeout": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntBetween(1, 60),
+				Description:  "Seconds to wait for a response of the RADIUS server",
+			},
+			"retries": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntBetween(0, 5),
+				Description:  "Number of retransmissions before the next RADIUS server is tried",
+			},
+			"server_group": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "AAA server group of the RADIUS server, reference aoscx_aaa_server_group.<name>.name so the group is created first. Defaults to the built-in radius group",
+			},
+		},
+	}
+}
+
+// radiusServerID returns the ID of the RADIUS server selected on d, e.g.
+// default,10.0.0.10,1812.
+func radiusServerID(d *schema.ResourceData) string {
+	return fmt.Sprintf("%s,%s,%d", d.Get("vrf").(string), d.Get("address").(string), d.Get("port").(int))
+}
+
+func radiusServerFromResourceData(d *schema.ResourceData) radiusServer {
+	return radiusServer{
+		Vrf:         d.Get("vrf").(string),
+		Address:     d.Get("address").(string),
+		Port:        d.Get("port").(int),
+		Key:         d.Get("key").(string),
+		Timeout:     d.Get("timeout").(int),
+		Retries:     d.Get("retries").(int),
+		ServerGroup: d.Get("server_group").(string),
+	}
+}
+
+func resourceRadiusServerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_server := radiusServerFromResourceData(d)
+
+	err = tmp_server.Create(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Creating RADIUS Server VRF does not exist", err, cty.GetAttrPath("vrf"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Creating RADIUS Server", err, cty.GetAttrPath("address"))...)
+		return diags
+	}
+
+	d.SetId(switchID(d, radiusServerID(d)))
+
+	return resourceRadiusServerRead(ctx, d, m)
+}
+
+func resourceRadiusServerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Retrieve RADIUS server from sw if existing
+	tmp_server := radiusServer{
+		Vrf:     d.Get("vrf").(string),
+		Address: d.Get("address").(string),
+		Port:    d.Get("port").(int),
+	}
+
+	err = tmp_server.Get(ctx, sw)
+
+	if isNotFound(err) {
+		// RADIUS server was removed outside of Terraform
+		d.SetId("")
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "RADIUS Server Not Found",
+			Detail:   radiusServerID(d),
+		})
+		return diags
+	} else if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving RADIUS Server", err, nil)...)
+		return diags
+	}
+
+	d.Set("timeout", tmp_server.Timeout)
+	d.Set("retries", tmp_server.Retries)
+	d.Set("server_group", tmp_server.ServerGroup)
+
+	return diags
+}
+
+func resourceRadiusServerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_server := radiusServerFromResourceData(d)
+
+	err = tmp_server.Update(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Updating RADIUS Server does not exist", err, cty.GetAttrPath("address"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Updating RADIUS Server", err, nil)...)
+		return diags
+	}
+
+	return resourceRadiusServerRead(ctx, d, m)
+}
+
+func resourceRadiusServerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_server := radiusServerFromResourceData(d)
+
+	err = tmp_server.Delete(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Deleting RADIUS Server does not exist", err, cty.GetAttrPath("address"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Deleting RADIUS Server", err, nil)...)
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceRadiusServerImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is the VRF, address and port of the RADIUS server, optionally
+	// followed by @switch, e.g.
+	// terraform import aoscx_radius_server.radius1 default,10.0.0.10,1812
+	import_id, switch_name := parseSwitchID(d.Id())
+	fields := strings.Split(import_id, ",")
+	if len(fields) != 3 || fields[0] == "" || fields[1] == "" {
+		return nil, fmt.Errorf("Invalid import ID %q, expected <vrf>,<address>,<port>", d.Id())
+	}
+	port, err := strconv.Atoi(fields[2])
+	if err != nil || port < 1 || port > 65535 {
+		return nil, fmt.Errorf("Invalid import ID %q, port must be between 1 and 65535", d.Id())
+	}
+
+	d.Set("switch", switch_name)
+	d.Set("vrf", fields[0])
+	d.Set("address", fields[1])
+	d.Set("port", port)
+	d.SetId(switchID(d, radiusServerID(d)))
+
+	return []*schema.ResourceData{d}, nil
+}
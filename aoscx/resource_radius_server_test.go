@@ -0,0 +1,74 @@
+package aoscx
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testRadiusServerPath = "system/vrfs/default/radius_servers/10.0.0.10%2C1812%2Cudp"
+
+func testRadiusServerConfig(m *mockSwitch, body string) string {
+	return testProviderConfig(m) + `
+resource "aoscx_radius_server" "test" {
+  address = "10.0.0.10"
+  key     = "s3cret"
+` + body + `
+}
+`
+}
+
+const testRadiusServerGrouped = `
+  timeout      = 10
+  retries      = 3
+  server_group = aoscx_aaa_server_group.campus.name
+`
+
+const testRadiusServerGroup = `
+resource "aoscx_aaa_server_group" "campus" {
+  name = "CAMPUS"
+}
+`
+
+func TestResourceRadiusServer(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, testRadiusServerPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testRadiusServerConfig(m, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_radius_server.test", "id", "default,10.0.0.10,1812"),
+					resource.TestCheckResourceAttr("aoscx_radius_server.test", "timeout", "5"),
+					resource.TestCheckResourceAttr("aoscx_radius_server.test", "server_group", ""),
+					testCheckMockAttr(m, testRadiusServerPath, "passkey", "s3cret"),
+					testCheckMockAttr(m, testRadiusServerPath, "group", "["+restURI("system/aaa_server_groups/radius")+"]"),
+				),
+			},
+			{
+				Config: testRadiusServerConfig(m, testRadiusServerGrouped) + testRadiusServerGroup,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_radius_server.test", "server_group", "CAMPUS"),
+					testCheckMockAttr(m, testRadiusServerPath, "timeout", "10"),
+					testCheckMockAttr(m, testRadiusServerPath, "group", "["+restURI("system/aaa_server_groups/CAMPUS")+"]"),
+				),
+			},
+			{
+				ResourceName:            "aoscx_radius_server.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"key"},
+			},
+			{
+				PreConfig: func() {
+					m.remove(testRadiusServerPath)
+				},
+				Config:             testRadiusServerConfig(m, testRadiusServerGrouped) + testRadiusServerGroup,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,427 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// routeMapClause maps an attribute of a route map entry to the match or set
+// clause it configures. Number clauses are stored as strings by the switch
+// and are not set when the attribute is 0.
+type routeMapClause struct {
+	Clause string
+	Key    string
+	Number bool
+}
+
+// routeMapClauses are the match and set clauses supported by aoscx_route_map
+// entries, keyed by attribute.
+var routeMapClauses = map[string]routeMapClause{
+	"match_ipv4_prefix_list": {"match", "ipv4_prefix_list", false},
+	"match_ipv6_prefix_list": {"match", "ipv6_prefix_list", false},
+	"match_community_list":   {"match", "community_list", false},
+	"match_tag":              {"match", "tag", true},
+	"set_local_preference":   {"set", "local_preference", true},
+	"set_metric":             {"set", "metric", true},
+	"set_weight":             {"set", "weight", true},
+	"set_tag":                {"set", "tag", true},
+	"set_community":          {"set", "community", false},
+	"set_as_path_prepend":    {"set", "as_path_prepend", false},
+	"set_origin":             {"set", "origin", false},
+	"set_ipv4_next_hop":      {"set", "ipv4_next_hop_address", false},
+}
+
+// bgpWellKnownCommunities are the well-known communities accepted besides
+// AA:NN communities.
+var bgpWellKnownCommunities = []string{"internet", "local-AS", "no-advertise", "no-export"}
+
+// checkCommunities checks a space separated list of communities in AA:NN
+// notation or well-known communities.
+func checkCommunities(communities string) error {
+	fields := strings.Fields(communities)
+	if len(fields) == 0 {
+		return fmt.Errorf("expected at least one community")
+	}
+
+	for _, community := range fields {
+		well_known := false
+		for _, name := range bgpWellKnownCommunities {
+			well_known = well_known || community == name
+		}
+		if well_known {
+			continue
+		}
+
+		as_number, value, found := strings.Cut(community, ":")
+		as_number_int, as_err := strconv.Atoi(as_number)
+		value_int, value_err := strconv.Atoi(value)
+		if !found || as_err != nil || value_err != nil || as_number_int < 0 || as_number_int > 65535 || value_int < 0 || value_int > 65535 {
+			return fmt.Errorf("invalid community %q, expected AA:NN or one of %s", community, strings.Join(bgpWellKnownCommunities, ", "))
+		}
+	}
+
+	return nil
+}
+
+func resourceRouteMap() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Resource to configure route maps on AOS-CX switches.",
+		CreateContext: resourceRouteMapCreate,
+		ReadContext:   resourceRouteMapRead,
+		UpdateContext: resourceRouteMapUpdate,
+		DeleteContext: resourceRouteMapDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRouteMapImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		CustomizeDiff: resourceRouteMapCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"switch": switchSchema(),
+			"name":   policyNameSchema("Name of the route map"),
+			"entry": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Entries of the route map, evaluated in the order of their sequence number. An entry matches when all of its match clauses match",
+				Elem: &schema.Resource{
+					Schema: policyEntrySchema(map[string]*schema.Schema{
+						"description": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringLenBetween(1, 80),
+							Description:  "Description of the entry",
+						},
+						"match_ipv4_prefix_list": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+							Description:  "Match routes permitted by the IPv4 prefix list, reference aoscx_prefix_list.<name>.name so it is created first",
+						},
+						"match_ipv6_prefix_list": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+							Description:  "Match routes permitted by the IPv6 prefix list, reference aoscx_prefix_list.<name>.name so it is created first",
+						},
+						"match_community_list": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+							Description:  "Match routes permitted by the community list, reference aoscx_community_list.<name>.name so it is created first",
+						},
+						"match_tag": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "Match routes with the tag, 0 does not match on the tag",
+						},
+						"set_local_preference": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "Set the BGP local preference, 0 leaves it unchanged",
+						},
+						"set_metric": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "Set the metric or BGP MED, 0 leaves it unchanged",
+						},
+						"set_weight": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntBetween(0, 65535),
+							Description:  "Set the BGP weight, 0 leaves it unchanged",
+						},
+						"set_tag": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "Set the route tag, 0 leaves it unchanged",
+						},
+						"set_community": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+							Description:  "Set the BGP communities, separated by spaces in AA:NN notation or well-known communities such as no-export",
+						},
+						"set_as_path_prepend": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+							Description:  "AS numbers prepended to the AS path, separated by spaces",
+						},
+						"set_origin": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"igp", "egp", "incomplete"}, false),
+							Description:  "Set the BGP origin, either igp, egp or incomplete",
+						},
+						"set_ipv4_next_hop": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsIPv4Address,
+							Description:  "Set the IPv4 next hop",
+						},
+					}),
+				},
+			},
+		},
+	}
+}
+
+// routeMapClauseValue returns the REST value of the clause configured by
+// attribute of entry, empty when the clause is not set.
+func routeMapClauseValue(entry map[string]interface{}, attribute string) string {
+	if routeMapClauses[attribute].Number {
+		if value := entry[attribute].(int); value != 0 {
+			return strconv.Itoa(value)
+		}
+		return ""
+	}
+
+	return entry[attribute].(string)
+}
+
+// resourceRouteMapCustomizeDiff checks the sequence numbers and set clauses
+// of the entries.
+func resourceRouteMapCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	entries := d.Get("entry").(*schema.Set).List()
+
+	if err := checkPolicySequences(entries); err != nil {
+		return err
+	}
+
+	for _, item := range entries {
+		entry := item.(map[string]interface{})
+		sequence := entry["sequence"].(int)
+
+		if entry["action"].(string) == "deny" {
+			for attribute, clause := range routeMapClauses {
+				if clause.Clause == "set" && routeMapClauseValue(entry, attribute) != "" {
+					return fmt.Errorf("entry %d: %s has no effect on deny entries", sequence, attribute)
+				}
+			}
+		}
+
+		if communities := entry["set_community"].(string); communities != "" {
+			if err := checkCommunities(communities); err != nil {
+				return fmt.Errorf("entry %d: set_community: %v", sequence, err)
+			}
+		}
+
+		for _, as_number := range strings.Fields(entry["set_as_path_prepend"].(string)) {
+			asn, err := strconv.ParseInt(as_number, 10, 64)
+			if err != nil || asn < 1 || asn > maxBgpAsn {
+				return fmt.Errorf("entry %d: invalid AS number %q in set_as_path_prepend", sequence, as_number)
+			}
+		}
+	}
+
+	return nil
+}
+
+func routeMapFromResourceData(d *schema.ResourceData) routeMap {
+	tmp_map := routeMap{
+		Name:    d.Get("name").(string),
+		Entries: []routeMapEntry{},
+	}
+
+	for _, item := range d.Get("entry").(*schema.Set).List() {
+		entry := item.(map[string]interface{})
+
+		tmp_entry := routeMapEntry{
+			Preference:  entry["sequence"].(int),
+			Action:      entry["action"].(string),
+			Description: entry["description"].(string),
+			Match:       map[string]string{},
+			Set:         map[string]string{},
+		}
+
+		for attribute, clause := range routeMapClauses {
+			value := routeMapClauseValue(entry, attribute)
+			if value == "" {
+				continue
+			}
+			if clause.Clause == "match" {
+				tmp_entry.Match[clause.Key] = value
+			} else {
+				tmp_entry.Set[clause.Key] = value
+			}
+		}
+
+		tmp_map.Entries = append(tmp_map.Entries, tmp_entry)
+	}
+
+	sort.Slice(tmp_map.Entries, func(i, j int) bool {
+		return tmp_map.Entries[i].Preference < tmp_map.Entries[j].Preference
+	})
+
+	return tmp_map
+}
+
+// flattenRouteMapEntry returns the attributes of a route map entry read from
+// the switch. Clauses not supported by aoscx_route_map are ignored.
+func flattenRouteMapEntry(tmp_entry routeMapEntry) map[string]interface{} {
+	entry := map[string]interface{}{
+		"sequence":    tmp_entry.Preference,
+		"action":      tmp_entry.Action,
+		"description": tmp_entry.Description,
+	}
+
+	for attribute, clause := range routeMapClauses {
+		value := tmp_entry.Match[clause.Key]
+		if clause.Clause == "set" {
+			value = tmp_entry.Set[clause.Key]
+		}
+
+		if clause.Number {
+			entry[attribute], _ = strconv.Atoi(value)
+		} else {
+			entry[attribute] = value
+		}
+	}
+
+	return entry
+}
+
+func resourceRouteMapCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_map := routeMapFromResourceData(d)
+
+	created, err := tmp_map.Create(ctx, sw)
+
+	if requestFailed(err) {
+		return append(diags, policyCreateDiagnostics(d, created, tmp_map.Name, "Error in Creating Route Map", err)...)
+	}
+
+	d.SetId(switchID(d, tmp_map.Name))
+
+	return resourceRouteMapRead(ctx, d, m)
+}
+
+func resourceRouteMapRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Retrieve route map from sw if existing
+	tmp_map := routeMap{
+		Name: d.Get("name").(string),
+	}
+
+	err = tmp_map.Get(ctx, sw)
+
+	if isNotFound(err) {
+		// Route map was removed outside of Terraform
+		d.SetId("")
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Route Map Not Found",
+			Detail:   tmp_map.Name,
+		})
+		return diags
+	} else if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving Route Map", err, nil)...)
+		return diags
+	}
+
+	entries := []interface{}{}
+	for _, tmp_entry := range tmp_map.Entries {
+		entries = append(entries, flattenRouteMapEntry(tmp_entry))
+	}
+
+	d.Set("entry", entries)
+
+	return diags
+}
+
+func resourceRouteMapUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_map := routeMapFromResourceData(d)
+
+	err = tmp_map.Update(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Updating Route Map does not exist", err, cty.GetAttrPath("name"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Updating Route Map", err, nil)...)
+		return diags
+	}
+
+	return resourceRouteMapRead(ctx, d, m)
+}
+
+func resourceRouteMapDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_map := routeMapFromResourceData(d)
+
+	err = tmp_map.Delete(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Deleting Route Map does not exist", err, cty.GetAttrPath("name"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Deleting Route Map", err, nil)...)
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceRouteMapImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is the name of the route map, optionally followed by
+	// @switch, e.g.
+	// terraform import aoscx_route_map.to_spine TO-SPINE
+	name, switch_name := parseSwitchID(d.Id())
+	if name == "" {
+		return nil, fmt.Errorf("Invalid import ID %q, expected <name>", d.Id())
+	}
+
+	d.Set("switch", switch_name)
+	d.Set("name", name)
+	d.SetId(switchID(d, name))
+
+	return []*schema.ResourceData{d}, nil
+}
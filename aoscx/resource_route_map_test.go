@@ -0,0 +1,210 @@
+package aoscx
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testRouteMapPath = "system/route_maps/FROM-SPINE"
+
+func testRouteMapConfig(m *mockSwitch, entries string) string {
+	return testProviderConfig(m) + `
+resource "aoscx_prefix_list" "test" {
+  name = "LOOPBACKS"
+
+  entry {
+    sequence = 10
+    action   = "permit"
+    prefix   = "10.255.0.0/16"
+    ge       = 32
+  }
+}
+
+resource "aoscx_community_list" "test" {
+  name = "BACKUP"
+
+  entry {
+    sequence  = 10
+    action    = "permit"
+    community = "65000:200"
+  }
+}
+
+resource "aoscx_route_map" "test" {
+  name = "FROM-SPINE"
+` + entries + `
+}
+`
+}
+
+const testRouteMapEntries = `
+  entry {
+    sequence               = 10
+    action                 = "permit"
+    description            = "Spine loopbacks"
+    match_ipv4_prefix_list = aoscx_prefix_list.test.name
+    set_local_preference   = 200
+    set_community          = "65001:100 no-export"
+  }
+
+  entry {
+    sequence = 100
+    action   = "deny"
+  }
+`
+
+const testRouteMapEntriesUpdated = `
+  entry {
+    sequence               = 10
+    action                 = "permit"
+    description            = "Spine loopbacks"
+    match_ipv4_prefix_list = aoscx_prefix_list.test.name
+    set_local_preference   = 300
+    set_community          = "65001:100 no-export"
+  }
+
+  entry {
+    sequence             = 20
+    action               = "permit"
+    match_community_list = aoscx_community_list.test.name
+    set_local_preference = 50
+    set_as_path_prepend  = "65001 65001"
+  }
+
+  entry {
+    sequence = 100
+    action   = "deny"
+  }
+`
+
+func TestResourceRouteMap(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, testRouteMapPath),
+		Steps: []resource.TestStep{
+			{
+				Config: testRouteMapConfig(m, testRouteMapEntries),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_route_map.test", "id", "FROM-SPINE"),
+					resource.TestCheckResourceAttr("aoscx_route_map.test", "entry.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("aoscx_route_map.test", "entry.*", map[string]string{
+						"sequence":               "10",
+						"description":            "Spine loopbacks",
+						"match_ipv4_prefix_list": "LOOPBACKS",
+						"set_local_preference":   "200",
+						"set_community":          "65001:100 no-export",
+						"set_metric":             "0",
+					}),
+					testCheckMockAttr(m, testRouteMapPath+"/route_map_entries/10", "match", "map[ipv4_prefix_list:LOOPBACKS]"),
+					testCheckMockAttr(m, testRouteMapPath+"/route_map_entries/10", "set", "map[community:65001:100 no-export local_preference:200]"),
+					testCheckMockExists(m, testRouteMapPath+"/route_map_entries/100"),
+				),
+			},
+			{
+				Config: testRouteMapConfig(m, testRouteMapEntriesUpdated),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_route_map.test", "entry.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs("aoscx_route_map.test", "entry.*", map[string]string{
+						"sequence":             "20",
+						"match_community_list": "BACKUP",
+						"set_local_preference": "50",
+						"set_as_path_prepend":  "65001 65001",
+					}),
+					testCheckMockAttr(m, testRouteMapPath+"/route_map_entries/10", "set", "map[community:65001:100 no-export local_preference:300]"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_route_map.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					m.patch(testRouteMapPath+"/route_map_entries/20", map[string]interface{}{
+						"set": map[string]interface{}{"local_preference": "60"},
+					})
+				},
+				Config:             testRouteMapConfig(m, testRouteMapEntriesUpdated),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testRouteMapConfig(m, testRouteMapEntriesUpdated),
+			},
+			{
+				PreConfig: func() {
+					m.remove(testRouteMapPath + "/route_map_entries/100")
+				},
+				Config:             testRouteMapConfig(m, testRouteMapEntriesUpdated),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				PreConfig: func() {
+					m.remove(testRouteMapPath)
+				},
+				Config:             testRouteMapConfig(m, testRouteMapEntriesUpdated),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceRouteMapInvalid(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testRouteMapConfig(m, `
+  entry {
+    sequence = 10
+    action   = "permit"
+  }
+
+  entry {
+    sequence = 10
+    action   = "deny"
+  }
+`),
+				ExpectError: regexp.MustCompile(`entry sequence 10 is used by more than one entry`),
+			},
+			{
+				Config: testRouteMapConfig(m, `
+  entry {
+    sequence   = 10
+    action     = "deny"
+    set_metric = 10
+  }
+`),
+				ExpectError: regexp.MustCompile(`set_metric has no effect on deny entries`),
+			},
+			{
+				Config: testRouteMapConfig(m, `
+  entry {
+    sequence      = 10
+    action        = "permit"
+    set_community = "65001:100000"
+  }
+`),
+				ExpectError: regexp.MustCompile(`invalid community "65001:100000"`),
+			},
+			{
+				Config: testRouteMapConfig(m, `
+  entry {
+    sequence            = 10
+    action              = "permit"
+    set_as_path_prepend = "65001 0"
+  }
+`),
+				ExpectError: regexp.MustCompile(`invalid AS number "0" in set_as_path_prepend`),
+			},
+		},
+	})
+}
@@ -0,0 +1,286 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// spanningTreeID is the ID of the spanning tree of a switch, a switch has a
+// single spanning tree configuration.
+const spanningTreeID = "spanning-tree"
+
+func resourceSpanningTree() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Resource to enable and configure spanning tree on AOS-CX switches, destroying it disables spanning tree.",
+		CreateContext: resourceSpanningTreeCreate,
+		ReadContext:   resourceSpanningTreeRead,
+		UpdateContext: resourceSpanningTreeUpdate,
+		DeleteContext: resourceSpanningTreeDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSpanningTreeImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		CustomizeDiff: resourceSpanningTreeCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"switch": switchSchema(),
+			"mode": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "mstp",
+				ValidateFunc: validation.StringInSlice([]string{"mstp", "rpvst"}, false),
+				Description:  "Spanning tree mode, either mstp or rpvst",
+			},
+			"priority": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      8,
+				ValidateFunc: validation.IntBetween(0, 15),
+				Description:  "Bridge priority multiplier, the bridge priority is 4096 times this value",
+			},
+			"config_name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(1, 32),
+				Description:  "MST region name, defaults to the system MAC address",
+			},
+			"config_revision": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntBetween(0, 65535),
+				Description:  "MST region revision number",
+			},
+			"instance": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "MST instances, VLANs not mapped to an instance belong to the common and internal spanning tree",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(1, 64),
+							Description:  "MST instance ID",
+						},
+						"vlans": &schema.Schema{
+							Type:        schema.TypeSet,
+							Required:    true,
+							Description: "VLANs mapped to the instance",
+							Elem: &schema.Schema{
+								Type:         schema.TypeInt,
+								ValidateFunc: validation.IntBetween(1, 4094),
+							},
+						},
+						"priority": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      8,
+							ValidateFunc: validation.IntBetween(0, 15),
+							Description:  "Bridge priority multiplier of the instance, the bridge priority is 4096 times this value",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceSpanningTreeCustomizeDiff checks that MST settings are only used
+// in mstp mode and that each VLAN is mapped to one instance.
+func resourceSpanningTreeCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	instances := d.Get("instance").(*schema.Set).List()
+
+	if d.Get("mode").(string) != "mstp" {
+		if len(instances) > 0 {
+			return fmt.Errorf("instance is only supported in mstp mode")
+		}
+		if d.Get("config_name").(string) != "" || d.Get("config_revision").(int) != 0 {
+			return fmt.Errorf("config_name and config_revision are only supported in mstp mode")
+		}
+	}
+
+	instance_ids := map[int]bool{}
+	vlan_instances := map[int]int{}
+	for _, item := range instances {
+		instance := item.(map[string]interface{})
+		instance_id := instance["id"].(int)
+		if instance_id == 0 {
+			continue
+		}
+		if instance_ids[instance_id] {
+			return fmt.Errorf("instance %d is listed more than once", instance_id)
+		}
+		instance_ids[instance_id] = true
+
+		for _, vlan := range instance["vlans"].(*schema.Set).List() {
+			vlan_id := vlan.(int)
+			if other_id, ok := vlan_instances[vlan_id]; ok {
+				return fmt.Errorf("VLAN %d is mapped to instances %d and %d", vlan_id, other_id, instance_id)
+			}
+			vlan_instances[vlan_id] = instance_id
+		}
+	}
+
+	return nil
+}
+
+func spanningTreeFromResourceData(d *schema.ResourceData) spanningTree {
+	tmp_stp := spanningTree{
+		Mode:           d.Get("mode").(string),
+		Priority:       d.Get("priority").(int),
+		ConfigName:     d.Get("config_name").(string),
+		ConfigRevision: d.Get("config_revision").(int),
+		Instances:      []mstInstance{},
+	}
+
+	for _, item := range d.Get("instance").(*schema.Set).List() {
+		instance := item.(map[string]interface{})
+		tmp_instance := mstInstance{
+			Id:       instance["id"].(int),
+			Vlans:    []int{},
+			Priority: instance["priority"].(int),
+		}
+		for _, vlan := range instance["vlans"].(*schema.Set).List() {
+			tmp_instance.Vlans = append(tmp_instance.Vlans, vlan.(int))
+		}
+		sort.Ints(tmp_instance.Vlans)
+		tmp_stp.Instances = append(tmp_stp.Instances, tmp_instance)
+	}
+
+	return tmp_stp
+}
+
+func resourceSpanningTreeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_stp := spanningTreeFromResourceData(d)
+
+	err = tmp_stp.Update(ctx, sw)
+
+	if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Creating Spanning Tree", err, nil)...)
+		return diags
+	}
+
+	d.SetId(switchID(d, spanningTreeID))
+
+	return resourceSpanningTreeRead(ctx, d, m)
+}
+
+func resourceSpanningTreeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Retrieve spanning tree from sw if enabled
+	tmp_stp := spanningTree{}
+
+	enabled, err := tmp_stp.Get(ctx, sw)
+
+	if err == nil && !enabled {
+		// Spanning tree was disabled outside of Terraform
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Spanning Tree Not Found",
+			Detail:   d.Id(),
+		})
+		d.SetId("")
+		return diags
+	} else if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving Spanning Tree", err, nil)...)
+		return diags
+	}
+
+	instances := []interface{}{}
+	for _, instance := range tmp_stp.Instances {
+		instances = append(instances, map[string]interface{}{
+			"id":       instance.Id,
+			"vlans":    instance.Vlans,
+			"priority": instance.Priority,
+		})
+	}
+
+	d.Set("mode", tmp_stp.Mode)
+	d.Set("priority", tmp_stp.Priority)
+	d.Set("config_name", tmp_stp.ConfigName)
+	d.Set("config_revision", tmp_stp.ConfigRevision)
+	d.Set("instance", instances)
+
+	return diags
+}
+
+func resourceSpanningTreeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_stp := spanningTreeFromResourceData(d)
+
+	err = tmp_stp.Update(ctx, sw)
+
+	if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Updating Spanning Tree", err, nil)...)
+		return diags
+	}
+
+	return resourceSpanningTreeRead(ctx, d, m)
+}
+
+func resourceSpanningTreeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_stp := spanningTree{}
+
+	err = tmp_stp.Delete(ctx, sw)
+
+	if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Deleting Spanning Tree", err, nil)...)
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceSpanningTreeImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is spanning-tree, optionally followed by @switch, e.g.
+	// terraform import aoscx_spanning_tree.stp spanning-tree@leaf1
+	id, switch_name := parseSwitchID(d.Id())
+	if id != spanningTreeID {
+		return nil, fmt.Errorf("Invalid import ID %q, expected %s", d.Id(), spanningTreeID)
+	}
+
+	d.Set("switch", switch_name)
+	d.SetId(switchID(d, spanningTreeID))
+
+	return []*schema.ResourceData{d}, nil
+}
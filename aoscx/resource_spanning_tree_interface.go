@@ -0,0 +1,237 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceSpanningTreeInterface() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Resource to configure spanning tree on ports of AOS-CX switches, destroying it restores the defaults of the port.",
+		CreateContext: resourceSpanningTreeInterfaceCreate,
+		ReadContext:   resourceSpanningTreeInterfaceRead,
+		UpdateContext: resourceSpanningTreeInterfaceUpdate,
+		DeleteContext: resourceSpanningTreeInterfaceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSpanningTreeInterfaceImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		CustomizeDiff: resourceSpanningTreeInterfaceCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"switch": switchSchema(),
+			"interface": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Layer 2 port, e.g. 1/1/1 of an aoscx_l2_interface or lag1 of an aoscx_lag",
+			},
+			"admin_edge": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Treat the port as an edge port connected to a host, it forwards immediately when it comes up",
+			},
+			"bpdu_guard": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Disable the port when it receives a BPDU",
+			},
+			"root_guard": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Block the port when it receives a superior BPDU, so the root bridge is never reached through it",
+			},
+			"loop_guard": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Block the port when it stops receiving BPDUs instead of moving it to forwarding",
+			},
+			"cost": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntBetween(0, 200000000),
+				Description:  "Path cost of the port, 0 derives it from the port speed",
+			},
+			"port_priority": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      8,
+				ValidateFunc: validation.IntBetween(0, 15),
+				Description:  "Port priority multiplier, the port priority is 16 times this value",
+			},
+		},
+	}
+}
+
+// resourceSpanningTreeInterfaceCustomizeDiff rejects combinations of port
+// protections the switch does not accept.
+func resourceSpanningTreeInterfaceCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Get("root_guard").(bool) && d.Get("loop_guard").(bool) {
+		return fmt.Errorf("root_guard and loop_guard cannot both be enabled")
+	}
+	if d.Get("admin_edge").(bool) && d.Get("loop_guard").(bool) {
+		return fmt.Errorf("loop_guard cannot be enabled on an admin_edge port")
+	}
+
+	return nil
+}
+
+func spanningTreeInterfaceFromResourceData(d *schema.ResourceData) spanningTreeInterface {
+	return spanningTreeInterface{
+		Name:         d.Get("interface").(string),
+		AdminEdge:    d.Get("admin_edge").(bool),
+		BpduGuard:    d.Get("bpdu_guard").(bool),
+		RootGuard:    d.Get("root_guard").(bool),
+		LoopGuard:    d.Get("loop_guard").(bool),
+		Cost:         d.Get("cost").(int),
+		PortPriority: d.Get("port_priority").(int),
+	}
+}
+
+func resourceSpanningTreeInterfaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_interface := spanningTreeInterfaceFromResourceData(d)
+
+	err = tmp_interface.Update(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Creating Spanning Tree Interface interface does not exist", err, cty.GetAttrPath("interface"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Creating Spanning Tree Interface", err, nil)...)
+		return diags
+	}
+
+	d.SetId(switchID(d, tmp_interface.Name))
+
+	return resourceSpanningTreeInterfaceRead(ctx, d, m)
+}
+
+func resourceSpanningTreeInterfaceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Retrieve spanning tree configuration of the port from sw if existing
+	tmp_interface := spanningTreeInterface{
+		Name: d.Get("interface").(string),
+	}
+
+	err = tmp_interface.Get(ctx, sw)
+
+	if isNotFound(err) {
+		// Interface was removed outside of Terraform
+		d.SetId("")
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Spanning Tree Interface Not Found",
+			Detail:   tmp_interface.Name,
+		})
+		return diags
+	} else if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving Spanning Tree Interface", err, nil)...)
+		return diags
+	}
+
+	d.Set("admin_edge", tmp_interface.AdminEdge)
+	d.Set("bpdu_guard", tmp_interface.BpduGuard)
+	d.Set("root_guard", tmp_interface.RootGuard)
+	d.Set("loop_guard", tmp_interface.LoopGuard)
+	d.Set("cost", tmp_interface.Cost)
+	d.Set("port_priority", tmp_interface.PortPriority)
+
+	return diags
+}
+
+func resourceSpanningTreeInterfaceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_interface := spanningTreeInterfaceFromResourceData(d)
+
+	err = tmp_interface.Update(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Updating Spanning Tree Interface does not exist", err, cty.GetAttrPath("interface"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Updating Spanning Tree Interface", err, nil)...)
+		return diags
+	}
+
+	return resourceSpanningTreeInterfaceRead(ctx, d, m)
+}
+
+func resourceSpanningTreeInterfaceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_interface := spanningTreeInterfaceFromResourceData(d)
+
+	err = tmp_interface.Delete(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Deleting Spanning Tree Interface does not exist", err, cty.GetAttrPath("interface"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Deleting Spanning Tree Interface", err, nil)...)
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceSpanningTreeInterfaceImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is the interface name, optionally followed by @switch, e.g.
+	// terraform import aoscx_spanning_tree_interface.int_1_1_1 1/1/1
+	name, switch_name := parseSwitchID(d.Id())
+	if name == "" {
+		return nil, fmt.Errorf("Invalid import ID %q, expected <interface>", d.Id())
+	}
+
+	d.Set("switch", switch_name)
+	d.Set("interface", name)
+	d.SetId(switchID(d, name))
+
+	return []*schema.ResourceData{d}, nil
+}
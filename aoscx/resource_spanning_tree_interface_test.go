@@ -0,0 +1,94 @@
+package aoscx
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testSpanningTreeInterfaceConfig(m *mockSwitch, body string) string {
+	return testProviderConfig(m) + `
+resource "aoscx_spanning_tree_interface" "test" {
+  interface = "1/1/1"
+` + body + `
+}
+`
+}
+
+func TestResourceSpanningTreeInterface(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockAttr(m, "system/interfaces/1%2F1%2F1", "stp_config", "map[]"),
+		Steps: []resource.TestStep{
+			{
+				Config: testSpanningTreeInterfaceConfig(m, `
+  admin_edge = true
+  bpdu_guard = true
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_spanning_tree_interface.test", "id", "1/1/1"),
+					resource.TestCheckResourceAttr("aoscx_spanning_tree_interface.test", "port_priority", "8"),
+					testCheckMockAttr(m, "system/interfaces/1%2F1%2F1", "stp_config", "map[admin_edge_port_enable:true bpdu_guard_enable:true loop_guard_enable:false port_priority:8 root_guard_enable:false]"),
+				),
+			},
+			{
+				Config: testSpanningTreeInterfaceConfig(m, `
+  root_guard    = true
+  cost          = 2000
+  port_priority = 4
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_spanning_tree_interface.test", "admin_edge", "false"),
+					resource.TestCheckResourceAttr("aoscx_spanning_tree_interface.test", "root_guard", "true"),
+					testCheckMockAttr(m, "system/interfaces/1%2F1%2F1", "stp_config", "map[admin_edge_port_enable:false bpdu_guard_enable:false loop_guard_enable:false path_cost:2000 port_priority:4 root_guard_enable:true]"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_spanning_tree_interface.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					m.patch("system/interfaces/1%2F1%2F1", map[string]interface{}{"stp_config": map[string]interface{}{}})
+				},
+				Config: testSpanningTreeInterfaceConfig(m, `
+  root_guard    = true
+  cost          = 2000
+  port_priority = 4
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceSpanningTreeInterfaceInvalid(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testSpanningTreeInterfaceConfig(m, `
+  root_guard = true
+  loop_guard = true
+`),
+				ExpectError: regexp.MustCompile(`root_guard and loop_guard cannot both be enabled`),
+			},
+			{
+				Config: `
+` + testProviderConfig(m) + `
+resource "aoscx_spanning_tree_interface" "test" {
+  interface = "1/1/99"
+}
+`,
+				ExpectError: regexp.MustCompile(`Error Creating Spanning Tree Interface interface does not exist`),
+			},
+		},
+	})
+}
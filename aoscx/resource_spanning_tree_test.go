@@ -0,0 +1,160 @@
+package aoscx
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testSpanningTreeConfig(m *mockSwitch, body string) string {
+	return testProviderConfig(m) + `
+resource "aoscx_spanning_tree" "test" {
+` + body + `
+}
+`
+}
+
+const testSpanningTreeMstp = `
+  priority        = 4
+  config_name     = "REGION1"
+  config_revision = 2
+
+  instance {
+    id    = 1
+    vlans = [10, 11]
+  }
+
+  instance {
+    id       = 2
+    vlans    = [20]
+    priority = 2
+  }
+`
+
+const testSpanningTreeMstpUpdated = `
+  priority        = 4
+  config_name     = "REGION1"
+  config_revision = 3
+
+  instance {
+    id    = 1
+    vlans = [10, 11, 12]
+  }
+`
+
+func TestResourceSpanningTree(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckMockAttr(m, "system", "stp_config", "map[]"),
+			testCheckMockDestroyed(m, "system/mstp_instances/1"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testSpanningTreeConfig(m, testSpanningTreeMstp),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_spanning_tree.test", "id", "spanning-tree"),
+					resource.TestCheckResourceAttr("aoscx_spanning_tree.test", "mode", "mstp"),
+					resource.TestCheckResourceAttr("aoscx_spanning_tree.test", "instance.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("aoscx_spanning_tree.test", "instance.*", map[string]string{
+						"id":       "2",
+						"vlans.#":  "1",
+						"priority": "2",
+					}),
+					testCheckMockAttr(m, "system", "stp_config", "map[admin_status:up mode:mstp mstp_config_name:REGION1 mstp_config_revision:2 priority:4]"),
+					testCheckMockAttr(m, "system/mstp_instances/1", "vlans", "["+restURI("system/vlans/10")+" "+restURI("system/vlans/11")+"]"),
+					testCheckMockAttr(m, "system/mstp_instances/2", "priority", "2"),
+				),
+			},
+			{
+				Config: testSpanningTreeConfig(m, testSpanningTreeMstpUpdated),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_spanning_tree.test", "instance.#", "1"),
+					testCheckMockAttr(m, "system/mstp_instances/1", "vlans", "["+restURI("system/vlans/10")+" "+restURI("system/vlans/11")+" "+restURI("system/vlans/12")+"]"),
+					testCheckMockDestroyed(m, "system/mstp_instances/2"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_spanning_tree.test",
+				ImportState:       true,
+				ImportStateId:     "spanning-tree",
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					m.patch("system/mstp_instances/1", map[string]interface{}{"priority": 0})
+				},
+				Config:             testSpanningTreeConfig(m, testSpanningTreeMstpUpdated),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				PreConfig: func() {
+					m.patch("system", map[string]interface{}{"stp_config": map[string]interface{}{}})
+				},
+				Config:             testSpanningTreeConfig(m, testSpanningTreeMstpUpdated),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceSpanningTreeRpvst(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testSpanningTreeConfig(m, `
+  mode     = "rpvst"
+  priority = 0
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_spanning_tree.test", "mode", "rpvst"),
+					resource.TestCheckResourceAttr("aoscx_spanning_tree.test", "priority", "0"),
+					testCheckMockAttr(m, "system", "stp_config", "map[admin_status:up mode:rpvst mstp_config_name: mstp_config_revision:0 priority:0]"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceSpanningTreeInvalid(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testSpanningTreeConfig(m, `
+  mode = "rpvst"
+
+  instance {
+    id    = 1
+    vlans = [10]
+  }
+`),
+				ExpectError: regexp.MustCompile(`instance is only supported in mstp mode`),
+			},
+			{
+				Config: testSpanningTreeConfig(m, `
+  instance {
+    id    = 1
+    vlans = [10, 20]
+  }
+
+  instance {
+    id    = 2
+    vlans = [20]
+  }
+`),
+				ExpectError: regexp.MustCompile(`VLAN 20 is mapped to instances`),
+			},
+		},
+	})
+}
@@ -0,0 +1,353 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceStaticRoute() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Resource to configure static routes on AOS-CX switches.",
+		CreateContext: resourceStaticRouteCreate,
+		ReadContext:   resourceStaticRouteRead,
+		UpdateContext: resourceStaticRouteUpdate,
+		DeleteContext: resourceStaticRouteDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceStaticRouteImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"switch": switchSchema(),
+			"vrf": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "default",
+				ForceNew:    true,
+				Description: "VRF of the route, reference aoscx_vrf.<name>.name so the VRF is created first",
+			},
+			"prefix": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDRNetwork,
+				Description:  "Destination prefix of the route in canonical CIDR notation, e.g. 10.0.0.0/24 or 2001:db8::/32",
+			},
+			"next_hop": &schema.Schema{
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Description: "Next-hops of the route",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip_address": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsIPAddress,
+							Description:  "IP address of the next-hop, of the same address family as prefix",
+						},
+						"interface": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Interface traffic is forwarded on, e.g. 1/1/1 or vlan42",
+						},
+						"blackhole": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Drop traffic to prefix, cannot be combined with ip_address or interface",
+						},
+						"distance": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntBetween(1, 255),
+							Description:  "Administrative distance of the next-hop",
+						},
+						"tag": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "Route tag of the next-hop",
+						},
+					},
+				},
+			},
+		},
+		CustomizeDiff: resourceStaticRouteCustomizeDiff,
+	}
+}
+
+// validateCIDRNetwork accepts prefixes in canonical CIDR notation, so the
+// prefix matches the key the switch stores the route under.
+func validateCIDRNetwork(i interface{}, k string) ([]string, []error) {
+	value, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	_, ipnet, err := net.ParseCIDR(value)
+	if err != nil {
+		return nil, []error{fmt.Errorf("expected %s to be a prefix in CIDR notation, got %q", k, value)}
+	}
+	if ipnet.String() != value {
+		return nil, []error{fmt.Errorf("expected %s to be the network address %s, got %q", k, ipnet.String(), value)}
+	}
+
+	return nil, nil
+}
+
+// resourceStaticRouteCustomizeDiff checks that every next-hop has a single
+// kind of target of the address family of prefix and that next-hops are not
+// configured twice.
+func resourceStaticRouteCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("prefix") || !d.NewValueKnown("next_hop") {
+		return nil
+	}
+
+	prefix := d.Get("prefix").(string)
+	ipv6 := strings.Contains(prefix, ":")
+
+	keys := map[string]bool{}
+
+	for _, item := range d.Get("next_hop").(*schema.Set).List() {
+		next_hop := item.(map[string]interface{})
+		ip_address := next_hop["ip_address"].(string)
+		interface_name := next_hop["interface"].(string)
+
+		switch {
+		case next_hop["blackhole"].(bool):
+			if ip_address != "" || interface_name != "" {
+				return fmt.Errorf("a blackhole next-hop cannot have an ip_address or interface")
+			}
+
+		case ip_address == "" && interface_name == "":
+			return fmt.Errorf("a next-hop needs an ip_address, an interface or blackhole set to true")
+
+		case ip_address != "" && strings.Contains(ip_address, ":") != ipv6:
+			return fmt.Errorf("next-hop %s is not of the same address family as prefix %s", ip_address, prefix)
+		}
+
+		tmp_hop := staticNextHopFromMap(next_hop)
+		if keys[tmp_hop.key()] {
+			return fmt.Errorf("duplicate next-hop, next-hops must differ in ip_address, interface or blackhole, distance and tag are set per next-hop")
+		}
+		keys[tmp_hop.key()] = true
+	}
+
+	return nil
+}
+
+// staticNextHopFromMap builds the next-hop described by an element of the
+// next_hop set.
+func staticNextHopFromMap(next_hop map[string]interface{}) staticNextHop {
+	tmp_hop := staticNextHop{
+		Type:      "forward",
+		IpAddress: next_hop["ip_address"].(string),
+		Distance:  next_hop["distance"].(int),
+		Tag:       next_hop["tag"].(int),
+	}
+
+	if next_hop["blackhole"].(bool) {
+		tmp_hop.Type = "blackhole"
+	}
+
+	if interface_name := next_hop["interface"].(string); interface_name != "" {
+		tmp_hop.Port = restURI("system/interfaces/" + restPath(interface_name))
+	}
+
+	return tmp_hop
+}
+
+// staticRouteFromResourceData builds the route described by the
+// configuration of d, its next-hops are checked when planning.
+func staticRouteFromResourceData(d *schema.ResourceData) staticRoute {
+	tmp_route := staticRoute{
+		Vrf:           d.Get("vrf").(string),
+		Prefix:        d.Get("prefix").(string),
+		AddressFamily: "ipv4",
+		Type:          "forward",
+	}
+
+	if strings.Contains(tmp_route.Prefix, ":") {
+		tmp_route.AddressFamily = "ipv6"
+	}
+
+	for _, item := range d.Get("next_hop").(*schema.Set).List() {
+		tmp_route.NextHops = append(tmp_route.NextHops, staticNextHopFromMap(item.(map[string]interface{})))
+	}
+
+	return tmp_route
+}
+
+func resourceStaticRouteCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_route := staticRouteFromResourceData(d)
+
+	created, err := tmp_route.Create(ctx, sw)
+
+	if requestFailed(err) {
+		// A route created without all of its next-hops is kept in state so
+		// it is tainted and replaced on the next apply
+		if created {
+			d.SetId(switchID(d, tmp_route.Vrf+","+tmp_route.Prefix))
+		} else if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Creating Static Route VRF does not exist", err, cty.GetAttrPath("vrf"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Creating Static Route", err, cty.GetAttrPath("prefix"))...)
+		return diags
+	}
+
+	d.SetId(switchID(d, tmp_route.Vrf+","+tmp_route.Prefix))
+
+	return resourceStaticRouteRead(ctx, d, m)
+}
+
+func resourceStaticRouteRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Retrieve route from sw if existing
+	tmp_route := staticRoute{
+		Vrf:    d.Get("vrf").(string),
+		Prefix: d.Get("prefix").(string),
+	}
+
+	err = tmp_route.Get(ctx, sw)
+
+	if isNotFound(err) {
+		// Route was removed outside of Terraform
+		d.SetId("")
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Static Route Not Found",
+			Detail:   tmp_route.Prefix,
+		})
+		return diags
+	} else if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving Static Route", err, nil)...)
+		return diags
+	}
+
+	next_hops := []interface{}{}
+	for _, next_hop := range tmp_route.NextHops {
+		interface_name := ""
+		if next_hop.Port != "" {
+			interface_name = uriKey(next_hop.Port)
+		}
+		next_hops = append(next_hops, map[string]interface{}{
+			"ip_address": next_hop.IpAddress,
+			"interface":  interface_name,
+			"blackhole":  next_hop.Type == "blackhole",
+			"distance":   next_hop.Distance,
+			"tag":        next_hop.Tag,
+		})
+	}
+
+	d.Set("vrf", tmp_route.Vrf)
+	d.Set("prefix", tmp_route.Prefix)
+	d.Set("next_hop", next_hops)
+
+	return diags
+}
+
+func resourceStaticRouteUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_route := staticRouteFromResourceData(d)
+
+	err = tmp_route.Update(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Updating Static Route does not exist", err, cty.GetAttrPath("prefix"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Updating Static Route", err, nil)...)
+		return diags
+	}
+
+	return resourceStaticRouteRead(ctx, d, m)
+}
+
+func resourceStaticRouteDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_route := staticRoute{
+		Vrf:    d.Get("vrf").(string),
+		Prefix: d.Get("prefix").(string),
+	}
+
+	err = tmp_route.Delete(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Deleting Static Route does not exist", err, cty.GetAttrPath("prefix"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Deleting Static Route", err, nil)...)
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceStaticRouteImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is the VRF and prefix separated by a comma, optionally
+	// followed by @switch, e.g.
+	// terraform import aoscx_static_route.default default,0.0.0.0/0
+	import_id, switch_name := parseSwitchID(d.Id())
+	vrf_name, prefix, found := strings.Cut(import_id, ",")
+
+	if !found || vrf_name == "" {
+		return nil, fmt.Errorf("Invalid Static Route import ID %q, expected <vrf>,<prefix>", d.Id())
+	}
+	if _, errs := validateCIDRNetwork(prefix, "prefix"); len(errs) > 0 {
+		return nil, fmt.Errorf("Invalid Static Route import ID %q: %v", d.Id(), errs[0])
+	}
+
+	d.Set("switch", switch_name)
+	d.SetId(switchID(d, vrf_name+","+prefix))
+	d.Set("vrf", vrf_name)
+	d.Set("prefix", prefix)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,235 @@
+package aoscx
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testStaticRoutePath = "system/vrfs/default/static_routes/10.10.0.0%2F16"
+
+func testStaticRouteConfig(m *mockSwitch, next_hops string) string {
+	return testProviderConfig(m) + `
+resource "aoscx_static_route" "test" {
+  prefix = "10.10.0.0/16"
+` + next_hops + `
+}
+`
+}
+
+const testStaticRouteNextHops = `
+  next_hop {
+    ip_address = "192.168.0.1"
+  }
+
+  next_hop {
+    ip_address = "192.168.0.2"
+    distance   = 10
+    tag        = 100
+  }
+`
+
+const testStaticRouteNextHopsUpdated = `
+  next_hop {
+    ip_address = "192.168.0.1"
+    distance   = 5
+  }
+
+  next_hop {
+    interface = "1/1/6"
+    distance  = 20
+  }
+`
+
+func TestResourceStaticRoute(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, testStaticRoutePath),
+		Steps: []resource.TestStep{
+			{
+				Config: testStaticRouteConfig(m, testStaticRouteNextHops),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_static_route.test", "id", "default,10.10.0.0/16"),
+					resource.TestCheckResourceAttr("aoscx_static_route.test", "next_hop.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("aoscx_static_route.test", "next_hop.*", map[string]string{
+						"ip_address": "192.168.0.2",
+						"distance":   "10",
+						"tag":        "100",
+					}),
+					testCheckMockExists(m, testStaticRoutePath+"/static_nexthops/0"),
+					testCheckMockExists(m, testStaticRoutePath+"/static_nexthops/1"),
+				),
+			},
+			{
+				Config: testStaticRouteConfig(m, testStaticRouteNextHopsUpdated),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_static_route.test", "next_hop.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("aoscx_static_route.test", "next_hop.*", map[string]string{
+						"ip_address": "192.168.0.1",
+						"distance":   "5",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("aoscx_static_route.test", "next_hop.*", map[string]string{
+						"interface": "1/1/6",
+						"distance":  "20",
+					}),
+				),
+			},
+			{
+				Config: testStaticRouteConfig(m, `
+  next_hop {
+    blackhole = true
+    distance  = 250
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_static_route.test", "next_hop.#", "1"),
+					resource.TestCheckResourceAttr("aoscx_static_route.test", "next_hop.0.blackhole", "true"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_static_route.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					m.patch(testStaticRoutePath+"/static_nexthops/0", map[string]interface{}{"distance": 1})
+				},
+				Config: testStaticRouteConfig(m, `
+  next_hop {
+    blackhole = true
+    distance  = 250
+  }
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				PreConfig: func() {
+					m.remove(testStaticRoutePath)
+				},
+				Config: testStaticRouteConfig(m, `
+  next_hop {
+    blackhole = true
+    distance  = 250
+  }
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceStaticRouteIPv6(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, "system/vrfs/default/static_routes/2001:db8::%2F32"),
+		Steps: []resource.TestStep{
+			{
+				Config: testProviderConfig(m) + `
+resource "aoscx_static_route" "test" {
+  prefix = "2001:db8::/32"
+
+  next_hop {
+    ip_address = "fe80::1"
+    interface  = "1/1/7"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_static_route.test", "next_hop.0.ip_address", "fe80::1"),
+					resource.TestCheckResourceAttr("aoscx_static_route.test", "next_hop.0.interface", "1/1/7"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceStaticRouteInvalid(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testProviderConfig(m) + `
+resource "aoscx_static_route" "test" {
+  prefix = "10.10.0.1/16"
+
+  next_hop {
+    ip_address = "192.168.0.1"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`expected prefix to be the network address 10.10.0.0/16`),
+			},
+			{
+				Config: testStaticRouteConfig(m, `
+  next_hop {
+    ip_address = "2001:db8::1"
+  }
+`),
+				ExpectError: regexp.MustCompile(`not of the same address family`),
+			},
+			{
+				Config: testStaticRouteConfig(m, `
+  next_hop {
+    ip_address = "192.168.0.1"
+    blackhole  = true
+  }
+`),
+				ExpectError: regexp.MustCompile(`blackhole next-hop cannot have an ip_address`),
+			},
+			{
+				Config: testStaticRouteConfig(m, `
+  next_hop {
+    distance = 10
+  }
+`),
+				ExpectError: regexp.MustCompile(`a next-hop needs an ip_address, an interface or blackhole set to true`),
+			},
+			{
+				Config: testStaticRouteConfig(m, `
+  next_hop {
+    ip_address = "192.168.0.1"
+  }
+
+  next_hop {
+    ip_address = "192.168.0.1"
+    distance   = 10
+  }
+`),
+				ExpectError: regexp.MustCompile(`duplicate next-hop`),
+			},
+		},
+	})
+}
+
+func TestResourceStaticRouteMissingVrf(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testProviderConfig(m) + `
+resource "aoscx_static_route" "test" {
+  vrf    = "missing"
+  prefix = "10.10.0.0/16"
+
+  next_hop {
+    ip_address = "192.168.0.1"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`VRF does not exist`),
+			},
+		},
+	})
+}
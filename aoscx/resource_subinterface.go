@@ -0,0 +1,260 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceSubinterface() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Resource to configure routed 802.1Q sub-interfaces of ports on AOS-CX switches.",
+		CreateContext: resourceSubinterfaceCreate,
+		ReadContext:   resourceSubinterfaceRead,
+		UpdateContext: resourceSubinterfaceUpdate,
+		DeleteContext: resourceSubinterfaceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSubinterfaceImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"switch": switchSchema(),
+			"parent": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.All(validation.StringIsNotWhiteSpace, validation.StringDoesNotContainAny(".")),
+				Description:  "Routed port the sub-interface is created on, reference aoscx_l3_interface.<name>.interface so the port is configured first and the sub-interface destroyed first",
+			},
+			"vlan_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(1, 4094),
+				Description:  "802.1Q VLAN ID encapsulated by the sub-interface, the sub-interface is named <parent>.<vlan_id>",
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"admin_state": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "up",
+				ValidateFunc: validation.StringInSlice([]string{"up", "down"}, true),
+			},
+			"ipv4": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "IPv4 addresses in CIDR notation, e.g. 192.0.2.1/30. The first address is the primary address",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.All(validation.IsCIDR, validation.StringDoesNotContainAny(":")),
+				},
+			},
+			"ipv6": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "IPv6 addresses in CIDR notation, e.g. 2001:db8:100::1/64",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.All(validation.IsCIDR, validation.StringMatch(regexp.MustCompile(`:`), "expected an IPv6 address")),
+				},
+			},
+			"vrf": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "default",
+				Description: "VRF the sub-interface is attached to, reference aoscx_vrf.<name>.name so the VRF is created first",
+			},
+			"mtu": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1500,
+				ValidateFunc: validation.IntBetween(68, 9198),
+				Description:  "IP MTU of the sub-interface, it cannot exceed the MTU of the parent port",
+			},
+		},
+	}
+}
+
+// subinterfaceID returns the name of the sub-interface selected on d, e.g.
+// 1/1/49.100.
+func subinterfaceID(d *schema.ResourceData) string {
+	return subinterfaceName(d.Get("parent").(string), d.Get("vlan_id").(int))
+}
+
+func subinterfaceFromResourceData(d *schema.ResourceData) subinterface {
+	tmp_subinterface := subinterface{
+		Parent:      d.Get("parent").(string),
+		VlanId:      d.Get("vlan_id").(int),
+		Description: d.Get("description").(string),
+		AdminState:  strings.ToLower(d.Get("admin_state").(string)),
+		Ipv4:        []string{},
+		Ipv6:        sortedStrings(d.Get("ipv6").(*schema.Set)),
+		Vrf:         d.Get("vrf").(string),
+		Mtu:         d.Get("mtu").(int),
+	}
+
+	for _, address := range d.Get("ipv4").([]interface{}) {
+		tmp_subinterface.Ipv4 = append(tmp_subinterface.Ipv4, address.(string))
+	}
+
+	return tmp_subinterface
+}
+
+func resourceSubinterfaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_subinterface := subinterfaceFromResourceData(d)
+
+	created, err := tmp_subinterface.Create(ctx, sw)
+
+	if requestFailed(err) {
+		// A subinterface created without all of its addresses is kept in
+		// state so it is tainted and replaced on the next apply
+		if created {
+			d.SetId(switchID(d, subinterfaceID(d)))
+		} else if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Creating Subinterface parent interface does not exist", err, cty.GetAttrPath("parent"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Creating Subinterface", err, cty.GetAttrPath("parent"))...)
+		return diags
+	}
+
+	d.SetId(switchID(d, subinterfaceID(d)))
+
+	return resourceSubinterfaceRead(ctx, d, m)
+}
+
+func resourceSubinterfaceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Retrieve sub-interface from sw if existing
+	tmp_subinterface := subinterface{
+		Parent: d.Get("parent").(string),
+		VlanId: d.Get("vlan_id").(int),
+	}
+
+	err = tmp_subinterface.Get(ctx, sw)
+
+	if isNotFound(err) {
+		// Sub-interface was removed outside of Terraform
+		d.SetId("")
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Subinterface Not Found",
+			Detail:   subinterfaceID(d),
+		})
+		return diags
+	} else if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving Subinterface", err, nil)...)
+		return diags
+	}
+
+	d.Set("description", tmp_subinterface.Description)
+	d.Set("admin_state", tmp_subinterface.AdminState)
+	d.Set("ipv4", tmp_subinterface.Ipv4)
+	d.Set("ipv6", tmp_subinterface.Ipv6)
+	d.Set("vrf", tmp_subinterface.Vrf)
+	d.Set("mtu", tmp_subinterface.Mtu)
+
+	return diags
+}
+
+func resourceSubinterfaceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_subinterface := subinterfaceFromResourceData(d)
+
+	err = tmp_subinterface.Update(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Updating Subinterface does not exist", err, cty.GetAttrPath("vlan_id"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Updating Subinterface", err, nil)...)
+		return diags
+	}
+
+	return resourceSubinterfaceRead(ctx, d, m)
+}
+
+func resourceSubinterfaceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_subinterface := subinterfaceFromResourceData(d)
+
+	err = tmp_subinterface.Delete(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Deleting Subinterface does not exist", err, cty.GetAttrPath("vlan_id"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Deleting Subinterface", err, nil)...)
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceSubinterfaceImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is the sub-interface name optionally followed by @switch, e.g.
+	// terraform import aoscx_subinterface.wan_100 1/1/49.100
+	name, switch_name := parseSwitchID(d.Id())
+	dot := strings.LastIndex(name, ".")
+	if dot < 1 {
+		return nil, fmt.Errorf("Invalid import ID %q, expected <parent>.<vlan_id>", d.Id())
+	}
+	vlan_id, err := strconv.Atoi(name[dot+1:])
+	if err != nil || vlan_id < 1 || vlan_id > 4094 {
+		return nil, fmt.Errorf("Invalid import ID %q, VLAN ID must be between 1 and 4094", d.Id())
+	}
+
+	d.Set("switch", switch_name)
+	d.Set("parent", name[:dot])
+	d.Set("vlan_id", vlan_id)
+	d.SetId(switchID(d, subinterfaceID(d)))
+
+	return []*schema.ResourceData{d}, nil
+}
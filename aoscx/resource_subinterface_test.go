@@ -0,0 +1,116 @@
+package aoscx
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testSubinterfaceConfig(m *mockSwitch, body string) string {
+	return testProviderConfig(m) + `
+resource "aoscx_vrf" "test" {
+  name = "wan"
+}
+
+resource "aoscx_subinterface" "test" {
+  parent  = "1/1/5"
+  vlan_id = 100
+` + body + `
+}
+`
+}
+
+const testSubinterface = `
+  description = "ISP handoff"
+  ipv4        = ["192.0.2.1/30"]
+  ipv6        = ["2001:db8:100::1/64"]
+`
+
+const testSubinterfaceVrf = `
+  admin_state = "down"
+  ipv4        = ["192.0.2.5/30"]
+  vrf         = aoscx_vrf.test.name
+  mtu         = 9000
+`
+
+func TestResourceSubinterface(t *testing.T) {
+	m := newMockSwitch(t)
+	m.patch("system/interfaces/1%2F1%2F5", map[string]interface{}{"routing": true})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, "system/interfaces/1%2F1%2F5.100"),
+		Steps: []resource.TestStep{
+			{
+				Config: testSubinterfaceConfig(m, testSubinterface),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_subinterface.test", "id", "1/1/5.100"),
+					resource.TestCheckResourceAttr("aoscx_subinterface.test", "mtu", "1500"),
+					resource.TestCheckResourceAttr("aoscx_subinterface.test", "vrf", "default"),
+					testCheckMockAttr(m, "system/interfaces/1%2F1%2F5.100", "subintf_vlan", "100"),
+					testCheckMockAttr(m, "system/interfaces/1%2F1%2F5.100", "ip4_address", "192.0.2.1/30"),
+					testCheckMockExists(m, "system/interfaces/1%2F1%2F5.100/ip6_addresses/2001:db8:100::1%2F64"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_subinterface.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testSubinterfaceConfig(m, testSubinterfaceVrf),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_subinterface.test", "admin_state", "down"),
+					resource.TestCheckResourceAttr("aoscx_subinterface.test", "vrf", "wan"),
+					resource.TestCheckResourceAttr("aoscx_subinterface.test", "mtu", "9000"),
+					resource.TestCheckResourceAttr("aoscx_subinterface.test", "ipv6.#", "0"),
+					testCheckMockAttr(m, "system/interfaces/1%2F1%2F5.100", "ip_mtu", "9000"),
+					testCheckMockDestroyed(m, "system/interfaces/1%2F1%2F5.100/ip6_addresses/2001:db8:100::1%2F64"),
+				),
+			},
+			{
+				PreConfig: func() {
+					m.patch("system/interfaces/1%2F1%2F5.100", map[string]interface{}{"ip_mtu": 1500})
+				},
+				Config:             testSubinterfaceConfig(m, testSubinterfaceVrf),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testSubinterfaceConfig(m, testSubinterfaceVrf),
+			},
+			{
+				PreConfig: func() {
+					m.remove("system/interfaces/1%2F1%2F5.100")
+				},
+				Config:             testSubinterfaceConfig(m, testSubinterfaceVrf),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceSubinterfaceParent(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testSubinterfaceConfig(m, ""),
+				ExpectError: regexp.MustCompile(`parent interface 1/1/5 is not a routed port`),
+			},
+			{
+				Config: testProviderConfig(m) + `
+resource "aoscx_subinterface" "test" {
+  parent  = "1/1/99"
+  vlan_id = 100
+}
+`,
+				ExpectError: regexp.MustCompile(`Error Creating Subinterface parent interface does not exist`),
+			},
+		},
+	})
+}
@@ -2,10 +2,12 @@ package aoscx
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
 	"github.com/aruba/aoscxgo"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -18,7 +20,17 @@ func resourceVlan() *schema.Resource {
 		ReadContext:   resourceVlanRead,
 		UpdateContext: resourceVlanUpdate,
 		DeleteContext: resourceVlanDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVlanImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
 		Schema: map[string]*schema.Schema{
+			"switch": switchSchema(),
 			"vlan_id": &schema.Schema{
 				Type:     schema.TypeInt,
 				Required: true,
@@ -48,7 +60,10 @@ func resourceVlanCreate(ctx context.Context, d *schema.ResourceData, m interface
 	var diags diag.Diagnostics
 	var err error
 
-	sw := m.(*aoscxgo.Client)
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
 	vlan_id := d.Get("vlan_id").(int)
 
 	tmp_vlan := aoscxgo.Vlan{
@@ -60,28 +75,28 @@ func resourceVlanCreate(ctx context.Context, d *schema.ResourceData, m interface
 
 	err = tmp_vlan.Create(sw)
 
-	//defer logout(tr, cookie, url)
-
 	if materialized := tmp_vlan.GetStatus(); !materialized {
+		create_err := err
 
 		err = tmp_vlan.Get(sw)
 
-		if err != nil {
-			{
-				diags = append(diags, diag.Errorf("Error in Creating VLAN ", err)...)
-				return diags
+		if requestFailed(err) {
+			if requestFailed(create_err) {
+				err = create_err
 			}
+			diags = append(diags, errorDiagnostics("Error in Creating VLAN", err, nil)...)
+			return diags
 		}
 
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Warning,
 			Summary:  "VLAN Already Existing",
-			Detail:   string(tmp_vlan.VlanId),
+			Detail:   strconv.Itoa(tmp_vlan.VlanId),
 		})
 
 	}
 
-	d.SetId(strconv.Itoa(vlan_id))
+	d.SetId(switchID(d, strconv.Itoa(vlan_id)))
 	d.Set("vlan_id", vlan_id)
 
 	resourceVlanRead(ctx, d, m)
@@ -93,7 +108,10 @@ func resourceVlanRead(ctx context.Context, d *schema.ResourceData, m interface{}
 	var diags diag.Diagnostics
 	var err error
 
-	sw := m.(*aoscxgo.Client)
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
 
 	// Retrieve VLAN from sw if existing
 	tmp_vlan := aoscxgo.Vlan{
@@ -102,8 +120,8 @@ func resourceVlanRead(ctx context.Context, d *schema.ResourceData, m interface{}
 
 	err = tmp_vlan.Get(sw)
 
-	if err != nil {
-		// Failure in VLAN retrieval
+	if isNotFound(err) {
+		// VLAN was removed outside of Terraform
 		d.SetId("")
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Warning,
@@ -111,6 +129,9 @@ func resourceVlanRead(ctx context.Context, d *schema.ResourceData, m interface{}
 			Detail:   "VLAN Not Found",
 		})
 		return diags
+	} else if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving VLAN", err, nil)...)
+		return diags
 	}
 
 	d.Set("name", tmp_vlan.Name)
@@ -124,7 +145,10 @@ func resourceVlanUpdate(ctx context.Context, d *schema.ResourceData, m interface
 	var diags diag.Diagnostics
 	var err error
 
-	sw := m.(*aoscxgo.Client)
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
 
 	// Retrieve VLAN from sw if existing
 	tmp_vlan := aoscxgo.Vlan{
@@ -133,6 +157,11 @@ func resourceVlanUpdate(ctx context.Context, d *schema.ResourceData, m interface
 
 	err = tmp_vlan.Get(sw)
 
+	if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving VLAN", err, cty.GetAttrPath("vlan_id"))...)
+		return diags
+	}
+
 	if d.HasChange("name") {
 		tmp_vlan.Name = d.Get("name").(string)
 	}
@@ -152,14 +181,13 @@ func resourceVlanUpdate(ctx context.Context, d *schema.ResourceData, m interface
 
 	err = tmp_vlan.Update(sw)
 
-	if err != nil {
-		if err.(*aoscxgo.RequestError).StatusCode == "404 Not Found" {
-			diags = append(diags, diag.Errorf("Error Updating VLAN does not exist: %s", err.(*aoscxgo.RequestError).StatusCode)...)
-			return diags
-		} else if err.(*aoscxgo.RequestError).StatusCode != "204 No Content" {
-			diags = append(diags, diag.Errorf("Error in Updating VLAN: %s", err.(*aoscxgo.RequestError).StatusCode)...)
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Updating VLAN does not exist", err, cty.GetAttrPath("vlan_id"))...)
 			return diags
 		}
+		diags = append(diags, errorDiagnostics("Error in Updating VLAN", err, nil)...)
+		return diags
 	}
 
 	return resourceVlanRead(ctx, d, m)
@@ -170,7 +198,10 @@ func resourceVlanDelete(ctx context.Context, d *schema.ResourceData, m interface
 	var diags diag.Diagnostics
 	var err error
 
-	sw := m.(*aoscxgo.Client)
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
 
 	// Retrieve VLAN from sw if existing
 	tmp_vlan := aoscxgo.Vlan{
@@ -179,16 +210,32 @@ func resourceVlanDelete(ctx context.Context, d *schema.ResourceData, m interface
 
 	err = tmp_vlan.Delete(sw)
 
-	if err != nil {
-		if err.(*aoscxgo.RequestError).StatusCode == "404 Not Found" {
-			diags = append(diags, diag.Errorf("Error Updating VLAN does not exist: %s", err.(*aoscxgo.RequestError).StatusCode)...)
-			return diags
-		} else if err.(*aoscxgo.RequestError).StatusCode != "204 No Content" {
-			diags = append(diags, diag.Errorf("Error in Updating VLAN: %s ", err.(*aoscxgo.RequestError).StatusCode)...)
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Deleting VLAN does not exist", err, cty.GetAttrPath("vlan_id"))...)
 			return diags
 		}
+		diags = append(diags, errorDiagnostics("Error in Deleting VLAN", err, nil)...)
+		return diags
 	}
 
 	d.SetId("")
 	return nil
 }
+
+func resourceVlanImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is the VLAN ID optionally followed by @switch, e.g.
+	// terraform import aoscx_vlan.vlan42 42
+	import_id, switch_name := parseSwitchID(d.Id())
+	vlan_id, err := strconv.Atoi(import_id)
+
+	if err != nil || vlan_id < 1 || vlan_id > 4094 {
+		return nil, fmt.Errorf("Invalid VLAN import ID %q, expected a VLAN ID between 1 and 4094", d.Id())
+	}
+
+	d.Set("switch", switch_name)
+	d.SetId(switchID(d, strconv.Itoa(vlan_id)))
+	d.Set("vlan_id", vlan_id)
+
+	return []*schema.ResourceData{d}, nil
+}
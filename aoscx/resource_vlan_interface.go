@@ -3,10 +3,14 @@ package aoscx
 import (
 	"context"
 	"fmt"
+	"net"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/aruba/aoscxgo"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -19,8 +23,19 @@ func resourceVlanInterface() *schema.Resource {
 		ReadContext:   resourceVlanInterfaceRead,
 		UpdateContext: resourceVlanInterfaceUpdate,
 		DeleteContext: resourceVlanInterfaceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVlanInterfaceImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
 
-		Schema: map[string]*schema.Schema{
+		CustomizeDiff: resourceVlanInterfaceCustomizeDiff,
+		Schema: withIpSettingsSchema(map[string]*schema.Schema{
+			"switch": switchSchema(),
 			"vlan_id": &schema.Schema{
 				Type:     schema.TypeInt,
 				Required: true,
@@ -57,11 +72,175 @@ func resourceVlanInterface() *schema.Resource {
 				Default:  nil,
 			},
 			"vrf": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: false,
-				Default:  "default",
-				Optional: true,
+				Type:        schema.TypeString,
+				Required:    false,
+				Default:     "default",
+				Optional:    true,
+				Description: "VRF the interface is attached to, reference aoscx_vrf.<name>.name so the VRF is created first",
+			},
+			"active_gateway": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "VSX active gateway, configure the same block on the VLAN interface of both switches of the pair",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ipv4": &schema.Schema{
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Virtual IPv4 addresses answered by both switches, each in a subnet of the ipv4 addresses of the interface",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.IsIPv4Address,
+							},
+						},
+						"ipv6": &schema.Schema{
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Virtual global IPv6 addresses answered by both switches, each in a subnet of the ipv6 addresses of the interface",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.IsIPv6Address,
+							},
+						},
+						"ipv6_link_local": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.IsIPv6Address,
+							Description:  "Virtual IPv6 link-local address answered by both switches, e.g. fe80::1, hosts use it as IPv6 default gateway",
+						},
+						"mac": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringMatch(macRegexp, "expected a lowercase MAC address such as 02:00:00:00:01:00"),
+							Description:  "Virtual MAC address of the gateway, used for IPv4 and IPv6",
+						},
+					},
+				},
 			},
+		}),
+	}
+}
+
+// resourceVlanInterfaceCustomizeDiff checks that the virtual IP addresses of
+// the active gateway are in a subnet of the interface without reusing one of
+// its addresses, both switches of a VSX pair would then answer for it with
+// different MAC addresses.
+func resourceVlanInterfaceCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if err := checkIpSettings(d); err != nil {
+		return err
+	}
+
+	if len(d.Get("active_gateway").([]interface{})) == 0 {
+		return nil
+	}
+
+	mac := d.Get("active_gateway.0.mac").(string)
+	if hw, err := net.ParseMAC(mac); err == nil && hw[0]&1 == 1 {
+		return fmt.Errorf("active_gateway mac must be a unicast MAC address, %s is multicast", mac)
+	}
+
+	virtual_ipv4 := d.Get("active_gateway.0.ipv4").([]interface{})
+	virtual_ipv6 := d.Get("active_gateway.0.ipv6").([]interface{})
+	link_local := d.Get("active_gateway.0.ipv6_link_local").(string)
+	if len(virtual_ipv4) == 0 && len(virtual_ipv6) == 0 && link_local == "" {
+		return fmt.Errorf("active_gateway needs at least one of ipv4, ipv6 and ipv6_link_local")
+	}
+
+	if ip := net.ParseIP(link_local); ip != nil && !ip.IsLinkLocalUnicast() {
+		return fmt.Errorf("active_gateway ipv6_link_local %s is not a link-local address", link_local)
+	}
+	for _, virtual_ip := range virtual_ipv6 {
+		if ip := net.ParseIP(fmt.Sprint(virtual_ip)); ip != nil && ip.IsLinkLocalUnicast() {
+			return fmt.Errorf("active_gateway address %s is link-local, set it as ipv6_link_local", ip)
+		}
+	}
+
+	// Addresses computed during apply can only be checked once known
+	if d.NewValueKnown("ipv4") {
+		if err := checkActiveGatewayAddresses(virtual_ipv4, d.Get("ipv4").([]interface{})); err != nil {
+			return err
+		}
+	}
+	if d.NewValueKnown("ipv6") {
+		if err := checkActiveGatewayAddresses(virtual_ipv6, d.Get("ipv6").(*schema.Set).List()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkActiveGatewayAddresses checks that each virtual IP address is in the
+// subnet of one of the CIDR interface addresses without being one of them.
+func checkActiveGatewayAddresses(virtual_ips []interface{}, interface_addresses []interface{}) error {
+	addresses := map[string]bool{}
+	subnets := []*net.IPNet{}
+	for _, ip_addr := range interface_addresses {
+		ip, subnet, err := net.ParseCIDR(fmt.Sprint(ip_addr))
+		if err != nil {
+			// Not an address the subnets can be derived from
+			continue
+		}
+		addresses[ip.String()] = true
+		subnets = append(subnets, subnet)
+	}
+
+	for _, virtual_ip := range virtual_ips {
+		ip := net.ParseIP(fmt.Sprint(virtual_ip))
+		if ip == nil {
+			continue
+		}
+		if addresses[ip.String()] {
+			return fmt.Errorf("active_gateway address %s is also an address of the interface", ip)
+		}
+
+		in_subnet := false
+		for _, subnet := range subnets {
+			in_subnet = in_subnet || subnet.Contains(ip)
+		}
+		if !in_subnet {
+			return fmt.Errorf("active_gateway address %s is not in a subnet of the interface", ip)
+		}
+	}
+
+	return nil
+}
+
+// activeGatewayFromResourceData returns the active gateway configured on d,
+// without virtual IP addresses when there is none.
+func activeGatewayFromResourceData(d *schema.ResourceData) activeGateway {
+	tmp_gateway := activeGateway{
+		Ipv4:          []string{},
+		Ipv6:          []string{},
+		Ipv6LinkLocal: d.Get("active_gateway.0.ipv6_link_local").(string),
+		Mac:           d.Get("active_gateway.0.mac").(string),
+	}
+	for _, virtual_ip := range d.Get("active_gateway.0.ipv4").([]interface{}) {
+		if virtual_ip != nil {
+			tmp_gateway.Ipv4 = append(tmp_gateway.Ipv4, virtual_ip.(string))
+		}
+	}
+	for _, virtual_ip := range d.Get("active_gateway.0.ipv6").([]interface{}) {
+		if virtual_ip != nil {
+			tmp_gateway.Ipv6 = append(tmp_gateway.Ipv6, virtual_ip.(string))
+		}
+	}
+
+	return tmp_gateway
+}
+
+func flattenActiveGateway(tmp_gateway activeGateway) []interface{} {
+	if !tmp_gateway.configured() {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"ipv4":            tmp_gateway.Ipv4,
+			"ipv6":            tmp_gateway.Ipv6,
+			"ipv6_link_local": tmp_gateway.Ipv6LinkLocal,
+			"mac":             tmp_gateway.Mac,
 		},
 	}
 }
@@ -70,7 +249,10 @@ func resourceVlanInterfaceCreate(ctx context.Context, d *schema.ResourceData, m
 	var diags diag.Diagnostics
 	var err error
 
-	sw := m.(*aoscxgo.Client)
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
 	// Create Vlan Object, if Vlan doesn't exist error will occur
 	tmp_vlan := aoscxgo.Vlan{
 		VlanId: d.Get("vlan_id").(int),
@@ -103,17 +285,36 @@ func resourceVlanInterfaceCreate(ctx context.Context, d *schema.ResourceData, m
 
 	if materialized := tmp_vlan_int.GetStatus(); !materialized {
 
+		create_err := err
+
 		err = tmp_vlan_int.Get(sw)
 
-		if err != nil {
-			diags = append(diags, diag.Errorf("Error in Creating Vlan Interface: %s", err.(*aoscxgo.RequestError).StatusCode)...)
+		if requestFailed(err) {
+			if requestFailed(create_err) {
+				err = create_err
+			}
+			diags = append(diags, errorDiagnostics("Error in Creating Vlan Interface", err, cty.GetAttrPath("vlan_id"))...)
 			return diags
 		}
 	}
 	str_vlanint_id := fmt.Sprintf("vlanint_%v", tmp_vlan_int.Vlan.VlanId)
-	d.SetId(str_vlanint_id)
+	d.SetId(switchID(d, str_vlanint_id))
 	d.Set("vlan_id", tmp_vlan_int.Vlan.VlanId)
 
+	tmp_gateway := activeGatewayFromResourceData(d)
+	if tmp_gateway.configured() {
+		err = tmp_gateway.Update(ctx, sw, fmt.Sprintf("vlan%v", tmp_vlan_int.Vlan.VlanId))
+		if requestFailed(err) {
+			// The interface exists, keep it in state so it is tainted
+			diags = append(diags, errorDiagnostics("Error in Creating Vlan Interface active gateway", err, cty.GetAttrPath("active_gateway"))...)
+			return diags
+		}
+	}
+
+	if ip_diags := updateIpSettings(ctx, sw, d, fmt.Sprintf("vlan%v", tmp_vlan_int.Vlan.VlanId), "Error in Creating Vlan Interface"); ip_diags.HasError() {
+		return append(diags, ip_diags...)
+	}
+
 	resourceVlanInterfaceRead(ctx, d, m)
 
 	return diags
@@ -123,7 +324,10 @@ func resourceVlanInterfaceRead(ctx context.Context, d *schema.ResourceData, m in
 	var diags diag.Diagnostics
 	var err error
 
-	sw := m.(*aoscxgo.Client)
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
 
 	// Retrieve VLAN from sw if existing
 	tmp_vlan := aoscxgo.Vlan{
@@ -136,8 +340,8 @@ func resourceVlanInterfaceRead(ctx context.Context, d *schema.ResourceData, m in
 
 	err = tmp_vlan_int.Get(sw)
 
-	if err != nil {
-		//Failure in VlanInterface retrieval
+	if isNotFound(err) {
+		// VlanInterface was removed outside of Terraform
 		d.SetId("")
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Warning,
@@ -145,6 +349,9 @@ func resourceVlanInterfaceRead(ctx context.Context, d *schema.ResourceData, m in
 			Detail:   "VlanInterface Not Found",
 		})
 		return diags
+	} else if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving VlanInterface", err, nil)...)
+		return diags
 	}
 
 	d.Set("vlan_id", tmp_vlan_int.Vlan.VlanId)
@@ -156,6 +363,33 @@ func resourceVlanInterfaceRead(ctx context.Context, d *schema.ResourceData, m in
 	d.Set("ipv6", tmp_vlan_int.Ipv6)
 	d.Set("vrf", tmp_vlan_int.Vrf)
 
+	tmp_gateway := activeGateway{}
+	err = tmp_gateway.Get(ctx, sw, fmt.Sprintf("vlan%v", tmp_vlan_int.Vlan.VlanId))
+	if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving VlanInterface active gateway", err, nil)...)
+		return diags
+	}
+	if tmp_gateway.Ipv6Mac != "" {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Vlan Interface active gateway MAC differs between IPv4 and IPv6",
+			Detail:   fmt.Sprintf("vlan%v uses %s for IPv4 and %s for IPv6, applying writes the configured MAC to both", tmp_vlan_int.Vlan.VlanId, tmp_gateway.Mac, tmp_gateway.Ipv6Mac),
+		})
+		// Keep the MAC that differs from the state so the plan rewrites both
+		if tmp_gateway.Mac == d.Get("active_gateway.0.mac").(string) {
+			tmp_gateway.Mac = tmp_gateway.Ipv6Mac
+		}
+	}
+	d.Set("active_gateway", flattenActiveGateway(tmp_gateway))
+
+	tmp_settings := ipSettings{}
+	err = tmp_settings.Get(ctx, sw, fmt.Sprintf("vlan%v", tmp_vlan_int.Vlan.VlanId))
+	if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving VlanInterface", err, nil)...)
+		return diags
+	}
+	setIpSettings(d, tmp_settings)
+
 	return diags
 }
 
@@ -164,7 +398,10 @@ func resourceVlanInterfaceUpdate(ctx context.Context, d *schema.ResourceData, m
 	var diags diag.Diagnostics
 	var err error
 
-	sw := m.(*aoscxgo.Client)
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
 	// Retrieve VLAN from sw if existing
 	tmp_vlan := aoscxgo.Vlan{
 		VlanId: d.Get("vlan_id").(int),
@@ -172,8 +409,8 @@ func resourceVlanInterfaceUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	err = tmp_vlan.Get(sw)
 
-	if err != nil {
-		diags = append(diags, diag.Errorf("VLAN missing - Error in Updating VlanInterface ", err)...)
+	if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("VLAN missing - Error in Updating VlanInterface", err, cty.GetAttrPath("vlan_id"))...)
 		return diags
 	}
 	tmp_vlan_int := aoscxgo.VlanInterface{
@@ -181,8 +418,8 @@ func resourceVlanInterfaceUpdate(ctx context.Context, d *schema.ResourceData, m
 	}
 	err = tmp_vlan_int.Get(sw)
 
-	if err != nil {
-		diags = append(diags, diag.Errorf("VLANInterface missing - Error in Updating VlanInterface ", err)...)
+	if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("VLANInterface missing - Error in Updating VlanInterface", err, cty.GetAttrPath("vlan_id"))...)
 		return diags
 	}
 
@@ -243,16 +480,30 @@ func resourceVlanInterfaceUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	err = tmp_vlan_int.Update(sw, use_put)
 
-	if err != nil {
-		if err.(*aoscxgo.RequestError).StatusCode == "404 Not Found" {
-			diags = append(diags, diag.Errorf("Error Updating Interface does not exist: %s", err.(*aoscxgo.RequestError).StatusCode)...)
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Updating Interface does not exist", err, cty.GetAttrPath("vlan_id"))...)
 			return diags
-		} else if err.(*aoscxgo.RequestError).StatusCode != "204 No Content" {
-			diags = append(diags, diag.Errorf("Error in Updating Interface: %s", err.(*aoscxgo.RequestError).StatusCode)...)
+		}
+		diags = append(diags, errorDiagnostics("Error in Updating Interface", err, nil)...)
+		return diags
+	}
+
+	// The update may have replaced the interface and cleared the active
+	// gateway, write it again whenever one is configured
+	tmp_gateway := activeGatewayFromResourceData(d)
+	if d.HasChange("active_gateway") || tmp_gateway.configured() {
+		err = tmp_gateway.Update(ctx, sw, fmt.Sprintf("vlan%v", tmp_vlan_int.Vlan.VlanId))
+		if requestFailed(err) {
+			diags = append(diags, errorDiagnostics("Error in Updating Interface active gateway", err, cty.GetAttrPath("active_gateway"))...)
 			return diags
 		}
 	}
 
+	if ip_diags := updateIpSettings(ctx, sw, d, fmt.Sprintf("vlan%v", tmp_vlan_int.Vlan.VlanId), "Error in Updating Interface"); ip_diags.HasError() {
+		return append(diags, ip_diags...)
+	}
+
 	return resourceVlanInterfaceRead(ctx, d, m)
 }
 
@@ -261,7 +512,10 @@ func resourceVlanInterfaceDelete(ctx context.Context, d *schema.ResourceData, m
 	var diags diag.Diagnostics
 	var err error
 
-	sw := m.(*aoscxgo.Client)
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
 
 	// Delete Interface Obj
 	tmp_vlan := aoscxgo.Vlan{
@@ -272,18 +526,42 @@ func resourceVlanInterfaceDelete(ctx context.Context, d *schema.ResourceData, m
 		Vlan: tmp_vlan,
 	}
 
+	// DHCP relay entries reference the interface, remove them first
+	tmp_settings := ipSettings{}
+	err = tmp_settings.Delete(ctx, sw, fmt.Sprintf("vlan%v", tmp_vlan.VlanId))
+	if requestFailed(err) && !isNotFound(err) {
+		diags = append(diags, errorDiagnostics("Error in Deleting Interface", err, nil)...)
+		return diags
+	}
+
 	err = tmp_vlan_int.Delete(sw)
 
-	if err != nil {
-		if err.(*aoscxgo.RequestError).StatusCode == "404 Not Found" {
-			diags = append(diags, diag.Errorf("Error Deleting Interface does not exist: %s", err.(*aoscxgo.RequestError).StatusCode)...)
-			return diags
-		} else if err.(*aoscxgo.RequestError).StatusCode != "204 No Content" {
-			diags = append(diags, diag.Errorf("Error in Deleting Interface: %s ", err.(*aoscxgo.RequestError).StatusCode)...)
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Deleting Interface does not exist", err, cty.GetAttrPath("vlan_id"))...)
 			return diags
 		}
+		diags = append(diags, errorDiagnostics("Error in Deleting Interface", err, nil)...)
+		return diags
 	}
 
 	d.SetId("")
 	return nil
 }
+
+func resourceVlanInterfaceImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is either the VLAN ID or the resource ID, e.g. 42 or vlanint_42,
+	// optionally followed by @switch
+	import_id, switch_name := parseSwitchID(d.Id())
+	vlan_id, err := strconv.Atoi(strings.TrimPrefix(import_id, "vlanint_"))
+
+	if err != nil || vlan_id < 1 || vlan_id > 4094 {
+		return nil, fmt.Errorf("Invalid VlanInterface import ID %q, expected a VLAN ID between 1 and 4094", d.Id())
+	}
+
+	d.Set("switch", switch_name)
+	d.SetId(switchID(d, fmt.Sprintf("vlanint_%v", vlan_id)))
+	d.Set("vlan_id", vlan_id)
+
+	return []*schema.ResourceData{d}, nil
+}
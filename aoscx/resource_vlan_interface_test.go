@@ -0,0 +1,409 @@
+package aoscx
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testVlanInterfaceConfig(m *mockSwitch, description string) string {
+	return testProviderConfig(m) + fmt.Sprintf(`
+resource "aoscx_vlan" "test" {
+  vlan_id = 42
+  name    = "servers"
+}
+
+resource "aoscx_vlan_interface" "test" {
+  vlan_id     = aoscx_vlan.test.vlan_id
+  description = %q
+  ipv4        = ["10.42.0.1/24"]
+}
+`, description)
+}
+
+func TestResourceVlanInterface(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckMockDestroyed(m, "system/interfaces/vlan42"),
+			testCheckMockDestroyed(m, "system/vlans/42"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testVlanInterfaceConfig(m, "servers gateway"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_vlan_interface.test", "id", "vlanint_42"),
+					resource.TestCheckResourceAttr("aoscx_vlan_interface.test", "description", "servers gateway"),
+					resource.TestCheckResourceAttr("aoscx_vlan_interface.test", "ipv4.0", "10.42.0.1/24"),
+					testCheckMockExists(m, "system/interfaces/vlan42"),
+				),
+			},
+			{
+				Config: testVlanInterfaceConfig(m, "servers"),
+				Check:  resource.TestCheckResourceAttr("aoscx_vlan_interface.test", "description", "servers"),
+			},
+			{
+				ResourceName:      "aoscx_vlan_interface.test",
+				ImportState:       true,
+				ImportStateId:     "42",
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					m.patch("system/interfaces/vlan42", map[string]interface{}{"description": "changed on switch"})
+				},
+				Config:             testVlanInterfaceConfig(m, "servers"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testVlanInterfaceConfig(m, "servers"),
+			},
+			{
+				PreConfig: func() {
+					m.remove("system/interfaces/vlan42")
+				},
+				Config:             testVlanInterfaceConfig(m, "servers"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testVlanInterfaceActiveGatewayConfig(m *mockSwitch, active_gateway string) string {
+	return testProviderConfig(m) + `
+resource "aoscx_vlan" "test" {
+  vlan_id = 42
+  name    = "servers"
+}
+
+resource "aoscx_vlan_interface" "test" {
+  vlan_id = aoscx_vlan.test.vlan_id
+  ipv4    = ["10.42.0.2/24"]
+` + active_gateway + `
+}
+`
+}
+
+func TestResourceVlanInterfaceActiveGateway(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, "system/interfaces/vlan42"),
+		Steps: []resource.TestStep{
+			{
+				Config: testVlanInterfaceActiveGatewayConfig(m, `
+  active_gateway {
+    ipv4 = ["10.42.0.1"]
+    mac  = "02:00:00:00:42:01"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_vlan_interface.test", "active_gateway.0.ipv4.0", "10.42.0.1"),
+					resource.TestCheckResourceAttr("aoscx_vlan_interface.test", "active_gateway.0.mac", "02:00:00:00:42:01"),
+					func(s *terraform.State) error {
+						if mac := m.get("system/interfaces/vlan42")["vsx_virtual_gw_mac_v4"]; mac != "02:00:00:00:42:01" {
+							return fmt.Errorf("expected vsx_virtual_gw_mac_v4 02:00:00:00:42:01, got %v", mac)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "aoscx_vlan_interface.test",
+				ImportState:       true,
+				ImportStateId:     "42",
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					m.patch("system/interfaces/vlan42", map[string]interface{}{"vsx_virtual_ip4": []interface{}{"10.42.0.254"}})
+				},
+				Config: testVlanInterfaceActiveGatewayConfig(m, `
+  active_gateway {
+    ipv4 = ["10.42.0.1"]
+    mac  = "02:00:00:00:42:01"
+  }
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testVlanInterfaceActiveGatewayConfig(m, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_vlan_interface.test", "active_gateway.#", "0"),
+					func(s *terraform.State) error {
+						if addresses, _ := m.get("system/interfaces/vlan42")["vsx_virtual_ip4"].([]interface{}); len(addresses) != 0 {
+							return fmt.Errorf("expected no vsx_virtual_ip4, got %v", addresses)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+const testVlanInterfaceActiveGatewayDualStack = `
+  ipv6 = ["2001:db8:42::2/64"]
+
+  active_gateway {
+    ipv4            = ["10.42.0.1"]
+    ipv6            = ["2001:db8:42::1"]
+    ipv6_link_local = "fe80::1"
+    mac             = "02:00:00:00:42:01"
+  }
+`
+
+func TestResourceVlanInterfaceActiveGatewayIpv6(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, "system/interfaces/vlan42"),
+		Steps: []resource.TestStep{
+			{
+				Config: testVlanInterfaceActiveGatewayConfig(m, testVlanInterfaceActiveGatewayDualStack),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_vlan_interface.test", "active_gateway.0.ipv6.0", "2001:db8:42::1"),
+					resource.TestCheckResourceAttr("aoscx_vlan_interface.test", "active_gateway.0.ipv6_link_local", "fe80::1"),
+					testCheckMockAttr(m, "system/interfaces/vlan42", "vsx_virtual_ip6", "[fe80::1 2001:db8:42::1]"),
+					testCheckMockAttr(m, "system/interfaces/vlan42", "vsx_virtual_gw_mac_v6", "02:00:00:00:42:01"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_vlan_interface.test",
+				ImportState:       true,
+				ImportStateId:     "42",
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					m.patch("system/interfaces/vlan42", map[string]interface{}{"vsx_virtual_gw_mac_v6": "02:00:00:00:42:99"})
+				},
+				Config:             testVlanInterfaceActiveGatewayConfig(m, testVlanInterfaceActiveGatewayDualStack),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testVlanInterfaceActiveGatewayConfig(m, testVlanInterfaceActiveGatewayDualStack),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_vlan_interface.test", "active_gateway.0.mac", "02:00:00:00:42:01"),
+					testCheckMockAttr(m, "system/interfaces/vlan42", "vsx_virtual_gw_mac_v6", "02:00:00:00:42:01"),
+				),
+			},
+			{
+				PreConfig: func() {
+					m.patch("system/interfaces/vlan42", map[string]interface{}{"vsx_virtual_ip6": []interface{}{"fe80::2"}})
+				},
+				Config:             testVlanInterfaceActiveGatewayConfig(m, testVlanInterfaceActiveGatewayDualStack),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testVlanInterfaceActiveGatewayConfig(m, `
+  active_gateway {
+    ipv6_link_local = "fe80::1"
+    mac             = "02:00:00:00:42:01"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_vlan_interface.test", "active_gateway.0.ipv4.#", "0"),
+					testCheckMockAttr(m, "system/interfaces/vlan42", "vsx_virtual_ip4", "[]"),
+					testCheckMockAttr(m, "system/interfaces/vlan42", "vsx_virtual_ip6", "[fe80::1]"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceVlanInterfaceActiveGatewayInvalid(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testVlanInterfaceActiveGatewayConfig(m, `
+  active_gateway {
+    ipv4 = ["10.42.0.2"]
+    mac  = "02:00:00:00:42:01"
+  }
+`),
+				ExpectError: regexp.MustCompile(`active_gateway address 10.42.0.2 is also an address of the interface`),
+			},
+			{
+				Config: testVlanInterfaceActiveGatewayConfig(m, `
+  active_gateway {
+    ipv4 = ["10.42.0.1"]
+    mac  = "01:00:5e:00:00:01"
+  }
+`),
+				ExpectError: regexp.MustCompile(`must be a unicast MAC address`),
+			},
+			{
+				Config: testVlanInterfaceActiveGatewayConfig(m, `
+  active_gateway {
+    ipv4 = ["10.43.0.1"]
+    mac  = "02:00:00:00:42:01"
+  }
+`),
+				ExpectError: regexp.MustCompile(`active_gateway address 10.43.0.1 is not in a subnet of the interface`),
+			},
+			{
+				Config: testVlanInterfaceActiveGatewayConfig(m, `
+  active_gateway {
+    ipv6_link_local = "2001:db8:42::1"
+    mac             = "02:00:00:00:42:01"
+  }
+`),
+				ExpectError: regexp.MustCompile(`ipv6_link_local 2001:db8:42::1 is not a link-local address`),
+			},
+			{
+				Config: testVlanInterfaceActiveGatewayConfig(m, `
+  active_gateway {
+    mac = "02:00:00:00:42:01"
+  }
+`),
+				ExpectError: regexp.MustCompile(`active_gateway needs at least one of ipv4, ipv6 and ipv6_link_local`),
+			},
+		},
+	})
+}
+
+func testVlanInterfaceIpSettingsConfig(m *mockSwitch, settings string) string {
+	return testProviderConfig(m) + `
+resource "aoscx_vrf" "test" {
+  name = "services"
+}
+
+resource "aoscx_vlan" "test" {
+  vlan_id = 42
+  name    = "servers"
+}
+
+resource "aoscx_vlan_interface" "test" {
+  vlan_id = aoscx_vlan.test.vlan_id
+  ipv4    = ["10.42.0.1/24"]
+` + settings + `
+}
+`
+}
+
+const testVlanInterfaceIpSettings = `
+  ip_mtu                = 9000
+  local_proxy_arp       = true
+  ip_directed_broadcast = true
+  icmp_redirects        = false
+
+  dhcp_relay_servers {
+    addresses = ["10.0.0.10"]
+  }
+
+  dhcp_relay_servers {
+    vrf       = aoscx_vrf.test.name
+    addresses = ["10.1.0.10", "10.1.0.11"]
+  }
+
+  ipv6_nd_ra {
+    interval       = 200
+    managed_config = true
+  }
+`
+
+const testVlanInterfaceIpSettingsChanged = `
+  dhcp_relay_servers {
+    vrf       = aoscx_vrf.test.name
+    addresses = ["10.1.0.11"]
+  }
+`
+
+func TestResourceVlanInterfaceIpSettings(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckMockDestroyed(m, "system/interfaces/vlan42"),
+			testCheckMockDestroyed(m, "system/dhcp_relays/vlan42,services"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testVlanInterfaceIpSettingsConfig(m, testVlanInterfaceIpSettings),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_vlan_interface.test", "dhcp_relay_servers.#", "2"),
+					resource.TestCheckResourceAttr("aoscx_vlan_interface.test", "ipv6_nd_ra.0.interval", "200"),
+					resource.TestCheckResourceAttr("aoscx_vlan_interface.test", "ipv6_nd_ra.0.lifetime", "1800"),
+					testCheckMockAttr(m, "system/interfaces/vlan42", "directed_broadcast_enabled", "true"),
+					testCheckMockAttr(m, "system/interfaces/vlan42", "icmp_redirect_enabled", "false"),
+					testCheckMockAttr(m, "system/dhcp_relays/vlan42,default", "ipv4_ucast_server", "[10.0.0.10]"),
+					testCheckMockAttr(m, "system/dhcp_relays/vlan42,services", "ipv4_ucast_server", "[10.1.0.10 10.1.0.11]"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_vlan_interface.test",
+				ImportState:       true,
+				ImportStateId:     "42",
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					m.patch("system/dhcp_relays/vlan42,default", map[string]interface{}{"ipv4_ucast_server": []interface{}{"10.0.0.99"}})
+				},
+				Config:             testVlanInterfaceIpSettingsConfig(m, testVlanInterfaceIpSettings),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testVlanInterfaceIpSettingsConfig(m, testVlanInterfaceIpSettingsChanged),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_vlan_interface.test", "ip_mtu", "1500"),
+					resource.TestCheckResourceAttr("aoscx_vlan_interface.test", "ipv6_nd_ra.#", "0"),
+					resource.TestCheckResourceAttr("aoscx_vlan_interface.test", "icmp_redirects", "true"),
+					testCheckMockAttr(m, "system/interfaces/vlan42", "icmp_redirect_enabled", "true"),
+					testCheckMockDestroyed(m, "system/dhcp_relays/vlan42,default"),
+					testCheckMockAttr(m, "system/dhcp_relays/vlan42,services", "ipv4_ucast_server", "[10.1.0.11]"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceVlanInterfaceIpSettingsInvalid(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testVlanInterfaceIpSettingsConfig(m, `
+  dhcp_relay_servers {
+    addresses = ["10.0.0.10"]
+  }
+
+  dhcp_relay_servers {
+    vrf       = "default"
+    addresses = ["10.0.0.11"]
+  }
+`),
+				ExpectError: regexp.MustCompile(`DHCP relay servers of VRF default are configured more than once`),
+			},
+			{
+				Config: testVlanInterfaceIpSettingsConfig(m, `
+  ipv6_nd_ra {
+    interval = 600
+    lifetime = 300
+  }
+`),
+				ExpectError: regexp.MustCompile(`lifetime 300 must be 0 or at least the interval 600`),
+			},
+		},
+	})
+}
@@ -0,0 +1,131 @@
+package aoscx
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testVlanConfig(m *mockSwitch, name string, admin_state string) string {
+	return testProviderConfig(m) + fmt.Sprintf(`
+resource "aoscx_vlan" "test" {
+  vlan_id     = 42
+  name        = %q
+  description = "managed by terraform"
+  admin_state = %q
+}
+`, name, admin_state)
+}
+
+func TestResourceVlan(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, "system/vlans/42"),
+		Steps: []resource.TestStep{
+			{
+				Config: testVlanConfig(m, "servers", "up"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_vlan.test", "id", "42"),
+					resource.TestCheckResourceAttr("aoscx_vlan.test", "name", "servers"),
+					resource.TestCheckResourceAttr("aoscx_vlan.test", "description", "managed by terraform"),
+					resource.TestCheckResourceAttr("aoscx_vlan.test", "admin_state", "up"),
+					testCheckMockExists(m, "system/vlans/42"),
+				),
+			},
+			{
+				Config: testVlanConfig(m, "storage", "down"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_vlan.test", "name", "storage"),
+					resource.TestCheckResourceAttr("aoscx_vlan.test", "admin_state", "down"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_vlan.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					m.patch("system/vlans/42", map[string]interface{}{"name": "changed on switch"})
+				},
+				Config:             testVlanConfig(m, "storage", "down"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testVlanConfig(m, "storage", "down"),
+				Check:  resource.TestCheckResourceAttr("aoscx_vlan.test", "name", "storage"),
+			},
+			{
+				PreConfig: func() {
+					m.remove("system/vlans/42")
+				},
+				Config:             testVlanConfig(m, "storage", "down"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceVlanTransientFailure(t *testing.T) {
+	m := newMockSwitch(t)
+	m.injectFault("POST", "system/vlans", 503, 1)
+	m.injectFault("GET", "system/vlans/42", 502, 1)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, "system/vlans/42"),
+		Steps: []resource.TestStep{
+			{
+				Config: testVlanConfig(m, "servers", "up"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_vlan.test", "name", "servers"),
+					testCheckMockExists(m, "system/vlans/42"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceVlanCreateError(t *testing.T) {
+	m := newMockSwitch(t)
+	m.injectFault("POST", "system/vlans", 500, -1)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testVlanConfig(m, "servers", "up"),
+				ExpectError: regexp.MustCompile(`Error in Creating VLAN`),
+			},
+		},
+	})
+}
+
+func TestResourceVlanSessionExpired(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, "system/vlans/42"),
+		Steps: []resource.TestStep{
+			{
+				Config: testVlanConfig(m, "servers", "up"),
+			},
+			{
+				// The switch drops the session in the middle of the update,
+				// the request is replayed after logging in again
+				PreConfig: func() {
+					m.injectFault("", "system/vlans/42", 401, 1)
+				},
+				Config: testVlanConfig(m, "storage", "up"),
+				Check:  resource.TestCheckResourceAttr("aoscx_vlan.test", "name", "storage"),
+			},
+		},
+	})
+}
@@ -0,0 +1,286 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// routeTargetRegexp matches route distinguishers and route targets in the
+// ASN:nn or IP:nn formats.
+var routeTargetRegexp = regexp.MustCompile(`^([0-9]+|[0-9]+\.[0-9]+\.[0-9]+\.[0-9]+):[0-9]+$`)
+
+func resourceVrf() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Resource to configure VRFs on AOS-CX switches.",
+		CreateContext: resourceVrfCreate,
+		ReadContext:   resourceVrfRead,
+		UpdateContext: resourceVrfUpdate,
+		DeleteContext: resourceVrfDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVrfImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"switch": switchSchema(),
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.All(validation.StringLenBetween(1, 32), validation.StringNotInSlice([]string{"default", "mgmt"}, false)),
+				Description:  "Name of the VRF, the built-in default and mgmt VRFs cannot be managed",
+			},
+			"rd": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringMatch(routeTargetRegexp, "expected a route distinguisher in the ASN:nn or IP:nn format"),
+				Description:  "Route distinguisher of the VRF",
+			},
+			"enable": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the VRF is enabled",
+			},
+			"address_family": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				MaxItems:    2,
+				Description: "Route targets of an address family of the VRF",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"ipv4-unicast", "ipv6-unicast"}, false),
+							Description:  "Address family, either ipv4-unicast or ipv6-unicast",
+						},
+						"import_route_targets": &schema.Schema{
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "Route targets imported into the VRF",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringMatch(routeTargetRegexp, "expected a route target in the ASN:nn or IP:nn format"),
+							},
+						},
+						"export_route_targets": &schema.Schema{
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "Route targets attached to routes exported from the VRF",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringMatch(routeTargetRegexp, "expected a route target in the ASN:nn or IP:nn format"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// vrfFromResourceData builds the VRF described by the configuration of d.
+func vrfFromResourceData(d *schema.ResourceData) (vrf, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	tmp_vrf := vrf{
+		Name:            d.Get("name").(string),
+		Rd:              d.Get("rd").(string),
+		Shutdown:        !d.Get("enable").(bool),
+		AddressFamilies: map[string]vrfAddressFamily{},
+	}
+
+	for _, item := range d.Get("address_family").(*schema.Set).List() {
+		family := item.(map[string]interface{})
+		family_type := family["type"].(string)
+
+		if _, ok := tmp_vrf.AddressFamilies[family_type]; ok {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Duplicate VRF address family",
+				Detail:        fmt.Sprintf("Address family %s is configured more than once", family_type),
+				AttributePath: cty.GetAttrPath("address_family"),
+			})
+			continue
+		}
+
+		tmp_vrf.AddressFamilies[family_type] = vrfAddressFamily{
+			ImportRouteTargets: sortedStrings(family["import_route_targets"].(*schema.Set)),
+			ExportRouteTargets: sortedStrings(family["export_route_targets"].(*schema.Set)),
+		}
+	}
+
+	return tmp_vrf, diags
+}
+
+// sortedStrings returns the elements of a set of strings in order.
+func sortedStrings(set *schema.Set) []string {
+	values := []string{}
+	for _, value := range set.List() {
+		values = append(values, value.(string))
+	}
+	sort.Strings(values)
+	return values
+}
+
+func resourceVrfCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_vrf, diags := vrfFromResourceData(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	created, err := tmp_vrf.Create(ctx, sw)
+
+	if requestFailed(err) {
+		// A VRF created without all of its address families is kept in
+		// state so it is tainted and replaced on the next apply
+		if created {
+			d.SetId(switchID(d, tmp_vrf.Name))
+		}
+		diags = append(diags, errorDiagnostics("Error in Creating VRF", err, cty.GetAttrPath("name"))...)
+		return diags
+	}
+
+	d.SetId(switchID(d, tmp_vrf.Name))
+
+	return resourceVrfRead(ctx, d, m)
+}
+
+func resourceVrfRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Retrieve VRF from sw if existing
+	tmp_vrf := vrf{
+		Name: d.Get("name").(string),
+	}
+
+	err = tmp_vrf.Get(ctx, sw)
+
+	if isNotFound(err) {
+		// VRF was removed outside of Terraform
+		d.SetId("")
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "VRF Not Found",
+			Detail:   tmp_vrf.Name,
+		})
+		return diags
+	} else if requestFailed(err) {
+		diags = append(diags, errorDiagnostics("Error in Retrieving VRF", err, nil)...)
+		return diags
+	}
+
+	families := []interface{}{}
+	for family_type, family := range tmp_vrf.AddressFamilies {
+		families = append(families, map[string]interface{}{
+			"type":                 family_type,
+			"import_route_targets": family.ImportRouteTargets,
+			"export_route_targets": family.ExportRouteTargets,
+		})
+	}
+
+	d.Set("name", tmp_vrf.Name)
+	d.Set("rd", tmp_vrf.Rd)
+	d.Set("enable", !tmp_vrf.Shutdown)
+	d.Set("address_family", families)
+
+	return diags
+}
+
+func resourceVrfUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_vrf, diags := vrfFromResourceData(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	err = tmp_vrf.Update(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Updating VRF does not exist", err, cty.GetAttrPath("name"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Updating VRF", err, nil)...)
+		return diags
+	}
+
+	return resourceVrfRead(ctx, d, m)
+}
+
+func resourceVrfDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+
+	sw, diags := switchClient(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	tmp_vrf := vrf{
+		Name: d.Get("name").(string),
+	}
+
+	err = tmp_vrf.Delete(ctx, sw)
+
+	if requestFailed(err) {
+		if isNotFound(err) {
+			diags = append(diags, errorDiagnostics("Error Deleting VRF does not exist", err, cty.GetAttrPath("name"))...)
+			return diags
+		}
+		diags = append(diags, errorDiagnostics("Error in Deleting VRF", err, nil)...)
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceVrfImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is the VRF name optionally followed by @switch, e.g.
+	// terraform import aoscx_vrf.blue blue
+	name, switch_name := parseSwitchID(d.Id())
+
+	if name == "" {
+		return nil, fmt.Errorf("Invalid VRF import ID %q, expected a VRF name", d.Id())
+	}
+
+	d.Set("switch", switch_name)
+	d.SetId(switchID(d, name))
+	d.Set("name", name)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,117 @@
+package aoscx
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testVrfConfig(m *mockSwitch, rd string, enable bool, ipv6 bool) string {
+	config := testProviderConfig(m) + fmt.Sprintf(`
+resource "aoscx_vrf" "test" {
+  name   = "blue"
+  rd     = %q
+  enable = %t
+
+  address_family {
+    type                 = "ipv4-unicast"
+    import_route_targets = ["65000:10", "65000:20"]
+    export_route_targets = ["65000:10"]
+  }
+`, rd, enable)
+
+	if ipv6 {
+		config += `
+  address_family {
+    type                 = "ipv6-unicast"
+    import_route_targets = ["65000:10"]
+    export_route_targets = ["65000:10"]
+  }
+`
+	}
+
+	return config + `
+}
+
+resource "aoscx_l3_interface" "test" {
+  interface = "1/1/4"
+  ipv4      = ["10.0.0.1/31"]
+  vrf       = aoscx_vrf.test.name
+}
+`
+}
+
+func TestResourceVrf(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy:      testCheckMockDestroyed(m, "system/vrfs/blue"),
+		Steps: []resource.TestStep{
+			{
+				Config: testVrfConfig(m, "65000:10", true, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_vrf.test", "id", "blue"),
+					resource.TestCheckResourceAttr("aoscx_vrf.test", "rd", "65000:10"),
+					resource.TestCheckResourceAttr("aoscx_vrf.test", "enable", "true"),
+					resource.TestCheckResourceAttr("aoscx_vrf.test", "address_family.#", "1"),
+					resource.TestCheckResourceAttr("aoscx_l3_interface.test", "vrf", "blue"),
+					testCheckMockExists(m, "system/vrfs/blue/vrf_address_families/ipv4-unicast"),
+				),
+			},
+			{
+				Config: testVrfConfig(m, "10.0.0.1:10", false, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_vrf.test", "rd", "10.0.0.1:10"),
+					resource.TestCheckResourceAttr("aoscx_vrf.test", "enable", "false"),
+					resource.TestCheckResourceAttr("aoscx_vrf.test", "address_family.#", "2"),
+					testCheckMockExists(m, "system/vrfs/blue/vrf_address_families/ipv6-unicast"),
+				),
+			},
+			{
+				Config: testVrfConfig(m, "10.0.0.1:10", false, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_vrf.test", "address_family.#", "1"),
+					testCheckMockDestroyed(m, "system/vrfs/blue/vrf_address_families/ipv6-unicast"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_vrf.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					m.patch("system/vrfs/blue", map[string]interface{}{"rd": "65000:99"})
+				},
+				Config:             testVrfConfig(m, "10.0.0.1:10", false, false),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				PreConfig: func() {
+					m.remove("system/vrfs/blue/vrf_address_families/ipv4-unicast")
+				},
+				Config:             testVrfConfig(m, "10.0.0.1:10", false, false),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceVrfInvalidRouteTarget(t *testing.T) {
+	m := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testVrfConfig(m, "blue", true, false),
+				ExpectError: regexp.MustCompile(`expected a route distinguisher`),
+			},
+		},
+	})
+}
@@ -0,0 +1,346 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/aruba/aoscxgo"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// macRegexp matches MAC addresses in the format used by AOS-CX.
+var macRegexp = regexp.MustCompile(`^([0-9a-f]{2}:){5}[0-9a-f]{2}$`)
+
+// vsxRoles are the blocks of the aoscx_vsx resource, named after the role of
+// their switch.
+var vsxRoles = []string{"primary", "secondary"}
+
+func vsxMemberSchema(role string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Required:    true,
+		MinItems:    1,
+		MaxItems:    1,
+		Description: fmt.Sprintf("Switch of the pair taking the %s role", role),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"switch": &schema.Schema{
+					Type:        schema.TypeString,
+					Required:    true,
+					ForceNew:    true,
+					Description: "Key of the provider switches map or hostname of the switch",
+				},
+				"keepalive_source": &schema.Schema{
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.IsIPAddress,
+					Description:  "Source IP address of keepalives sent by the switch, the other switch uses it as keepalive peer",
+				},
+			},
+		},
+	}
+}
+
+func resourceVsx() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Resource to configure a VSX pair of AOS-CX switches. Both switches are configured from one resource so they always get consistent settings.",
+		CreateContext: resourceVsxCreate,
+		ReadContext:   resourceVsxRead,
+		UpdateContext: resourceVsxUpdate,
+		DeleteContext: resourceVsxDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVsxImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Read:   schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		CustomizeDiff: resourceVsxCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"primary":   vsxMemberSchema("primary"),
+			"secondary": vsxMemberSchema("secondary"),
+			"isl_lag": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^lag[1-9][0-9]*$`), "expected a LAG name such as lag256"),
+				Description:  "LAG used as inter-switch link on both switches, reference aoscx_lag.<name>.name so it is created first",
+			},
+			"keepalive_vrf": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "default",
+				Description: "VRF keepalives are sent in",
+			},
+			"system_mac": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringMatch(macRegexp, "expected a lowercase MAC address such as 02:00:00:00:01:00"),
+				Description:  "Unicast MAC address both switches use as VSX system MAC",
+			},
+			"config_sync": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Synchronize the configuration of config_sync_features from the primary to the secondary switch",
+			},
+			"config_sync_features": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Features synchronized by VSX config-sync, e.g. vsx-global, acl or static-routes",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+		},
+	}
+}
+
+// resourceVsxCustomizeDiff checks that the settings of the two switches are
+// consistent with each other.
+func resourceVsxCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	primary_switch := d.Get("primary.0.switch").(string)
+	secondary_switch := d.Get("secondary.0.switch").(string)
+	if primary_switch != "" && primary_switch == secondary_switch {
+		return fmt.Errorf("primary and secondary must be different switches, both are %s", primary_switch)
+	}
+
+	primary_source := net.ParseIP(d.Get("primary.0.keepalive_source").(string))
+	secondary_source := net.ParseIP(d.Get("secondary.0.keepalive_source").(string))
+	if primary_source != nil && secondary_source != nil {
+		if primary_source.Equal(secondary_source) {
+			return fmt.Errorf("primary and secondary keepalive_source must differ, both are %s", primary_source)
+		}
+		if (primary_source.To4() == nil) != (secondary_source.To4() == nil) {
+			return fmt.Errorf("primary and secondary keepalive_source must be of the same address family")
+		}
+	}
+
+	if system_mac := d.Get("system_mac").(string); system_mac != "" {
+		if hw, err := net.ParseMAC(system_mac); err == nil && hw[0]&1 == 1 {
+			return fmt.Errorf("system_mac %s is a multicast address", system_mac)
+		}
+	}
+
+	return nil
+}
+
+// vsxFromResourceData builds the VSX configuration of the switch taking
+// role, the keepalive peer is the keepalive source of the other switch.
+func vsxFromResourceData(d *schema.ResourceData, role string) vsx {
+	peer_role := vsxRoles[0]
+	if role == vsxRoles[0] {
+		peer_role = vsxRoles[1]
+	}
+
+	return vsx{
+		Role:               role,
+		IslPort:            restURI("system/interfaces/" + restPath(d.Get("isl_lag").(string))),
+		KeepalivePeer:      d.Get(peer_role + ".0.keepalive_source").(string),
+		KeepaliveSource:    d.Get(role + ".0.keepalive_source").(string),
+		KeepaliveVrf:       restURI("system/vrfs/" + restPath(d.Get("keepalive_vrf").(string))),
+		SystemMac:          d.Get("system_mac").(string),
+		ConfigSyncDisable:  !d.Get("config_sync").(bool),
+		ConfigSyncFeatures: sortedStrings(d.Get("config_sync_features").(*schema.Set)),
+	}
+}
+
+// vsxClients returns the clients of the primary and secondary switch.
+func vsxClients(ctx context.Context, d *schema.ResourceData, m interface{}) (map[string]*aoscxgo.Client, diag.Diagnostics) {
+	clients := map[string]*aoscxgo.Client{}
+
+	for _, role := range vsxRoles {
+		sw, diags := namedSwitchClient(ctx, m, d.Get(role+".0.switch").(string), cty.GetAttrPath(role).IndexInt(0).GetAttr("switch"))
+		if diags.HasError() {
+			return nil, diags
+		}
+		clients[role] = sw
+	}
+
+	return clients, nil
+}
+
+// vsxPick returns the value of a setting shared by both switches. A switch
+// that differs from the state wins so drift on either switch shows up in the
+// plan.
+func vsxPick(state string, primary string, secondary string) string {
+	if primary != state {
+		return primary
+	}
+	return secondary
+}
+
+func resourceVsxCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	clients, diags := vsxClients(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	for index, role := range vsxRoles {
+		tmp_vsx := vsxFromResourceData(d, role)
+
+		err := tmp_vsx.Put(ctx, clients[role])
+
+		if requestFailed(err) {
+			// A pair configured on one switch only is kept in state so it
+			// is tainted and replaced on the next apply
+			if index > 0 {
+				d.SetId(d.Get("primary.0.switch").(string) + "," + d.Get("secondary.0.switch").(string))
+			}
+			diags = append(diags, errorDiagnostics(fmt.Sprintf("Error in Creating VSX on %s switch", role), err, cty.GetAttrPath(role))...)
+			return diags
+		}
+	}
+
+	d.SetId(d.Get("primary.0.switch").(string) + "," + d.Get("secondary.0.switch").(string))
+
+	return resourceVsxRead(ctx, d, m)
+}
+
+func resourceVsxRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	clients, diags := vsxClients(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	members := map[string]vsx{}
+
+	for _, role := range vsxRoles {
+		tmp_vsx := vsx{}
+
+		err := tmp_vsx.Get(ctx, clients[role])
+
+		if isNotFound(err) {
+			// VSX was removed outside of Terraform, the pair is recreated
+			d.SetId("")
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "VSX Not Found",
+				Detail:   fmt.Sprintf("VSX is not configured on %s switch %s", role, d.Get(role+".0.switch").(string)),
+			})
+			return diags
+		} else if requestFailed(err) {
+			diags = append(diags, errorDiagnostics(fmt.Sprintf("Error in Retrieving VSX of %s switch", role), err, nil)...)
+			return diags
+		}
+
+		members[role] = tmp_vsx
+	}
+
+	primary := members["primary"]
+	secondary := members["secondary"]
+
+	for _, role := range vsxRoles {
+		peer := secondary
+		if role == "secondary" {
+			peer = primary
+		}
+
+		// A switch with the wrong role or not peering with the keepalive
+		// source of the other switch shows up as drift of its block
+		keepalive_source := members[role].KeepaliveSource
+		if members[role].Role != role || members[role].KeepaliveSource != peer.KeepalivePeer {
+			keepalive_source = ""
+		}
+
+		d.Set(role, []interface{}{
+			map[string]interface{}{
+				"switch":           d.Get(role + ".0.switch").(string),
+				"keepalive_source": keepalive_source,
+			},
+		})
+	}
+
+	d.Set("isl_lag", uriKey(vsxPick(restURI("system/interfaces/"+restPath(d.Get("isl_lag").(string))), primary.IslPort, secondary.IslPort)))
+	d.Set("keepalive_vrf", uriKey(vsxPick(restURI("system/vrfs/"+restPath(d.Get("keepalive_vrf").(string))), primary.KeepaliveVrf, secondary.KeepaliveVrf)))
+	d.Set("system_mac", vsxPick(d.Get("system_mac").(string), primary.SystemMac, secondary.SystemMac))
+
+	config_sync := !primary.ConfigSyncDisable
+	if primary.ConfigSyncDisable != secondary.ConfigSyncDisable && config_sync == d.Get("config_sync").(bool) {
+		config_sync = !secondary.ConfigSyncDisable
+	}
+	d.Set("config_sync", config_sync)
+
+	features := primary.ConfigSyncFeatures
+	if strings.Join(features, ",") == strings.Join(sortedStrings(d.Get("config_sync_features").(*schema.Set)), ",") {
+		features = secondary.ConfigSyncFeatures
+	}
+	d.Set("config_sync_features", features)
+
+	return diags
+}
+
+func resourceVsxUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	clients, diags := vsxClients(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	for _, role := range vsxRoles {
+		tmp_vsx := vsxFromResourceData(d, role)
+
+		err := tmp_vsx.Put(ctx, clients[role])
+
+		if requestFailed(err) {
+			diags = append(diags, errorDiagnostics(fmt.Sprintf("Error in Updating VSX on %s switch", role), err, cty.GetAttrPath(role))...)
+			return diags
+		}
+	}
+
+	return resourceVsxRead(ctx, d, m)
+}
+
+func resourceVsxDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	clients, diags := vsxClients(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	// The secondary is removed first so it never runs VSX without a primary
+	for index := len(vsxRoles) - 1; index >= 0; index-- {
+		role := vsxRoles[index]
+		tmp_vsx := vsx{}
+
+		err := tmp_vsx.Delete(ctx, clients[role])
+
+		if requestFailed(err) && !isNotFound(err) {
+			diags = append(diags, errorDiagnostics(fmt.Sprintf("Error in Deleting VSX on %s switch", role), err, nil)...)
+			return diags
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceVsxImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import ID is the primary and secondary switch separated by a comma,
+	// e.g. terraform import aoscx_vsx.pair leaf1a,leaf1b
+	primary_switch, secondary_switch, found := strings.Cut(d.Id(), ",")
+
+	if !found || primary_switch == "" || secondary_switch == "" {
+		return nil, fmt.Errorf("Invalid VSX import ID %q, expected <primary switch>,<secondary switch>", d.Id())
+	}
+
+	d.Set("primary", []interface{}{map[string]interface{}{"switch": primary_switch}})
+	d.Set("secondary", []interface{}{map[string]interface{}{"switch": secondary_switch}})
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,186 @@
+package aoscx
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testVsxConfig(leaf1 *mockSwitch, leaf2 *mockSwitch, body string) string {
+	return fmt.Sprintf(`
+provider "aoscx" {
+  username = "admin"
+  password = "admin"
+  insecure = true
+  switches = {
+    leaf1 = %q
+    leaf2 = %q
+  }
+}
+
+resource "aoscx_lag" "isl" {
+  for_each = toset(["leaf1", "leaf2"])
+  switch   = each.value
+  name     = "lag256"
+  members  = ["1/1/7", "1/1/8"]
+}
+
+resource "aoscx_vsx" "test" {
+  isl_lag = aoscx_lag.isl["leaf1"].name
+`, leaf1.hostname(), leaf2.hostname()) + body + `
+}
+`
+}
+
+const testVsxMembers = `
+  primary {
+    switch           = "leaf1"
+    keepalive_source = "192.168.0.1"
+  }
+
+  secondary {
+    switch           = "leaf2"
+    keepalive_source = "192.168.0.2"
+  }
+`
+
+func TestResourceVsx(t *testing.T) {
+	leaf1 := newMockSwitch(t)
+	leaf2 := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckMockDestroyed(leaf1, vsxPath),
+			testCheckMockDestroyed(leaf2, vsxPath),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testVsxConfig(leaf1, leaf2, testVsxMembers+`
+  system_mac = "02:00:00:00:01:00"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_vsx.test", "id", "leaf1,leaf2"),
+					resource.TestCheckResourceAttr("aoscx_vsx.test", "isl_lag", "lag256"),
+					resource.TestCheckResourceAttr("aoscx_vsx.test", "keepalive_vrf", "default"),
+					resource.TestCheckResourceAttr("aoscx_vsx.test", "config_sync", "true"),
+					testCheckMockExists(leaf1, vsxPath),
+					testCheckMockExists(leaf2, vsxPath),
+					func(s *terraform.State) error {
+						for role, m := range map[string]*mockSwitch{"primary": leaf1, "secondary": leaf2} {
+							obj := m.get(vsxPath)
+							if obj["device_role"] != role {
+								return fmt.Errorf("expected device_role %s, got %v", role, obj["device_role"])
+							}
+						}
+						if peer := leaf1.get(vsxPath)["keepalive_peer_ip"]; peer != "192.168.0.2" {
+							return fmt.Errorf("expected primary keepalive peer 192.168.0.2, got %v", peer)
+						}
+						if peer := leaf2.get(vsxPath)["keepalive_peer_ip"]; peer != "192.168.0.1" {
+							return fmt.Errorf("expected secondary keepalive peer 192.168.0.1, got %v", peer)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testVsxConfig(leaf1, leaf2, testVsxMembers+`
+  system_mac           = "02:00:00:00:02:00"
+  config_sync_features = ["vsx-global", "acl"]
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aoscx_vsx.test", "system_mac", "02:00:00:00:02:00"),
+					resource.TestCheckResourceAttr("aoscx_vsx.test", "config_sync_features.#", "2"),
+					resource.TestCheckTypeSetElemAttr("aoscx_vsx.test", "config_sync_features.*", "acl"),
+				),
+			},
+			{
+				ResourceName:      "aoscx_vsx.test",
+				ImportState:       true,
+				ImportStateId:     "leaf1,leaf2",
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: func() {
+					leaf2.patch(vsxPath, map[string]interface{}{"system_mac": "02:00:00:00:03:00"})
+				},
+				Config: testVsxConfig(leaf1, leaf2, testVsxMembers+`
+  system_mac           = "02:00:00:00:02:00"
+  config_sync_features = ["vsx-global", "acl"]
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				PreConfig: func() {
+					leaf1.patch(vsxPath, map[string]interface{}{"device_role": "secondary"})
+				},
+				Config: testVsxConfig(leaf1, leaf2, testVsxMembers+`
+  system_mac           = "02:00:00:00:02:00"
+  config_sync_features = ["vsx-global", "acl"]
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				PreConfig: func() {
+					leaf2.remove(vsxPath)
+				},
+				Config: testVsxConfig(leaf1, leaf2, testVsxMembers+`
+  system_mac           = "02:00:00:00:02:00"
+  config_sync_features = ["vsx-global", "acl"]
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceVsxInvalid(t *testing.T) {
+	leaf1 := newMockSwitch(t)
+	leaf2 := newMockSwitch(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testVsxConfig(leaf1, leaf2, `
+  primary {
+    switch           = "leaf1"
+    keepalive_source = "192.168.0.1"
+  }
+
+  secondary {
+    switch           = "leaf1"
+    keepalive_source = "192.168.0.2"
+  }
+`),
+				ExpectError: regexp.MustCompile(`primary and secondary must be different switches`),
+			},
+			{
+				Config: testVsxConfig(leaf1, leaf2, `
+  primary {
+    switch           = "leaf1"
+    keepalive_source = "192.168.0.1"
+  }
+
+  secondary {
+    switch           = "leaf2"
+    keepalive_source = "2001:db8::2"
+  }
+`),
+				ExpectError: regexp.MustCompile(`keepalive_source must be of the same address family`),
+			},
+			{
+				Config: testVsxConfig(leaf1, leaf2, testVsxMembers+`
+  system_mac = "01:00:5e:00:00:01"
+`),
+				ExpectError: regexp.MustCompile(`is a multicast address`),
+			},
+		},
+	})
+}
@@ -0,0 +1,108 @@
+package aoscx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aruba/aoscxgo"
+)
+
+// restVersion is the AOS-CX REST API version used for requests that are not
+// covered by aoscxgo objects.
+const restVersion = "v10.09"
+
+// restPath builds a REST path relative to /rest/<version>/ from the given
+// segments, escaping each one so interface names like 1/1/1 become 1%2F1%2F1.
+func restPath(segments ...string) string {
+	escaped := make([]string, len(segments))
+	for index, segment := range segments {
+		escaped[index] = url.PathEscape(segment)
+	}
+	return strings.Join(escaped, "/")
+}
+
+// restURI returns the URI AOS-CX uses to reference the object at path, e.g.
+// /rest/v10.09/system/interfaces/1%2F1%2F1.
+func restURI(path string) string {
+	return "/rest/" + restVersion + "/" + path
+}
+
+// uriKey returns the unescaped key of the object referenced by uri, e.g. 1/1/1
+// for /rest/v10.09/system/interfaces/1%2F1%2F1.
+func uriKey(uri string) string {
+	key := uri[strings.LastIndex(uri, "/")+1:]
+	if unescaped, err := url.PathUnescape(key); err == nil {
+		return unescaped
+	}
+	return key
+}
+
+// restRequest performs a REST call using the session of the aoscxgo client.
+// body is JSON encoded when not nil and the response is decoded into result
+// when result is not nil.
+func restRequest(ctx context.Context, sw *aoscxgo.Client, method string, path string, body interface{}, result interface{}) error {
+	var req_body io.Reader
+
+	if body != nil {
+		json_body, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		req_body = bytes.NewReader(json_body)
+	}
+
+	req_url := fmt.Sprintf("https://%s/rest/%s/%s", sw.Hostname, restVersion, path)
+
+	req, err := http.NewRequestWithContext(ctx, method, req_url, req_body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if sw.Cookie != nil {
+		req.AddCookie(sw.Cookie)
+	}
+
+	client := &http.Client{Transport: sw.Transport}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	resp_body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &apiError{
+			Method:     method,
+			URI:        req.URL.RequestURI(),
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Body:       string(resp_body),
+		}
+	}
+
+	if result != nil && len(resp_body) > 0 {
+		return json.Unmarshal(resp_body, result)
+	}
+
+	return nil
+}
+
+// restGet retrieves the object at path and decodes it into result.
+func restGet(ctx context.Context, sw *aoscxgo.Client, path string, result interface{}) error {
+	return restRequest(ctx, sw, http.MethodGet, path, nil, result)
+}
@@ -0,0 +1,158 @@
+package aoscx
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// retryTransport retries requests that failed with a transient error using
+// jittered exponential backoff.
+//
+// Idempotent requests are retried on connection errors and on 429, 502, 503
+// and 504 responses. Other requests are only retried on 429 and 503, where
+// the switch rejected the request without applying it, e.g. while the config
+// DB is locked during a checkpoint.
+type retryTransport struct {
+	next        http.RoundTripper
+	max_retries int
+	min_backoff time.Duration
+	max_backoff time.Duration
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		tmp_req := req
+		if attempt > 0 {
+			tmp_req = req.Clone(ctx)
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				tmp_req.Body = body
+			}
+		}
+
+		resp, err := t.next.RoundTrip(tmp_req)
+
+		if attempt >= t.max_retries || !t.retryable(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+
+		reason := ""
+		if err != nil {
+			reason = err.Error()
+		} else {
+			reason = resp.Status
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		tflog.Warn(ctx, "Retrying AOS-CX REST request", map[string]interface{}{
+			"method":  req.Method,
+			"uri":     req.URL.Path,
+			"reason":  reason,
+			"attempt": attempt + 1,
+			"backoff": wait.String(),
+		})
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retryable reports whether the outcome of req is a transient failure worth
+// retrying.
+func (t *retryTransport) retryable(req *http.Request, resp *http.Response, err error) bool {
+	// A body that cannot be recreated cannot be sent again
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		return isIdempotent(req.Method) && isTransientNetworkError(err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return isIdempotent(req.Method)
+	}
+
+	return false
+}
+
+// backoff returns how long to wait before retry number attempt+1. A
+// Retry-After header sent by the switch takes precedence.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			if wait := time.Duration(seconds) * time.Second; wait < t.max_backoff {
+				return wait
+			}
+			return t.max_backoff
+		}
+	}
+
+	wait := t.min_backoff << uint(attempt)
+	if wait > t.max_backoff || wait <= 0 {
+		wait = t.max_backoff
+	}
+
+	// Equal jitter keeps at least half of the backoff while spreading out
+	// retries of concurrent requests.
+	half := wait / 2
+	if half <= 0 {
+		return wait
+	}
+
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// isTransientNetworkError reports whether err is a connection failure that is
+// likely to succeed when retried.
+func isTransientNetworkError(err error) bool {
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+
+	var net_err net.Error
+	if errors.As(err, &net_err) && net_err.Timeout() {
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,258 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// logoutTimeout bounds how long CloseSessions waits for each switch, the
+// plugin is killed shortly after Terraform asks it to stop.
+const logoutTimeout = 2 * time.Second
+
+// session owns the REST login of one switch. It is registered as the https
+// handler of the transport given to aoscxgo so every request carries the
+// current session cookie, an expired cookie is replaced by logging in again
+// and the session can be logged out when the provider shuts down.
+type session struct {
+	hostname string
+	username string
+	password string
+
+	// transport performs the actual HTTP requests
+	transport http.RoundTripper
+
+	mu      sync.Mutex
+	version string
+	cookie  *http.Cookie
+}
+
+var (
+	sessionsMu sync.Mutex
+	sessions   []*session
+)
+
+func newSession(hostname string, username string, password string, transport http.RoundTripper) *session {
+	return &session{
+		hostname:  hostname,
+		username:  username,
+		password:  password,
+		version:   restVersion,
+		transport: transport,
+	}
+}
+
+// clientTransport returns the transport to hand to aoscxgo.Client. All https
+// requests made through it are routed to the session.
+func (s *session) clientTransport() *http.Transport {
+	tr := &http.Transport{}
+	tr.RegisterProtocol("https", s)
+	return tr
+}
+
+// register records the session so it is logged out by CloseSessions.
+func (s *session) register() {
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+
+	sessions = append(sessions, s)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (s *session) RoundTrip(req *http.Request) (*http.Response, error) {
+	s.trackVersion(req.URL)
+
+	if strings.HasSuffix(req.URL.Path, "/login") {
+		resp, err := s.transport.RoundTrip(req)
+		if err == nil && resp.StatusCode == http.StatusOK {
+			s.setCookie(resp.Cookies())
+		}
+		return resp, err
+	}
+
+	sent_cookie := s.currentCookie()
+
+	resp, err := s.transport.RoundTrip(withCookie(req, sent_cookie))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || strings.HasSuffix(req.URL.Path, "/logout") {
+		return resp, err
+	}
+
+	// The session expired or was cleared on the switch, log in again and
+	// replay the request once if its body can be recreated.
+	if req.Body != nil && req.GetBody == nil {
+		return resp, err
+	}
+
+	if err := s.relogin(req.Context(), sent_cookie); err != nil {
+		log.Printf("[WARN] Unable to renew REST session on %s: %v", s.hostname, err)
+		return resp, nil
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	log.Printf("[DEBUG] Retrying %s %s on %s with renewed REST session", req.Method, req.URL.Path, s.hostname)
+
+	return s.transport.RoundTrip(withCookie(retry, s.currentCookie()))
+}
+
+// trackVersion remembers the REST version used by aoscxgo so logins and
+// logouts go to the same API version.
+func (s *session) trackVersion(req_url *url.URL) {
+	segments := strings.Split(strings.TrimPrefix(req_url.Path, "/"), "/")
+	if len(segments) < 2 || segments[0] != "rest" {
+		return
+	}
+
+	s.mu.Lock()
+	s.version = segments[1]
+	s.mu.Unlock()
+}
+
+func (s *session) currentCookie() *http.Cookie {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.cookie
+}
+
+func (s *session) setCookie(cookies []*http.Cookie) {
+	if len(cookies) == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	s.cookie = cookies[0]
+	s.mu.Unlock()
+}
+
+// relogin performs a new login unless another request already renewed the
+// session since expired_cookie was sent.
+func (s *session) relogin(ctx context.Context, expired_cookie *http.Cookie) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cookie != nil && s.cookie != expired_cookie {
+		return nil
+	}
+
+	form := url.Values{}
+	form.Set("username", s.username)
+	form.Set("password", s.password)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url("login"), strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.transport.RoundTrip(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("login returned %s", resp.Status)
+	}
+
+	if cookies := resp.Cookies(); len(cookies) > 0 {
+		s.cookie = cookies[0]
+	}
+
+	return nil
+}
+
+// logout ends the REST session on the switch.
+func (s *session) logout(ctx context.Context) error {
+	cookie := s.currentCookie()
+	if cookie == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	logout_url := s.url("logout")
+	s.mu.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, logout_url, nil)
+	if err != nil {
+		return err
+	}
+	req.AddCookie(cookie)
+
+	resp, err := s.transport.RoundTrip(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	s.mu.Lock()
+	s.cookie = nil
+	s.mu.Unlock()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("logout returned %s", resp.Status)
+	}
+
+	return nil
+}
+
+// url returns the REST URL of path, s.mu must be held.
+func (s *session) url(path string) string {
+	return fmt.Sprintf("https://%s/rest/%s/%s", s.hostname, s.version, path)
+}
+
+// withCookie returns a copy of req carrying cookie instead of the cookie set
+// by the caller.
+func withCookie(req *http.Request, cookie *http.Cookie) *http.Request {
+	if cookie == nil {
+		return req
+	}
+
+	tmp_req := req.Clone(req.Context())
+	tmp_req.Header.Del("Cookie")
+	tmp_req.AddCookie(cookie)
+
+	return tmp_req
+}
+
+// CloseSessions logs out every REST session opened by the provider. It is
+// called when the plugin server stops so sessions don't count against the
+// switch's per user session limit until they time out.
+func CloseSessions() {
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, s := range sessions {
+		wg.Add(1)
+		go func(s *session) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(context.Background(), logoutTimeout)
+			defer cancel()
+
+			if err := s.logout(ctx); err != nil {
+				log.Printf("[WARN] Unable to log out of %s: %v", s.hostname, err)
+			}
+		}(s)
+	}
+	wg.Wait()
+
+	sessions = nil
+}
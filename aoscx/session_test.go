@@ -0,0 +1,123 @@
+package aoscx
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testSession logs in to m through a session the way aoscxgo.Connect does.
+func testSession(t *testing.T, m *mockSwitch) *session {
+	transport := &retryTransport{
+		next:        &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+		max_retries: 2,
+		min_backoff: 10 * time.Millisecond,
+		max_backoff: 50 * time.Millisecond,
+	}
+	sess := newSession(m.hostname(), "admin", "admin", transport)
+
+	form := url.Values{}
+	form.Set("username", "admin")
+	form.Set("password", "admin")
+
+	resp := testRequest(t, sess, http.MethodPost, "login", form.Encode())
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("login returned %s", resp.Status)
+	}
+
+	return sess
+}
+
+func testRequest(t *testing.T, rt http.RoundTripper, method string, path string, body string) *http.Response {
+	req, err := http.NewRequest(method, "https://"+testHostname(rt)+"/rest/"+restVersion+"/"+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path == "login" {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	return resp
+}
+
+func testHostname(rt http.RoundTripper) string {
+	return rt.(*session).hostname
+}
+
+func TestSessionRelogin(t *testing.T) {
+	m := newMockSwitch(t)
+	sess := testSession(t, m)
+
+	if resp := testRequest(t, sess, http.MethodGet, "system/vlans/1", ""); resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET returned %s", resp.Status)
+	}
+
+	m.expireSessions()
+
+	if resp := testRequest(t, sess, http.MethodPut, "system/vlans/1", `{"name": "renamed"}`); resp.StatusCode != http.StatusOK {
+		t.Fatalf("PUT after session expiry returned %s", resp.Status)
+	}
+	if logins := m.loginCount(); logins != 2 {
+		t.Fatalf("expected 2 logins, got %d", logins)
+	}
+	if name := m.get("system/vlans/1")["name"]; name != "renamed" {
+		t.Fatalf("PUT was not replayed, name is %v", name)
+	}
+}
+
+func TestSessionLogout(t *testing.T) {
+	m := newMockSwitch(t)
+	sess := testSession(t, m)
+
+	if sessions := m.activeSessions(); sessions != 1 {
+		t.Fatalf("expected 1 session, got %d", sessions)
+	}
+
+	if err := sess.logout(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if sessions := m.activeSessions(); sessions != 0 {
+		t.Fatalf("expected no session after logout, got %d", sessions)
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	m := newMockSwitch(t)
+	sess := testSession(t, m)
+
+	m.injectFault("GET", "system/vlans/1", http.StatusServiceUnavailable, 2)
+	if resp := testRequest(t, sess, http.MethodGet, "system/vlans/1", ""); resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET returned %s after retries", resp.Status)
+	}
+	if count := m.requestCount("GET", "system/vlans/1"); count != 3 {
+		t.Fatalf("expected 3 attempts, got %d", count)
+	}
+
+	// POST is not idempotent and is not retried on a gateway error
+	m.injectFault("POST", "system/vlans", http.StatusBadGateway, 1)
+	if resp := testRequest(t, sess, http.MethodPost, "system/vlans", `{"id": 42, "name": "test"}`); resp.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected POST to fail with 502, got %s", resp.Status)
+	}
+	if m.exists("system/vlans/42") {
+		t.Fatal("POST was retried")
+	}
+
+	// Retries give up after max_retries
+	m.injectFault("DELETE", "system/vlans/1", http.StatusServiceUnavailable, -1)
+	if resp := testRequest(t, sess, http.MethodDelete, "system/vlans/1", ""); resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected DELETE to fail with 503, got %s", resp.Status)
+	}
+	if count := m.requestCount("DELETE", "system/vlans/1"); count != 3 {
+		t.Fatalf("expected 3 attempts, got %d", count)
+	}
+}
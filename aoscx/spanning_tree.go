@@ -0,0 +1,260 @@
+package aoscx
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/aruba/aoscxgo"
+)
+
+// Spanning tree is configured in the stp_config attribute of the system and
+// of interfaces, MST instances are the mstp_instances collection. aoscxgo
+// does not cover spanning tree.
+
+func mstInstancePath(instance_id int) string {
+	return "system/mstp_instances/" + strconv.Itoa(instance_id)
+}
+
+// spanningTree is the global spanning tree configuration of a switch.
+// Priority is the bridge priority divided by 4096. ConfigName and
+// ConfigRevision identify the MST region, instances other than the CIST are
+// only used in mstp mode.
+type spanningTree struct {
+	Mode           string
+	Priority       int
+	ConfigName     string
+	ConfigRevision int
+
+	Instances []mstInstance
+}
+
+// mstInstance maps VLANs to an MST instance with its own bridge priority.
+type mstInstance struct {
+	Id       int
+	Vlans    []int
+	Priority int
+}
+
+// spanningTreeConfig is the stp_config attribute of the system.
+type spanningTreeConfig struct {
+	AdminStatus    string `json:"admin_status"`
+	Mode           string `json:"mode"`
+	Priority       int    `json:"priority"`
+	ConfigName     string `json:"mstp_config_name"`
+	ConfigRevision int    `json:"mstp_config_revision"`
+}
+
+// mstInstanceResponse is the REST representation of an MST instance.
+type mstInstanceResponse struct {
+	Id       int      `json:"mstp_instance_id"`
+	Vlans    []string `json:"vlans"`
+	Priority int      `json:"priority"`
+}
+
+func (i *mstInstance) body() map[string]interface{} {
+	vlans := []string{}
+	for _, vlan_id := range i.Vlans {
+		vlans = append(vlans, restURI("system/vlans/"+strconv.Itoa(vlan_id)))
+	}
+
+	return map[string]interface{}{
+		"vlans":    vlans,
+		"priority": i.Priority,
+	}
+}
+
+// enabled reports whether spanning tree is enabled, a switch without
+// spanning tree configuration has it disabled.
+func (s *spanningTree) enabled(ctx context.Context, sw *aoscxgo.Client) (bool, error) {
+	// Attributes missing from stp_config have their default value
+	tmp_system := struct {
+		StpConfig spanningTreeConfig `json:"stp_config"`
+	}{
+		StpConfig: spanningTreeConfig{Mode: "mstp", Priority: 8},
+	}
+
+	err := restGet(ctx, sw, "system?attributes=stp_config", &tmp_system)
+	if err != nil {
+		return false, err
+	}
+
+	s.Mode = tmp_system.StpConfig.Mode
+	s.Priority = tmp_system.StpConfig.Priority
+	s.ConfigName = tmp_system.StpConfig.ConfigName
+	s.ConfigRevision = tmp_system.StpConfig.ConfigRevision
+
+	return tmp_system.StpConfig.AdminStatus == "up", nil
+}
+
+// Get retrieves the spanning tree configuration, enabled is false when
+// spanning tree is disabled.
+func (s *spanningTree) Get(ctx context.Context, sw *aoscxgo.Client) (enabled bool, err error) {
+	enabled, err = s.enabled(ctx, sw)
+	if err != nil || !enabled {
+		return enabled, err
+	}
+
+	instances := map[string]mstInstanceResponse{}
+
+	err = restGet(ctx, sw, "system/mstp_instances?depth=2", &instances)
+	if err != nil && !isNotFound(err) {
+		return enabled, err
+	}
+
+	s.Instances = []mstInstance{}
+	for key, instance := range instances {
+		tmp_instance := mstInstance{
+			Id:       instance.Id,
+			Vlans:    []int{},
+			Priority: instance.Priority,
+		}
+		if tmp_instance.Id == 0 {
+			tmp_instance.Id, _ = strconv.Atoi(key)
+		}
+		for _, uri := range instance.Vlans {
+			vlan_id, _ := strconv.Atoi(uriKey(uri))
+			tmp_instance.Vlans = append(tmp_instance.Vlans, vlan_id)
+		}
+		sort.Ints(tmp_instance.Vlans)
+		s.Instances = append(s.Instances, tmp_instance)
+	}
+	sort.Slice(s.Instances, func(i, j int) bool {
+		return s.Instances[i].Id < s.Instances[j].Id
+	})
+
+	return enabled, nil
+}
+
+// Update enables spanning tree with the configuration of s and reconciles the
+// MST instances by instance ID.
+func (s *spanningTree) Update(ctx context.Context, sw *aoscxgo.Client) error {
+	current := spanningTree{}
+	_, err := current.Get(ctx, sw)
+	if err != nil {
+		return err
+	}
+
+	err = restRequest(ctx, sw, http.MethodPatch, "system", map[string]interface{}{
+		"stp_config": spanningTreeConfig{
+			AdminStatus:    "up",
+			Mode:           s.Mode,
+			Priority:       s.Priority,
+			ConfigName:     s.ConfigName,
+			ConfigRevision: s.ConfigRevision,
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	existing := map[int]mstInstance{}
+	for _, instance := range current.Instances {
+		existing[instance.Id] = instance
+	}
+
+	for _, instance := range s.Instances {
+		current_instance, ok := existing[instance.Id]
+		delete(existing, instance.Id)
+
+		if !ok {
+			body := instance.body()
+			body["mstp_instance_id"] = instance.Id
+			err = restRequest(ctx, sw, http.MethodPost, "system/mstp_instances", body, nil)
+		} else if current_instance.Priority != instance.Priority || !equalInts(current_instance.Vlans, instance.Vlans) {
+			err = restRequest(ctx, sw, http.MethodPut, mstInstancePath(instance.Id), instance.body(), nil)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for instance_id := range existing {
+		err = restRequest(ctx, sw, http.MethodDelete, mstInstancePath(instance_id), nil, nil)
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delete removes the MST instances and resets the spanning tree
+// configuration to its defaults, which disables spanning tree.
+func (s *spanningTree) Delete(ctx context.Context, sw *aoscxgo.Client) error {
+	disabled := spanningTree{Mode: "mstp", Priority: 8}
+	err := disabled.Update(ctx, sw)
+	if err != nil {
+		return err
+	}
+
+	return restRequest(ctx, sw, http.MethodPatch, "system", map[string]interface{}{
+		"stp_config": map[string]interface{}{},
+	}, nil)
+}
+
+// equalInts reports whether the sorted slices a and b are equal.
+func equalInts(a []int, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for index := range a {
+		if a[index] != b[index] {
+			return false
+		}
+	}
+	return true
+}
+
+// spanningTreeInterface is the spanning tree configuration of a port.
+// Cost 0 derives the path cost from the port speed, PortPriority is the port
+// priority divided by 16.
+type spanningTreeInterface struct {
+	Name string `json:"-"`
+
+	AdminEdge    bool `json:"admin_edge_port_enable"`
+	BpduGuard    bool `json:"bpdu_guard_enable"`
+	RootGuard    bool `json:"root_guard_enable"`
+	LoopGuard    bool `json:"loop_guard_enable"`
+	Cost         int  `json:"path_cost,omitempty"`
+	PortPriority int  `json:"port_priority"`
+}
+
+func (i *spanningTreeInterface) path() string {
+	return "system/interfaces/" + restPath(i.Name)
+}
+
+// Get retrieves the spanning tree configuration of the port.
+func (i *spanningTreeInterface) Get(ctx context.Context, sw *aoscxgo.Client) error {
+	// Attributes missing from stp_config have their default value
+	tmp_interface := struct {
+		StpConfig spanningTreeInterface `json:"stp_config"`
+	}{
+		StpConfig: spanningTreeInterface{Name: i.Name, PortPriority: 8},
+	}
+
+	err := restGet(ctx, sw, i.path()+"?attributes=stp_config", &tmp_interface)
+	if err != nil {
+		return err
+	}
+
+	*i = tmp_interface.StpConfig
+
+	return nil
+}
+
+// Update writes the spanning tree configuration of the port.
+func (i *spanningTreeInterface) Update(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodPatch, i.path(), map[string]interface{}{
+		"stp_config": i,
+	}, nil)
+}
+
+// Delete resets the spanning tree configuration of the port to its
+// defaults.
+func (i *spanningTreeInterface) Delete(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodPatch, i.path(), map[string]interface{}{
+		"stp_config": map[string]interface{}{},
+	}, nil)
+}
@@ -0,0 +1,165 @@
+package aoscx
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/aruba/aoscxgo"
+)
+
+// staticRoute is a static route as stored under
+// system/vrfs/<vrf>/static_routes, aoscxgo does not cover static routes.
+type staticRoute struct {
+	Vrf           string `json:"-"`
+	Prefix        string `json:"prefix,omitempty"`
+	AddressFamily string `json:"address_family,omitempty"`
+	Type          string `json:"type"`
+
+	// NextHops are stored in the static_nexthops child collection
+	NextHops []staticNextHop `json:"-"`
+}
+
+// staticNextHop is an entry of the static_nexthops collection of a route.
+// Type is forward for next-hops with an IP address or interface and
+// blackhole for routes dropping their traffic.
+type staticNextHop struct {
+	Id        int    `json:"id"`
+	IpAddress string `json:"ip_address,omitempty"`
+	Port      string `json:"port,omitempty"`
+	Type      string `json:"type"`
+	Distance  int    `json:"distance"`
+	Tag       int    `json:"tag"`
+}
+
+// key identifies the next-hop regardless of its distance and tag, next-hops
+// with the same key are updated in place.
+func (nh *staticNextHop) key() string {
+	return nh.Type + "," + nh.IpAddress + "," + nh.Port
+}
+
+func (r *staticRoute) path() string {
+	return "system/vrfs/" + restPath(r.Vrf) + "/static_routes/" + restPath(r.Prefix)
+}
+
+func (r *staticRoute) nextHopPath(id int) string {
+	return r.path() + "/static_nexthops/" + strconv.Itoa(id)
+}
+
+// Create creates the route and its next-hops, which are numbered from 0. It
+// returns whether the route itself was created.
+func (r *staticRoute) Create(ctx context.Context, sw *aoscxgo.Client) (bool, error) {
+	err := restRequest(ctx, sw, http.MethodPost, "system/vrfs/"+restPath(r.Vrf)+"/static_routes", r, nil)
+	if err != nil {
+		return false, err
+	}
+
+	for index := range r.NextHops {
+		r.NextHops[index].Id = index
+		err = restRequest(ctx, sw, http.MethodPost, r.path()+"/static_nexthops", r.NextHops[index], nil)
+		if err != nil {
+			return true, err
+		}
+	}
+
+	return true, nil
+}
+
+// Get retrieves the route to r.Prefix in r.Vrf and its next-hops.
+func (r *staticRoute) Get(ctx context.Context, sw *aoscxgo.Client) error {
+	tmp_route := staticRoute{}
+
+	err := restGet(ctx, sw, r.path(), &tmp_route)
+	if err != nil {
+		return err
+	}
+
+	next_hops := map[string]staticNextHop{}
+
+	err = restGet(ctx, sw, r.path()+"/static_nexthops?depth=2", &next_hops)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+
+	r.AddressFamily = tmp_route.AddressFamily
+	r.Type = tmp_route.Type
+	r.NextHops = []staticNextHop{}
+	for _, next_hop := range next_hops {
+		r.NextHops = append(r.NextHops, next_hop)
+	}
+
+	return nil
+}
+
+// Update reconciles the next-hops of the route with r.NextHops. Next-hops
+// that only changed distance or tag are updated in place so traffic is not
+// interrupted.
+func (r *staticRoute) Update(ctx context.Context, sw *aoscxgo.Client) error {
+	current := staticRoute{Vrf: r.Vrf, Prefix: r.Prefix}
+	err := current.Get(ctx, sw)
+	if err != nil {
+		return err
+	}
+
+	existing := map[string]staticNextHop{}
+	used_ids := map[int]bool{}
+	for _, next_hop := range current.NextHops {
+		existing[next_hop.key()] = next_hop
+		used_ids[next_hop.Id] = true
+	}
+
+	var created []staticNextHop
+
+	for index := range r.NextHops {
+		next_hop := &r.NextHops[index]
+
+		current_hop, ok := existing[next_hop.key()]
+		if !ok {
+			created = append(created, *next_hop)
+			continue
+		}
+		delete(existing, next_hop.key())
+
+		next_hop.Id = current_hop.Id
+		if current_hop.Distance != next_hop.Distance || current_hop.Tag != next_hop.Tag {
+			err = restRequest(ctx, sw, http.MethodPatch, r.nextHopPath(next_hop.Id), map[string]interface{}{
+				"distance": next_hop.Distance,
+				"tag":      next_hop.Tag,
+			}, nil)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Next-hops are removed before new ones are added so a route replacing
+	// its blackhole by a forwarding next-hop never holds both
+	for _, next_hop := range existing {
+		err = restRequest(ctx, sw, http.MethodDelete, r.nextHopPath(next_hop.Id), nil, nil)
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+		delete(used_ids, next_hop.Id)
+	}
+
+	next_id := 0
+	for _, next_hop := range created {
+		for used_ids[next_id] {
+			next_id++
+		}
+		next_hop.Id = next_id
+		used_ids[next_id] = true
+
+		err = restRequest(ctx, sw, http.MethodPost, r.path()+"/static_nexthops", next_hop, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delete deletes the route, its next-hops are removed with it.
+func (r *staticRoute) Delete(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodDelete, r.path(), nil, nil)
+}
@@ -0,0 +1,156 @@
+package aoscx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/aruba/aoscxgo"
+)
+
+// subinterface is a routed 802.1Q sub-interface of a physical port,
+// system/interfaces/<parent>.<vlan>, e.g. 1/1/49.100. aoscxgo can only
+// materialize physical ports, so sub-interfaces are configured through the
+// REST API.
+type subinterface struct {
+	Parent      string
+	VlanId      int
+	Description string
+	AdminState  string
+
+	// The first Ipv4 address is the primary address
+	Ipv4 []string
+	Ipv6 []string
+	Vrf  string
+	Mtu  int
+}
+
+// subinterfaceResponse is the REST representation of a sub-interface.
+type subinterfaceResponse struct {
+	Description         string            `json:"description"`
+	AdminState          string            `json:"admin"`
+	SubintfParent       map[string]string `json:"subintf_parent"`
+	SubintfVlan         int               `json:"subintf_vlan"`
+	Ip4Address          string            `json:"ip4_address"`
+	Ip4AddressSecondary []string          `json:"ip4_address_secondary"`
+	Vrf                 string            `json:"vrf"`
+	IpMtu               int               `json:"ip_mtu"`
+}
+
+// subinterfaceName returns the interface name of the sub-interface of parent
+// encapsulating vlan_id, e.g. 1/1/49.100.
+func subinterfaceName(parent string, vlan_id int) string {
+	return parent + "." + strconv.Itoa(vlan_id)
+}
+
+func (s *subinterface) path() string {
+	return "system/interfaces/" + restPath(subinterfaceName(s.Parent, s.VlanId))
+}
+
+// body returns the writable configuration of the sub-interface.
+func (s *subinterface) body() map[string]interface{} {
+	body := map[string]interface{}{
+		"description":           nil,
+		"admin":                 s.AdminState,
+		"ip4_address":           nil,
+		"ip4_address_secondary": []string{},
+		"vrf":                   restURI("system/vrfs/" + restPath(s.Vrf)),
+		"ip_mtu":                s.Mtu,
+	}
+
+	if s.Description != "" {
+		body["description"] = s.Description
+	}
+	if len(s.Ipv4) > 0 {
+		body["ip4_address"] = s.Ipv4[0]
+		body["ip4_address_secondary"] = s.Ipv4[1:]
+	}
+
+	return body
+}
+
+// Create creates the sub-interface on its parent, which must be a routed
+// port. A missing parent is reported as a not found error. It returns whether
+// the sub-interface itself was created.
+func (s *subinterface) Create(ctx context.Context, sw *aoscxgo.Client) (bool, error) {
+	parent := struct {
+		Routing bool `json:"routing"`
+	}{}
+	err := restGet(ctx, sw, "system/interfaces/"+restPath(s.Parent)+"?attributes=routing", &parent)
+	if err != nil {
+		return false, err
+	}
+	if !parent.Routing {
+		return false, fmt.Errorf("parent interface %s is not a routed port", s.Parent)
+	}
+
+	body := s.body()
+	body["name"] = subinterfaceName(s.Parent, s.VlanId)
+	body["type"] = "vlan"
+	body["subintf_parent"] = map[string]string{
+		s.Parent: restURI("system/interfaces/" + restPath(s.Parent)),
+	}
+	body["subintf_vlan"] = s.VlanId
+
+	err = restRequest(ctx, sw, http.MethodPost, "system/interfaces", body, nil)
+	if err != nil {
+		return false, err
+	}
+
+	return true, updateIp6Addresses(ctx, sw, s.path(), nil, s.Ipv6)
+}
+
+// Get retrieves the sub-interface of s.Parent encapsulating s.VlanId.
+func (s *subinterface) Get(ctx context.Context, sw *aoscxgo.Client) error {
+	tmp_subinterface := subinterfaceResponse{
+		IpMtu: 1500,
+	}
+
+	err := restGet(ctx, sw, s.path(), &tmp_subinterface)
+	if err != nil {
+		return err
+	}
+
+	s.Description = tmp_subinterface.Description
+	s.AdminState = tmp_subinterface.AdminState
+	if s.AdminState == "" {
+		s.AdminState = "down"
+	}
+
+	s.Ipv4 = []string{}
+	if tmp_subinterface.Ip4Address != "" {
+		s.Ipv4 = append([]string{tmp_subinterface.Ip4Address}, tmp_subinterface.Ip4AddressSecondary...)
+	}
+
+	s.Vrf = "default"
+	if tmp_subinterface.Vrf != "" {
+		s.Vrf = uriKey(tmp_subinterface.Vrf)
+	}
+	s.Mtu = tmp_subinterface.IpMtu
+
+	s.Ipv6, err = getIp6Addresses(ctx, sw, s.path())
+
+	return err
+}
+
+// Update writes the sub-interface configuration and reconciles its IPv6
+// addresses.
+func (s *subinterface) Update(ctx context.Context, sw *aoscxgo.Client) error {
+	current, err := getIp6Addresses(ctx, sw, s.path())
+	if err != nil {
+		return err
+	}
+
+	err = restRequest(ctx, sw, http.MethodPatch, s.path(), s.body(), nil)
+	if err != nil {
+		return err
+	}
+
+	return updateIp6Addresses(ctx, sw, s.path(), current, s.Ipv6)
+}
+
+// Delete deletes the sub-interface.
+func (s *subinterface) Delete(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodDelete, s.path(), nil, nil)
+}
@@ -0,0 +1,150 @@
+package aoscx
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// tlsConfigFromProvider builds the TLS configuration used to talk to the
+// switch from the provider arguments.
+func tlsConfigFromProvider(d *schema.ResourceData) (*tls.Config, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	tls_config := &tls.Config{
+		InsecureSkipVerify: d.Get("insecure").(bool),
+		ServerName:         d.Get("tls_server_name").(string),
+	}
+
+	ca_pem := []byte(d.Get("ca_cert_pem").(string))
+
+	if ca_file := d.Get("ca_cert_file").(string); ca_file != "" {
+		file_pem, err := os.ReadFile(ca_file)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Unable to read CA certificate file",
+				Detail:        fmt.Sprintf("Error reading %s: %v", ca_file, err),
+				AttributePath: cty.GetAttrPath("ca_cert_file"),
+			})
+			return nil, diags
+		}
+		ca_pem = append(ca_pem, '\n')
+		ca_pem = append(ca_pem, file_pem...)
+	}
+
+	if len(strings.TrimSpace(string(ca_pem))) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca_pem) {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Invalid CA certificate bundle",
+				Detail:   "No PEM encoded certificates could be parsed from ca_cert_file/ca_cert_pem",
+			})
+			return nil, diags
+		}
+		tls_config.RootCAs = pool
+	}
+
+	client_cert := d.Get("client_cert").(string)
+	client_key := d.Get("client_key").(string)
+
+	if client_cert != "" || client_key != "" {
+		if client_cert == "" || client_key == "" {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Incomplete client certificate configuration",
+				Detail:   "client_cert and client_key must be set together to use mutual TLS",
+			})
+			return nil, diags
+		}
+
+		cert_pem, err := pemFromValue(client_cert)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Unable to read client certificate",
+				Detail:        err.Error(),
+				AttributePath: cty.GetAttrPath("client_cert"),
+			})
+			return nil, diags
+		}
+
+		key_pem, err := pemFromValue(client_key)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Unable to read client key",
+				Detail:        err.Error(),
+				AttributePath: cty.GetAttrPath("client_key"),
+			})
+			return nil, diags
+		}
+
+		cert, err := tls.X509KeyPair(cert_pem, key_pem)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Invalid client certificate or key",
+				Detail:   err.Error(),
+			})
+			return nil, diags
+		}
+		tls_config.Certificates = []tls.Certificate{cert}
+	}
+
+	return tls_config, diags
+}
+
+// pemFromValue returns value itself when it holds PEM encoded data, otherwise
+// value is treated as the path of a PEM file.
+func pemFromValue(value string) ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN") {
+		return []byte(value), nil
+	}
+
+	pem, err := os.ReadFile(value)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading %s: %v", value, err)
+	}
+
+	return pem, nil
+}
+
+// tlsErrorDiagnostic turns certificate verification failures into a
+// diagnostic explaining how to trust the switch certificate. It returns nil
+// for any other error.
+func tlsErrorDiagnostic(hostname string, err error) *diag.Diagnostic {
+	var unknown_authority x509.UnknownAuthorityError
+	var hostname_error x509.HostnameError
+	var invalid_cert x509.CertificateInvalidError
+
+	var detail string
+
+	switch {
+	case errors.As(err, &unknown_authority), strings.Contains(err.Error(), "certificate signed by unknown authority"):
+		detail = fmt.Sprintf("The certificate presented by %s is not signed by a trusted CA. "+
+			"Set ca_cert_file or ca_cert_pem to the CA that issued the switch certificate, "+
+			"or set insecure = true to skip verification.", hostname)
+	case errors.As(err, &hostname_error), strings.Contains(err.Error(), "certificate is valid for"):
+		detail = fmt.Sprintf("The certificate presented by %s does not match the hostname. "+
+			"Set tls_server_name to a name contained in the certificate.", hostname)
+	case errors.As(err, &invalid_cert), strings.Contains(err.Error(), "x509:"):
+		detail = fmt.Sprintf("The certificate presented by %s is invalid.", hostname)
+	default:
+		return nil
+	}
+
+	return &diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  "TLS certificate verification failed",
+		Detail:   fmt.Sprintf("%s\n\n%v", detail, err),
+	}
+}
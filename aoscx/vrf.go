@@ -0,0 +1,129 @@
+package aoscx
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aruba/aoscxgo"
+)
+
+// vrf is a VRF as stored under system/vrfs, aoscxgo does not cover VRFs.
+type vrf struct {
+	Name     string `json:"name,omitempty"`
+	Rd       string `json:"rd,omitempty"`
+	Shutdown bool   `json:"shutdown"`
+
+	// AddressFamilies holds the route targets keyed by address family, e.g.
+	// ipv4-unicast. They are stored in the vrf_address_families child
+	// collection.
+	AddressFamilies map[string]vrfAddressFamily `json:"-"`
+}
+
+// vrfAddressFamily is an entry of system/vrfs/<name>/vrf_address_families.
+type vrfAddressFamily struct {
+	AddressFamily      string   `json:"address_family,omitempty"`
+	ExportRouteTargets []string `json:"export_route_targets"`
+	ImportRouteTargets []string `json:"import_route_targets"`
+}
+
+func (v *vrf) path() string {
+	return "system/vrfs/" + restPath(v.Name)
+}
+
+func (v *vrf) familyPath(family string) string {
+	return v.path() + "/vrf_address_families/" + restPath(family)
+}
+
+// Create creates the VRF and its address families. It returns whether the
+// VRF itself was created, which is also the case when an address family
+// failed.
+func (v *vrf) Create(ctx context.Context, sw *aoscxgo.Client) (bool, error) {
+	err := restRequest(ctx, sw, http.MethodPost, "system/vrfs", v, nil)
+	if err != nil {
+		return false, err
+	}
+
+	for family, tmp_family := range v.AddressFamilies {
+		tmp_family.AddressFamily = family
+		err = restRequest(ctx, sw, http.MethodPost, v.path()+"/vrf_address_families", tmp_family, nil)
+		if err != nil {
+			return true, err
+		}
+	}
+
+	return true, nil
+}
+
+// Get retrieves the VRF named v.Name and its address families.
+func (v *vrf) Get(ctx context.Context, sw *aoscxgo.Client) error {
+	tmp_vrf := vrf{}
+
+	err := restGet(ctx, sw, v.path(), &tmp_vrf)
+	if err != nil {
+		return err
+	}
+
+	families := map[string]vrfAddressFamily{}
+
+	err = restGet(ctx, sw, v.path()+"/vrf_address_families?depth=2", &families)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+
+	v.Rd = tmp_vrf.Rd
+	v.Shutdown = tmp_vrf.Shutdown
+	v.AddressFamilies = map[string]vrfAddressFamily{}
+	for _, tmp_family := range families {
+		v.AddressFamilies[tmp_family.AddressFamily] = tmp_family
+	}
+
+	return nil
+}
+
+// Update writes the VRF settings and reconciles its address families with
+// v.AddressFamilies.
+func (v *vrf) Update(ctx context.Context, sw *aoscxgo.Client) error {
+	err := restRequest(ctx, sw, http.MethodPatch, v.path(), map[string]interface{}{
+		"rd":       v.Rd,
+		"shutdown": v.Shutdown,
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	current := vrf{Name: v.Name}
+	err = current.Get(ctx, sw)
+	if err != nil {
+		return err
+	}
+
+	for family := range current.AddressFamilies {
+		if _, ok := v.AddressFamilies[family]; !ok {
+			err = restRequest(ctx, sw, http.MethodDelete, v.familyPath(family), nil, nil)
+			if err != nil && !isNotFound(err) {
+				return err
+			}
+		}
+	}
+
+	for family, tmp_family := range v.AddressFamilies {
+		if _, ok := current.AddressFamilies[family]; ok {
+			// The key is not writable, it is omitted from PUT bodies
+			tmp_family.AddressFamily = ""
+			err = restRequest(ctx, sw, http.MethodPut, v.familyPath(family), tmp_family, nil)
+		} else {
+			tmp_family.AddressFamily = family
+			err = restRequest(ctx, sw, http.MethodPost, v.path()+"/vrf_address_families", tmp_family, nil)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delete deletes the VRF, its address families are removed with it.
+func (v *vrf) Delete(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodDelete, v.path(), nil, nil)
+}
@@ -0,0 +1,47 @@
+package aoscx
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	"github.com/aruba/aoscxgo"
+)
+
+// vsxPath is the VSX configuration of a switch, there is at most one.
+const vsxPath = "system/vsx"
+
+// vsx is the VSX configuration of one switch of a VSX pair, aoscxgo does not
+// cover VSX.
+type vsx struct {
+	Role               string   `json:"device_role"`
+	IslPort            string   `json:"isl_port"`
+	KeepalivePeer      string   `json:"keepalive_peer_ip"`
+	KeepaliveSource    string   `json:"keepalive_src_ip"`
+	KeepaliveVrf       string   `json:"keepalive_vrf"`
+	SystemMac          string   `json:"system_mac"`
+	ConfigSyncDisable  bool     `json:"config_sync_disable"`
+	ConfigSyncFeatures []string `json:"config_sync_features"`
+}
+
+// Get retrieves the VSX configuration of the switch.
+func (v *vsx) Get(ctx context.Context, sw *aoscxgo.Client) error {
+	err := restGet(ctx, sw, vsxPath, v)
+	if err != nil {
+		return err
+	}
+
+	sort.Strings(v.ConfigSyncFeatures)
+
+	return nil
+}
+
+// Put creates or replaces the VSX configuration of the switch.
+func (v *vsx) Put(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodPut, vsxPath, v, nil)
+}
+
+// Delete removes VSX from the switch.
+func (v *vsx) Delete(ctx context.Context, sw *aoscxgo.Client) error {
+	return restRequest(ctx, sw, http.MethodDelete, vsxPath, nil, nil)
+}
@@ -12,4 +12,7 @@ func main() {
 			return aoscx.Provider()
 		},
 	})
+
+	// Log out of the switches once Terraform stops the plugin
+	aoscx.CloseSessions()
 }